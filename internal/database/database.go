@@ -31,6 +31,9 @@ type Service interface {
 	// CreateTeam creates a new team in the database
 	CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error)
 
+	// CreateTeams creates several teams in one transaction, so either all of them are created or none
+	CreateTeams(ctx context.Context, reqs []models.CreateTeamRequest) ([]*models.Team, error)
+
 	// GetAllTeams retrieves all teams from the database
 	GetAllTeams(ctx context.Context) ([]*models.Team, error)
 
@@ -43,42 +46,128 @@ type Service interface {
 	// DeleteTeam deletes a team from the database
 	DeleteTeam(ctx context.Context, teamID int) error
 
+	// GetLeaguesForTeam retrieves all leagues a team belongs to with its standing position in each
+	GetLeaguesForTeam(ctx context.Context, teamID int) ([]models.TeamLeague, error)
+
+	// GetHeadToHead retrieves played matches between two teams, most recent first.
+	// A leagueID of 0 searches across all leagues.
+	GetHeadToHead(ctx context.Context, teamID, opponentID, leagueID, limit int) ([]*models.Match, error)
+
+	// AddRivalry registers two teams as rivals; registering an existing rivalry again is a no-op
+	AddRivalry(ctx context.Context, teamID, rivalID int) error
+
+	// GetRivals retrieves the teams registered as rivals of a team, ordered by name
+	GetRivals(ctx context.Context, teamID int) ([]*models.Team, error)
+
+	// IsRivalry reports whether two teams are registered rivals
+	IsRivalry(ctx context.Context, teamID, rivalID int) (bool, error)
+
 	// CreateLeague creates a new league in the database
 	CreateLeague(ctx context.Context, req *models.CreateLeagueRequest) (*models.League, error)
 
-	// AddTeamToLeague adds a team to a league
+	// CreateLeagueWithIdempotencyKey creates a league once per key, returning the original league on repeats
+	CreateLeagueWithIdempotencyKey(ctx context.Context, key string, req *models.CreateLeagueRequest) (*models.League, bool, error)
+
+	// AddTeamToLeague adds a team to a league, failing if the team is already in it
 	AddTeamToLeague(ctx context.Context, leagueID, teamID int) error
 
 	// InitializeStanding creates initial standing entry for a team in a league
 	InitializeStanding(ctx context.Context, leagueID, teamID int) error
 
+	// InitializeLeagueWithTeams creates a league with the given teams and their standings in one transaction
+	InitializeLeagueWithTeams(ctx context.Context, req *models.CreateLeagueRequest, teams []*models.Team) (*models.League, error)
+
+	// CloneLeague creates a new league with the same teams as an existing one, without matches or results
+	CloneLeague(ctx context.Context, sourceLeagueID int, name string) (*models.League, error)
+
+	// ImportLeague creates a league with new teams and matches from an imported document in one transaction
+	ImportLeague(ctx context.Context, req *models.ImportLeagueRequest) (*models.League, []*models.Team, error)
+
 	// GetDefaultTeams retrieves the 4 default teams for league initialization
 	GetDefaultTeams(ctx context.Context) ([]*models.Team, error)
 
 	// GetLeagueByID retrieves a league by its ID
 	GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error)
 
+	// UpdateLeagueConfig saves a league's settings while it is still in "created" status
+	UpdateLeagueConfig(ctx context.Context, leagueID int, config models.LeagueConfig) error
+
+	// GetAllLeagues retrieves all leagues ordered by ID, optionally filtered by status
+	GetAllLeagues(ctx context.Context, status string) ([]*models.League, error)
+
+	// GetLeagueSummary counts leagues by status and the total number of teams
+	GetLeagueSummary(ctx context.Context) (*models.LeagueSummary, error)
+
+	// RecordLeagueEvent adds an entry to a league's audit log
+	RecordLeagueEvent(ctx context.Context, leagueID int, eventType, details string) error
+
+	// GetLeagueEvents retrieves a league's audit log, oldest event first
+	GetLeagueEvents(ctx context.Context, leagueID int) ([]models.LeagueEvent, error)
+
+	// RecordSimulationRun saves a play-all run and returns it with its ID and creation time
+	RecordSimulationRun(ctx context.Context, run models.SimulationRun) (*models.SimulationRun, error)
+
+	// GetSimulationRuns retrieves a league's play-all runs, oldest first
+	GetSimulationRuns(ctx context.Context, leagueID int) ([]models.SimulationRun, error)
+
+	// GetLeaderboard sums each team's points and wins across finished leagues, best first
+	GetLeaderboard(ctx context.Context, limit int) ([]models.LeaderboardEntry, error)
+
+	// DeleteFinishedLeagues deletes the finished leagues that finished before a time, with their
+	// matches, standings and events, and returns their IDs
+	DeleteFinishedLeagues(ctx context.Context, finishedBefore time.Time) ([]int, error)
+
 	// RemoveTeamFromLeague removes a team from a league
 	RemoveTeamFromLeague(ctx context.Context, leagueID, teamID int) error
 
 	// GetTeamsInLeague retrieves all teams that are part of a specific league
 	GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error)
 
+	// GetTeamsNotInLeague retrieves all teams that are not part of a specific league
+	GetTeamsNotInLeague(ctx context.Context, leagueID int) ([]*models.Team, error)
+
+	// SetTeamGroup assigns a team to a group within a league; an empty group removes it from its group
+	SetTeamGroup(ctx context.Context, leagueID, teamID int, group string) error
+
+	// GetTeamGroups retrieves the group of every team in a league that has been assigned one, keyed by team ID
+	GetTeamGroups(ctx context.Context, leagueID int) (map[int]string, error)
+
 	// CreateMatch creates a new match in the database
 	CreateMatch(ctx context.Context, match *models.Match) (*models.Match, error)
 
+	// ReplaceScheduledMatches deletes a league's scheduled matches and creates the given ones in their place
+	ReplaceScheduledMatches(ctx context.Context, leagueID int, matches []models.Match) ([]*models.Match, error)
+
+	// GetVenueCounts counts a team's scheduled and played fixtures in a league at home and away
+	GetVenueCounts(ctx context.Context, leagueID, teamID int) (home, away int, err error)
+
+	// GetHomeAwaySplit totals home wins, away wins, draws and goals by venue over a league's played matches
+	GetHomeAwaySplit(ctx context.Context, leagueID int) (*models.HomeAwaySplit, error)
+
 	// UpdateLeagueStatus updates the status of a league
 	UpdateLeagueStatus(ctx context.Context, leagueID int, status string) error
 
+	// TransitionLeagueStatus moves a league from one status to another, failing if it is not in the expected status
+	TransitionLeagueStatus(ctx context.Context, leagueID int, fromStatus, toStatus string) error
+
 	// GetMatchesByWeekAndLeague retrieves matches for a specific league and week
 	GetMatchesByWeekAndLeague(ctx context.Context, leagueID, week int) ([]*models.Match, error)
 
+	// GetMatchesByKickoffRange retrieves a league's matches kicking off in [from, to), in chronological order
+	GetMatchesByKickoffRange(ctx context.Context, leagueID int, from, to time.Time) ([]*models.Match, error)
+
+	// GetMatchesByLeague retrieves all matches of a league, optionally filtered by status
+	GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error)
+
 	// PlayMatch updates a match with results and marks it as played
 	PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals int) error
 
-	// UpdateStandings updates team standings after a match
+	// UpdateStandings updates team standings after a match, creating a missing standings row for a team in the league
 	UpdateStandings(ctx context.Context, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error
 
+	// AddStandingPoints adds points to a team's standing without recording a match, such as for a bye
+	AddStandingPoints(ctx context.Context, leagueID, teamID, points int) error
+
 	// AdvanceLeagueWeek increments the current week of a league
 	AdvanceLeagueWeek(ctx context.Context, leagueID int) error
 
@@ -88,6 +177,12 @@ type Service interface {
 	// GetMatchByID retrieves a match by its ID
 	GetMatchByID(ctx context.Context, matchID int) (*models.Match, error)
 
+	// UpdateMatchStatus moves a match from one status to another, failing if it is not in the expected status
+	UpdateMatchStatus(ctx context.Context, matchID int, fromStatus, toStatus string) error
+
+	// SwapMatchVenue swaps the home and away teams of a scheduled match
+	SwapMatchVenue(ctx context.Context, matchID int) error
+
 	// EditMatch updates match result and recalculates standings
 	EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayGoals int) error
 }
@@ -179,5 +274,10 @@ func (s *service) Health() map[string]string {
 // If an error occurs while closing the connection, it returns the error.
 func (s *service) Close() error {
 	log.Printf("Disconnected from database: %s", database)
+	// Drop the shared instance so a later New opens a fresh connection pool
+	if dbInstance == s {
+		dbInstance = nil
+	}
+	// sql.DB.Close waits for queries that have started to finish before closing the pool
 	return s.db.Close()
 }
@@ -2,13 +2,18 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+
+	"insider-league-manager/internal/models"
 )
 
 func mustStartPostgresContainer() (func(context.Context, ...testcontainers.TerminateOption) error, error) {
@@ -91,6 +96,757 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestEditMatch_ConcurrentEditsSharingATeam(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Concurrent Edits"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 3; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Concurrent %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		if err := srv.InitializeStanding(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to initialize standing: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	// The shared team hosts both matches and starts with a draw in each
+	shared := teamIDs[0]
+	var matchIDs []int
+	for _, opponent := range teamIDs[1:] {
+		match, err := srv.CreateMatch(ctx, &models.Match{LeagueID: league.ID, HomeTeamID: shared, AwayTeamID: opponent, Week: 1, Status: "scheduled"})
+		if err != nil {
+			t.Fatalf("failed to create match: %v", err)
+		}
+		if err := srv.PlayMatch(ctx, match.ID, 1, 1); err != nil {
+			t.Fatalf("failed to play match: %v", err)
+		}
+		if err := srv.UpdateStandings(ctx, league.ID, shared, opponent, 1, 1); err != nil {
+			t.Fatalf("failed to update standings: %v", err)
+		}
+		matchIDs = append(matchIDs, match.ID)
+	}
+
+	// Edit both matches at the same time: the shared team wins 2-0 and loses 0-3
+	edits := [][2]int{{2, 0}, {0, 3}}
+	var wg sync.WaitGroup
+	errs := make([]error, len(matchIDs))
+	for i, matchID := range matchIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = srv.EditMatch(ctx, matchID, edits[i][0], edits[i][1])
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to edit match: %v", err)
+		}
+	}
+
+	standings, err := srv.GetStandings(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get standings: %v", err)
+	}
+
+	// Applying the edits one after the other gives these rows
+	expected := map[int]models.Standing{
+		teamIDs[0]: {Played: 2, Wins: 1, Losses: 1, Points: 3, GoalsFor: 2, GoalsAgainst: 3, GoalDifference: -1},
+		teamIDs[1]: {Played: 1, Losses: 1, Points: 0, GoalsFor: 0, GoalsAgainst: 2, GoalDifference: -2},
+		teamIDs[2]: {Played: 1, Wins: 1, Points: 3, GoalsFor: 3, GoalsAgainst: 0, GoalDifference: 3},
+	}
+
+	for _, standing := range standings {
+		want := expected[standing.TeamID]
+		want.LeagueID, want.TeamID = league.ID, standing.TeamID
+		if standing.Standing != want {
+			t.Errorf("team %d: expected standing %+v, got %+v", standing.TeamID, want, standing.Standing)
+		}
+	}
+}
+
+func TestUpdateStandings_CleanSheetBonus(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	bonus := 1
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Clean Sheets", CleanSheetBonus: &bonus})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Clean Sheet %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		if err := srv.InitializeStanding(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to initialize standing: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	match, err := srv.CreateMatch(ctx, &models.Match{LeagueID: league.ID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1], Week: 1, Status: "scheduled"})
+	if err != nil {
+		t.Fatalf("failed to create match: %v", err)
+	}
+	if err := srv.PlayMatch(ctx, match.ID, 1, 0); err != nil {
+		t.Fatalf("failed to play match: %v", err)
+	}
+	if err := srv.UpdateStandings(ctx, league.ID, teamIDs[0], teamIDs[1], 1, 0); err != nil {
+		t.Fatalf("failed to update standings: %v", err)
+	}
+
+	points := func() map[int]int {
+		t.Helper()
+		standings, err := srv.GetStandings(ctx, league.ID)
+		if err != nil {
+			t.Fatalf("failed to get standings: %v", err)
+		}
+		byTeam := make(map[int]int)
+		for _, standing := range standings {
+			byTeam[standing.TeamID] = standing.Points
+		}
+		return byTeam
+	}
+
+	// A 1-0 win is worth 3 points plus the clean sheet bonus
+	if got := points(); got[teamIDs[0]] != 4 || got[teamIDs[1]] != 0 {
+		t.Errorf("expected 4 and 0 points after a 1-0 win, got %v", got)
+	}
+
+	// Editing to 1-1 removes the bonus along with the win
+	if err := srv.EditMatch(ctx, match.ID, 1, 1); err != nil {
+		t.Fatalf("failed to edit match: %v", err)
+	}
+	if got := points(); got[teamIDs[0]] != 1 || got[teamIDs[1]] != 1 {
+		t.Errorf("expected 1 point each after editing to 1-1, got %v", got)
+	}
+
+	// A goalless draw earns both sides the bonus
+	if err := srv.EditMatch(ctx, match.ID, 0, 0); err != nil {
+		t.Fatalf("failed to edit match: %v", err)
+	}
+	if got := points(); got[teamIDs[0]] != 2 || got[teamIDs[1]] != 2 {
+		t.Errorf("expected 2 points each after editing to 0-0, got %v", got)
+	}
+}
+
+func TestUpdateStandings_BigWinBonus(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	margin, bonus := 3, 1
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Big Wins", BigWinMargin: &margin, BigWinBonus: &bonus})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Big Win %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		if err := srv.InitializeStanding(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to initialize standing: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	match, err := srv.CreateMatch(ctx, &models.Match{LeagueID: league.ID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1], Week: 1, Status: "scheduled"})
+	if err != nil {
+		t.Fatalf("failed to create match: %v", err)
+	}
+	if err := srv.PlayMatch(ctx, match.ID, 3, 0); err != nil {
+		t.Fatalf("failed to play match: %v", err)
+	}
+	if err := srv.UpdateStandings(ctx, league.ID, teamIDs[0], teamIDs[1], 3, 0); err != nil {
+		t.Fatalf("failed to update standings: %v", err)
+	}
+
+	points := func() map[int]int {
+		t.Helper()
+		standings, err := srv.GetStandings(ctx, league.ID)
+		if err != nil {
+			t.Fatalf("failed to get standings: %v", err)
+		}
+		byTeam := make(map[int]int)
+		for _, standing := range standings {
+			byTeam[standing.TeamID] = standing.Points
+		}
+		return byTeam
+	}
+
+	// A 3-0 win meets the margin, so it is worth 3 points plus the bonus
+	if got := points(); got[teamIDs[0]] != 4 || got[teamIDs[1]] != 0 {
+		t.Errorf("expected 4 and 0 points after a 3-0 win, got %v", got)
+	}
+
+	// Editing to 1-0 removes the bonus but keeps the win
+	if err := srv.EditMatch(ctx, match.ID, 1, 0); err != nil {
+		t.Fatalf("failed to edit match: %v", err)
+	}
+	if got := points(); got[teamIDs[0]] != 3 || got[teamIDs[1]] != 0 {
+		t.Errorf("expected 3 and 0 points after editing to 1-0, got %v", got)
+	}
+
+	// The bonus goes to the winner whichever side it is
+	if err := srv.EditMatch(ctx, match.ID, 0, 4); err != nil {
+		t.Fatalf("failed to edit match: %v", err)
+	}
+	if got := points(); got[teamIDs[0]] != 0 || got[teamIDs[1]] != 4 {
+		t.Errorf("expected 0 and 4 points after editing to 0-4, got %v", got)
+	}
+}
+
+func TestUpdateStandings_MissingStandingRow(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Drift"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	// Both teams are in the league but only the first has a standings row
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Drift %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+	if err := srv.InitializeStanding(ctx, league.ID, teamIDs[0]); err != nil {
+		t.Fatalf("failed to initialize standing: %v", err)
+	}
+
+	if err := srv.UpdateStandings(ctx, league.ID, teamIDs[0], teamIDs[1], 1, 2); err != nil {
+		t.Fatalf("failed to update standings: %v", err)
+	}
+
+	standings, err := srv.GetStandings(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get standings: %v", err)
+	}
+	if len(standings) != 2 {
+		t.Fatalf("expected a standing for both teams, got %d", len(standings))
+	}
+
+	expected := map[int]models.Standing{
+		teamIDs[0]: {Played: 1, Losses: 1, GoalsFor: 1, GoalsAgainst: 2, GoalDifference: -1},
+		teamIDs[1]: {Played: 1, Wins: 1, Points: 3, GoalsFor: 2, GoalsAgainst: 1, GoalDifference: 1},
+	}
+	for _, standing := range standings {
+		want := expected[standing.TeamID]
+		want.LeagueID, want.TeamID = league.ID, standing.TeamID
+		if standing.Standing != want {
+			t.Errorf("team %d: expected standing %+v, got %+v", standing.TeamID, want, standing.Standing)
+		}
+	}
+}
+
+func TestEditMatch_MarksEdited(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Edited Results"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Edited %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		if err := srv.InitializeStanding(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to initialize standing: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	match, err := srv.CreateMatch(ctx, &models.Match{LeagueID: league.ID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1], Week: 1, Status: "scheduled"})
+	if err != nil {
+		t.Fatalf("failed to create match: %v", err)
+	}
+	if err := srv.PlayMatch(ctx, match.ID, 2, 1); err != nil {
+		t.Fatalf("failed to play match: %v", err)
+	}
+	if err := srv.UpdateStandings(ctx, league.ID, teamIDs[0], teamIDs[1], 2, 1); err != nil {
+		t.Fatalf("failed to update standings: %v", err)
+	}
+
+	played, err := srv.GetMatchByID(ctx, match.ID)
+	if err != nil {
+		t.Fatalf("failed to get match: %v", err)
+	}
+	if played.Edited || played.UpdatedAt != nil {
+		t.Errorf("expected a freshly played match not to be edited, got %+v", played)
+	}
+
+	if err := srv.EditMatch(ctx, match.ID, 2, 2); err != nil {
+		t.Fatalf("failed to edit match: %v", err)
+	}
+	edited, err := srv.GetMatchByID(ctx, match.ID)
+	if err != nil {
+		t.Fatalf("failed to get match: %v", err)
+	}
+	if !edited.Edited || edited.UpdatedAt == nil {
+		t.Errorf("expected the match to be edited after EditMatch, got %+v", edited)
+	}
+}
+
+func TestGetLeaderboard_AggregatesFinishedLeagues(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Leaderboard %d %d", time.Now().UnixNano(), i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	// Team 0 wins the first finished league, team 1 wins the second more heavily,
+	// and team 0's big wins in an unfinished league must not count
+	seasons := []struct {
+		status string
+		scores [][2]int // home (team 0) goals, away (team 1) goals
+	}{
+		{"finished", [][2]int{{2, 0}, {1, 1}}},
+		{"finished", [][2]int{{0, 1}, {0, 2}, {0, 0}}},
+		{"started", [][2]int{{5, 0}, {4, 0}}},
+	}
+	for i, season := range seasons {
+		league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: fmt.Sprintf("Leaderboard Season %d", i)})
+		if err != nil {
+			t.Fatalf("failed to create league: %v", err)
+		}
+		for _, teamID := range teamIDs {
+			if err := srv.AddTeamToLeague(ctx, league.ID, teamID); err != nil {
+				t.Fatalf("failed to add team to league: %v", err)
+			}
+			if err := srv.InitializeStanding(ctx, league.ID, teamID); err != nil {
+				t.Fatalf("failed to initialize standing: %v", err)
+			}
+		}
+		for _, score := range season.scores {
+			if err := srv.UpdateStandings(ctx, league.ID, teamIDs[0], teamIDs[1], score[0], score[1]); err != nil {
+				t.Fatalf("failed to update standings: %v", err)
+			}
+		}
+		if err := srv.UpdateLeagueStatus(ctx, league.ID, season.status); err != nil {
+			t.Fatalf("failed to update league status: %v", err)
+		}
+	}
+
+	entries, err := srv.GetLeaderboard(ctx, 1000)
+	if err != nil {
+		t.Fatalf("failed to get leaderboard: %v", err)
+	}
+
+	// Team 1: 1 + 7 points with 2 wins; team 0: 4 + 1 points with 1 win
+	byTeam := make(map[int]models.LeaderboardEntry)
+	for _, entry := range entries {
+		byTeam[entry.TeamID] = entry
+	}
+	first, second := byTeam[teamIDs[1]], byTeam[teamIDs[0]]
+	if first.Points != 8 || first.Wins != 2 || first.Leagues != 2 {
+		t.Errorf("expected 8 points and 2 wins over 2 leagues, got %+v", first)
+	}
+	if second.Points != 5 || second.Wins != 1 || second.Leagues != 2 {
+		t.Errorf("expected 5 points and 1 win over 2 leagues, got %+v", second)
+	}
+	if first.Rank == 0 || second.Rank == 0 || first.Rank >= second.Rank {
+		t.Errorf("expected team 1 to rank above team 0, got ranks %d and %d", first.Rank, second.Rank)
+	}
+}
+
+func TestCreateTeams(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	teams, err := srv.CreateTeams(ctx, []models.CreateTeamRequest{
+		{Name: "Imported Rovers", Strength: 72, PrimaryColor: "#1D428A"},
+		{Name: "Imported City", Strength: 64},
+	})
+	if err != nil {
+		t.Fatalf("failed to create teams: %v", err)
+	}
+	if len(teams) != 2 || teams[0].ID == teams[1].ID {
+		t.Fatalf("expected 2 distinct teams, got %+v", teams)
+	}
+
+	stored, err := srv.GetTeamByID(ctx, teams[0].ID)
+	if err != nil {
+		t.Fatalf("failed to get imported team: %v", err)
+	}
+	if stored.Name != "Imported Rovers" || stored.Strength != 72 || stored.PrimaryColor != "#1D428A" {
+		t.Errorf("unexpected imported team: %+v", stored)
+	}
+}
+
+func TestGetTeamsNotInLeague(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Available Teams"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 3; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Available %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+	if err := srv.AddTeamToLeague(ctx, league.ID, teamIDs[0]); err != nil {
+		t.Fatalf("failed to add team to league: %v", err)
+	}
+
+	teams, err := srv.GetTeamsNotInLeague(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get teams not in league: %v", err)
+	}
+
+	available := make(map[int]bool)
+	for _, team := range teams {
+		available[team.ID] = true
+	}
+	if available[teamIDs[0]] {
+		t.Errorf("expected team %d in the league to be excluded", teamIDs[0])
+	}
+	if !available[teamIDs[1]] || !available[teamIDs[2]] {
+		t.Errorf("expected teams %d and %d outside the league to be included", teamIDs[1], teamIDs[2])
+	}
+}
+
+func TestTeamGroups(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Grouped League"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 3; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Grouped %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+			t.Fatalf("failed to add team to league: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	if err := srv.SetTeamGroup(ctx, league.ID, teamIDs[0], "A"); err != nil {
+		t.Fatalf("failed to set team group: %v", err)
+	}
+	if err := srv.SetTeamGroup(ctx, league.ID, teamIDs[1], "B"); err != nil {
+		t.Fatalf("failed to set team group: %v", err)
+	}
+	if err := srv.SetTeamGroup(ctx, league.ID, -1, "A"); err == nil {
+		t.Error("expected an error for a team outside the league")
+	}
+
+	groups, err := srv.GetTeamGroups(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get team groups: %v", err)
+	}
+	if len(groups) != 2 || groups[teamIDs[0]] != "A" || groups[teamIDs[1]] != "B" {
+		t.Errorf("expected groups A and B for the first two teams only, got %v", groups)
+	}
+
+	// An empty group removes the team from its group
+	if err := srv.SetTeamGroup(ctx, league.ID, teamIDs[0], ""); err != nil {
+		t.Fatalf("failed to clear team group: %v", err)
+	}
+	groups, err = srv.GetTeamGroups(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get team groups: %v", err)
+	}
+	if _, ok := groups[teamIDs[0]]; ok {
+		t.Errorf("expected team %d to have no group, got %v", teamIDs[0], groups)
+	}
+}
+
+func TestAddTeamToLeague_AlreadyInLeague(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Re-add League"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+	team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Re-add %d", league.ID), Strength: 50})
+	if err != nil {
+		t.Fatalf("failed to create team: %v", err)
+	}
+
+	if err := srv.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
+		t.Fatalf("failed to add team to league: %v", err)
+	}
+	err = srv.AddTeamToLeague(ctx, league.ID, team.ID)
+	if err == nil || !strings.Contains(err.Error(), "already in league") {
+		t.Errorf("Expected an already in league error, got %v", err)
+	}
+}
+
+func TestGetHomeAwaySplit(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Split League"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	var teamIDs []int
+	for i := 0; i < 2; i++ {
+		team, err := srv.CreateTeam(ctx, &models.CreateTeamRequest{Name: fmt.Sprintf("Split %d %d", league.ID, i), Strength: 50})
+		if err != nil {
+			t.Fatalf("failed to create team: %v", err)
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	// A home win, an away win and a draw are played; a fourth match stays scheduled
+	results := [][2]int{{3, 1}, {0, 2}, {1, 1}, {-1, -1}}
+	for i, result := range results {
+		match, err := srv.CreateMatch(ctx, &models.Match{LeagueID: league.ID, HomeTeamID: teamIDs[0], AwayTeamID: teamIDs[1], Week: i + 1, Status: "scheduled"})
+		if err != nil {
+			t.Fatalf("failed to create match: %v", err)
+		}
+		if result[0] >= 0 {
+			if err := srv.PlayMatch(ctx, match.ID, result[0], result[1]); err != nil {
+				t.Fatalf("failed to play match: %v", err)
+			}
+		}
+	}
+
+	split, err := srv.GetHomeAwaySplit(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get home and away split: %v", err)
+	}
+
+	expected := models.HomeAwaySplit{MatchesPlayed: 3, HomeWins: 1, AwayWins: 1, Draws: 1, HomeGoals: 4, AwayGoals: 4}
+	if *split != expected {
+		t.Errorf("expected %+v, got %+v", expected, *split)
+	}
+}
+
+func TestDeleteFinishedLeagues(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	statuses := []string{"finished", "started", "paused"}
+	leagueIDs := make(map[string]int)
+	for _, status := range statuses {
+		league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Cleanup " + status})
+		if err != nil {
+			t.Fatalf("failed to create league: %v", err)
+		}
+		if err := srv.UpdateLeagueStatus(ctx, league.ID, status); err != nil {
+			t.Fatalf("failed to set league status: %v", err)
+		}
+		leagueIDs[status] = league.ID
+	}
+
+	// Nothing finished an hour ago
+	deleted, err := srv.DeleteFinishedLeagues(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("failed to delete finished leagues: %v", err)
+	}
+	for _, id := range deleted {
+		if id == leagueIDs["finished"] {
+			t.Errorf("expected the league finished just now to be kept")
+		}
+	}
+
+	deleted, err = srv.DeleteFinishedLeagues(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to delete finished leagues: %v", err)
+	}
+	found := false
+	for _, id := range deleted {
+		found = found || id == leagueIDs["finished"]
+		if id == leagueIDs["started"] || id == leagueIDs["paused"] {
+			t.Errorf("expected league %d to be kept, it was deleted", id)
+		}
+	}
+	if !found {
+		t.Errorf("expected finished league %d to be deleted, got %v", leagueIDs["finished"], deleted)
+	}
+
+	if _, err := srv.GetLeagueByID(ctx, leagueIDs["finished"]); err == nil {
+		t.Errorf("expected the finished league to be gone")
+	}
+	if _, err := srv.GetLeagueByID(ctx, leagueIDs["started"]); err != nil {
+		t.Errorf("expected the started league to remain: %v", err)
+	}
+}
+
+func TestLeagueEvents(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Audited League"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	recorded := []string{models.LeagueEventCreated, models.LeagueEventStarted, models.LeagueEventWeekAdvanced}
+	for _, eventType := range recorded {
+		if err := srv.RecordLeagueEvent(ctx, league.ID, eventType, "details for "+eventType); err != nil {
+			t.Fatalf("failed to record %s event: %v", eventType, err)
+		}
+	}
+
+	events, err := srv.GetLeagueEvents(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get league events: %v", err)
+	}
+	if len(events) != len(recorded) {
+		t.Fatalf("expected %d events, got %d", len(recorded), len(events))
+	}
+	for i, event := range events {
+		if event.Type != recorded[i] || event.Details != "details for "+recorded[i] || event.LeagueID != league.ID {
+			t.Errorf("event %d: expected %s, got %+v", i, recorded[i], event)
+		}
+	}
+}
+
+func TestSimulationRuns(t *testing.T) {
+	ctx := context.Background()
+	srv := New()
+
+	if err := srv.InitializeTables(ctx); err != nil {
+		t.Fatalf("failed to initialize tables: %v", err)
+	}
+
+	league, err := srv.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Simulated League"})
+	if err != nil {
+		t.Fatalf("failed to create league: %v", err)
+	}
+
+	temperature := 0.25
+	recorded := []models.SimulationRun{
+		{LeagueID: league.ID, Mode: "expected", StartingWeek: 0, FinalWeek: 6, TableHash: "first"},
+		{LeagueID: league.ID, Mode: "tempered", Temperature: &temperature, StartingWeek: 2, FinalWeek: 6, TableHash: "second"},
+	}
+	for _, run := range recorded {
+		saved, err := srv.RecordSimulationRun(ctx, run)
+		if err != nil {
+			t.Fatalf("failed to record simulation run: %v", err)
+		}
+		if saved.ID == 0 || saved.CreatedAt.IsZero() {
+			t.Errorf("expected an ID and creation time, got %+v", saved)
+		}
+	}
+
+	runs, err := srv.GetSimulationRuns(ctx, league.ID)
+	if err != nil {
+		t.Fatalf("failed to get simulation runs: %v", err)
+	}
+	if len(runs) != len(recorded) {
+		t.Fatalf("expected %d runs, got %d", len(recorded), len(runs))
+	}
+	if runs[0].Mode != "expected" || runs[0].Temperature != nil || runs[0].TableHash != "first" {
+		t.Errorf("run 0: expected the expected-mode run, got %+v", runs[0])
+	}
+	if runs[1].Mode != "tempered" || runs[1].Temperature == nil || *runs[1].Temperature != temperature || runs[1].StartingWeek != 2 {
+		t.Errorf("run 1: expected the tempered run, got %+v", runs[1])
+	}
+}
+
 func TestClose(t *testing.T) {
 	srv := New()
 
@@ -4,45 +4,355 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"insider-league-manager/internal/models"
 )
 
 // CreateLeague creates a new league in the database
 func (s *service) CreateLeague(ctx context.Context, req *models.CreateLeagueRequest) (*models.League, error) {
-	// Insert the new league
+	league, err := insertLeague(ctx, s.db, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create league: %w", err)
+	}
+
+	return league, nil
+}
+
+// CreateLeagueWithIdempotencyKey creates a league once per idempotency key. A repeated key
+// returns the league created by the first request, with created set to false.
+func (s *service) CreateLeagueWithIdempotencyKey(ctx context.Context, key string, req *models.CreateLeagueRequest) (*models.League, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Claim the key; a concurrent request with the same key blocks here until we commit
+	result, err := tx.ExecContext(ctx, `INSERT INTO idempotency_keys (key) VALUES ($1) ON CONFLICT (key) DO NOTHING`, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get rows affected after storing idempotency key: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		// The key was already used; return the league it created
+		league, err := scanLeague(tx.QueryRowContext(ctx, `
+			SELECT `+leagueColumns+` FROM leagues
+			WHERE id = (SELECT league_id FROM idempotency_keys WHERE key = $1)
+		`, key))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get league for idempotency key: %w", err)
+		}
+		return league, false, nil
+	}
+
+	league, err := insertLeague(ctx, tx, req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create league: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE idempotency_keys SET league_id = $1 WHERE key = $2`, league.ID, key); err != nil {
+		return nil, false, fmt.Errorf("failed to record league for idempotency key: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return league, true, nil
+}
+
+// queryRower is implemented by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertLeague inserts a new league in "created" status
+func insertLeague(ctx context.Context, q queryRower, req *models.CreateLeagueRequest) (*models.League, error) {
+	zones := models.DefaultLeagueZones
+	if req.Zones != nil {
+		zones = *req.Zones
+	}
+
+	var scoreCorrelation, upsetFactor float64
+	if req.ScoreCorrelation != nil {
+		scoreCorrelation = *req.ScoreCorrelation
+	}
+	if req.UpsetFactor != nil {
+		upsetFactor = *req.UpsetFactor
+	}
+
+	var cleanSheetBonus, fatiguePenalty, matchesPerWeek, byePoints, bigWinMargin, bigWinBonus int
+	if req.CleanSheetBonus != nil {
+		cleanSheetBonus = *req.CleanSheetBonus
+	}
+	if req.FatiguePenalty != nil {
+		fatiguePenalty = *req.FatiguePenalty
+	}
+	if req.MatchesPerWeek != nil {
+		matchesPerWeek = *req.MatchesPerWeek
+	}
+	if req.ByePoints != nil {
+		byePoints = *req.ByePoints
+	}
+	if req.BigWinMargin != nil {
+		bigWinMargin = *req.BigWinMargin
+	}
+	if req.BigWinBonus != nil {
+		bigWinBonus = *req.BigWinBonus
+	}
+
+	finalTiebreak := req.FinalTiebreak
+	if finalTiebreak == "" {
+		finalTiebreak = models.FinalTiebreakName
+	}
+
+	weekNumbering := req.WeekNumbering
+	if weekNumbering == "" {
+		weekNumbering = models.WeekNumberingZeroBased
+	}
+
+	// Store the preset's resolved values so later changes to presets don't affect the league
+	scoring, ok := models.ResolveScoringPreset(req.ScoringPreset)
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring preset %q", req.ScoringPreset)
+	}
+
 	insertQuery := `
-		INSERT INTO leagues (name, status, current_week)
-		VALUES ($1, $2, $3)
-		RETURNING id, name, status, current_week, created_at
-	`
+		INSERT INTO leagues (name, status, current_week, champion_spots, promotion_spots, relegation_spots, score_correlation, upset_factor,
+		                     expectancy_base, expectancy_min, expectancy_max, clean_sheet_bonus, final_tiebreak, tiebreak_seed, fatigue_penalty,
+		                     matches_per_week, bye_points, big_win_margin, big_win_bonus, week_numbering)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING ` + leagueColumns
 
-	league := &models.League{}
-	err := s.db.QueryRowContext(
+	return scanLeague(q.QueryRowContext(
 		ctx,
 		insertQuery,
 		req.Name,
 		"created", // Default status
 		0,         // Default current_week
-	).Scan(
+		zones.ChampionSpots,
+		zones.PromotionSpots,
+		zones.RelegationSpots,
+		scoreCorrelation,
+		upsetFactor,
+		scoring.ExpectancyBase,
+		scoring.ExpectancyMin,
+		scoring.ExpectancyMax,
+		cleanSheetBonus,
+		finalTiebreak,
+		req.TiebreakSeed,
+		fatiguePenalty,
+		matchesPerWeek,
+		byePoints,
+		bigWinMargin,
+		bigWinBonus,
+		weekNumbering,
+	))
+}
+
+// leagueColumns lists the leagues columns in the order scanLeague reads them
+const leagueColumns = `id, name, status, current_week, created_at, champion_spots, promotion_spots, relegation_spots, score_correlation, upset_factor,
+	expectancy_base, expectancy_min, expectancy_max, clean_sheet_bonus, final_tiebreak, tiebreak_seed, fatigue_penalty,
+	matches_per_week, bye_points, big_win_margin, big_win_bonus, week_numbering`
+
+// standingsOrder ranks standings rows (aliased s, joined to their team t and league l) by points,
+// goal difference and goals for, then by the league's final tiebreak
+const standingsOrder = `s.points DESC, s.goal_difference DESC, s.goals_for DESC,
+	CASE WHEN l.final_tiebreak = 'team_id' THEN s.team_id END ASC,
+	CASE WHEN l.final_tiebreak = 'seeded' THEN md5(l.tiebreak_seed::text || ':' || s.team_id::text) END ASC,
+	t.name ASC`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanLeague scans a league row selected with leagueColumns
+func scanLeague(row rowScanner) (*models.League, error) {
+	league := &models.League{}
+	err := row.Scan(
 		&league.ID,
 		&league.Name,
 		&league.Status,
 		&league.CurrentWeek,
 		&league.CreatedAt,
+		&league.Zones.ChampionSpots,
+		&league.Zones.PromotionSpots,
+		&league.Zones.RelegationSpots,
+		&league.ScoreCorrelation,
+		&league.UpsetFactor,
+		&league.Scoring.ExpectancyBase,
+		&league.Scoring.ExpectancyMin,
+		&league.Scoring.ExpectancyMax,
+		&league.CleanSheetBonus,
+		&league.FinalTiebreak,
+		&league.TiebreakSeed,
+		&league.FatiguePenalty,
+		&league.MatchesPerWeek,
+		&league.ByePoints,
+		&league.BigWinMargin,
+		&league.BigWinBonus,
+		&league.WeekNumbering,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
+
+// CloneLeague creates a new league in "created" status with the same teams and freshly
+// initialized standings as the source league, in one transaction
+func (s *service) CloneLeague(ctx context.Context, sourceLeagueID int, name string) (*models.League, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	source, err := scanLeague(tx.QueryRowContext(ctx, `SELECT `+leagueColumns+` FROM leagues WHERE id = $1`, sourceLeagueID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league by ID %d: %w", sourceLeagueID, err)
+	}
 
+	league, err := scanLeague(tx.QueryRowContext(ctx, `
+		INSERT INTO leagues (name, status, current_week, champion_spots, promotion_spots, relegation_spots, score_correlation, upset_factor,
+		                     expectancy_base, expectancy_min, expectancy_max, clean_sheet_bonus, final_tiebreak, tiebreak_seed, fatigue_penalty,
+		                     matches_per_week, bye_points, big_win_margin, big_win_bonus, week_numbering)
+		VALUES ($1, 'created', 0, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING `+leagueColumns,
+		name, source.Zones.ChampionSpots, source.Zones.PromotionSpots, source.Zones.RelegationSpots, source.ScoreCorrelation, source.UpsetFactor,
+		source.Scoring.ExpectancyBase, source.Scoring.ExpectancyMin, source.Scoring.ExpectancyMax, source.CleanSheetBonus,
+		source.FinalTiebreak, source.TiebreakSeed, source.FatiguePenalty, source.MatchesPerWeek, source.ByePoints,
+		source.BigWinMargin, source.BigWinBonus, source.WeekNumbering))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create league: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO league_teams (league_id, team_id, group_name)
+		SELECT $1, team_id, group_name FROM league_teams WHERE league_id = $2
+	`, league.ID, sourceLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy teams from league %d: %w", sourceLeagueID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO standings (league_id, team_id)
+		SELECT $1, team_id FROM league_teams WHERE league_id = $2
+	`, league.ID, sourceLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize standings for league %d: %w", league.ID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return league, nil
 }
 
+// ImportLeague creates a league, its teams, memberships, standings and matches from an imported
+// document in one transaction. Document team IDs are remapped to the newly created team IDs.
+func (s *service) ImportLeague(ctx context.Context, req *models.ImportLeagueRequest) (*models.League, []*models.Team, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Determine the league state from the imported results
+	status, currentWeek := "created", 0
+	for _, match := range req.Matches {
+		status = "started"
+		if match.HomeGoals != nil && match.AwayGoals != nil && match.Week > currentWeek {
+			currentWeek = match.Week
+		}
+	}
+
+	zones := models.DefaultLeagueZones
+	league, err := scanLeague(tx.QueryRowContext(ctx, `
+		INSERT INTO leagues (name, status, current_week, champion_spots, promotion_spots, relegation_spots)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+leagueColumns,
+		req.Name, status, currentWeek, zones.ChampionSpots, zones.PromotionSpots, zones.RelegationSpots))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create league: %w", err)
+	}
+	bonuses := league.Bonuses()
+
+	// Create the teams and remember which new ID each document ID maps to
+	teamIDs := make(map[int]int, len(req.Teams))
+	teams := make([]*models.Team, 0, len(req.Teams))
+	for _, importTeam := range req.Teams {
+		team, err := scanTeam(tx.QueryRowContext(ctx, `
+			INSERT INTO teams (name, strength)
+			VALUES ($1, $2)
+			RETURNING `+teamColumns, importTeam.Name, importTeam.Strength))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create team %s: %w", importTeam.Name, err)
+		}
+		teamIDs[importTeam.ID] = team.ID
+		teams = append(teams, team)
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO league_teams (league_id, team_id) VALUES ($1, $2)`, league.ID, team.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to add team %d to league %d: %w", team.ID, league.ID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO standings (league_id, team_id) VALUES ($1, $2)`, league.ID, team.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize standing for team %d in league %d: %w", team.ID, league.ID, err)
+		}
+	}
+
+	// Create the matches against the remapped team IDs, applying played results to standings
+	for i, match := range req.Matches {
+		homeTeamID, homeOK := teamIDs[match.HomeTeamID]
+		awayTeamID, awayOK := teamIDs[match.AwayTeamID]
+		if !homeOK || !awayOK {
+			return nil, nil, fmt.Errorf("match %d references a team outside the imported set", i+1)
+		}
+
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO matches (league_id, home_team_id, away_team_id, week, status)
+				VALUES ($1, $2, $3, $4, 'scheduled')
+			`, league.ID, homeTeamID, awayTeamID, match.Week)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create match %d: %w", i+1, err)
+			}
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO matches (league_id, home_team_id, away_team_id, week, home_goals, away_goals, status, played_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 'played', NOW())
+		`, league.ID, homeTeamID, awayTeamID, match.Week, *match.HomeGoals, *match.AwayGoals)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create match %d: %w", i+1, err)
+		}
+
+		err = s.applyStandingsEffect(ctx, tx, league.ID, homeTeamID, awayTeamID, *match.HomeGoals, *match.AwayGoals, bonuses)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to apply standings for match %d: %w", i+1, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return league, teams, nil
+}
+
 // GetDefaultTeams retrieves the 4 default teams for league initialization
 func (s *service) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
 	query := `
-		SELECT id, name, strength 
+		SELECT ` + teamColumns + `
 		FROM teams 
 		WHERE name IN ('Manchester City', 'Liverpool FC', 'Chelsea FC', 'Arsenal FC')
 		ORDER BY name
@@ -54,18 +364,9 @@ func (s *service) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
 	}
 	defer rows.Close()
 
-	var teams []*models.Team
-	for rows.Next() {
-		team := &models.Team{}
-		err := rows.Scan(&team.ID, &team.Name, &team.Strength)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan team: %w", err)
-		}
-		teams = append(teams, team)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over teams: %w", err)
+	teams, err := scanTeams(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(teams) != 4 {
@@ -77,13 +378,71 @@ func (s *service) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
 
 // AddTeamToLeague adds a team to a league
 func (s *service) AddTeamToLeague(ctx context.Context, leagueID, teamID int) error {
+	// First, check the team isn't in the league already
+	var exists bool
+	checkQuery := `SELECT EXISTS(SELECT 1 FROM league_teams WHERE league_id = $1 AND team_id = $2)`
+	err := s.db.QueryRowContext(ctx, checkQuery, leagueID, teamID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check if team %d exists in league %d: %w", teamID, leagueID, err)
+	}
+
+	if exists {
+		return fmt.Errorf("team %d is already in league %d", teamID, leagueID)
+	}
+
+	return addTeamToLeague(ctx, s.db, leagueID, teamID)
+}
+
+// InitializeStanding creates initial standing entry for a team in a league
+func (s *service) InitializeStanding(ctx context.Context, leagueID, teamID int) error {
+	return initializeStanding(ctx, s.db, leagueID, teamID)
+}
+
+// InitializeLeagueWithTeams creates a league, adds the teams to it and initializes their
+// standings in one transaction, so a failure part way through leaves no league behind
+func (s *service) InitializeLeagueWithTeams(ctx context.Context, req *models.CreateLeagueRequest, teams []*models.Team) (*models.League, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	league, err := insertLeague(ctx, tx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create league: %w", err)
+	}
+
+	for _, team := range teams {
+		if err := addTeamToLeague(ctx, tx, league.ID, team.ID); err != nil {
+			return nil, err
+		}
+
+		if err := initializeStanding(ctx, tx, league.ID, team.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return league, nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// addTeamToLeague adds a team to a league, ignoring teams that are already members
+func addTeamToLeague(ctx context.Context, e execer, leagueID, teamID int) error {
 	insertQuery := `
 		INSERT INTO league_teams (league_id, team_id)
 		VALUES ($1, $2)
 		ON CONFLICT (league_id, team_id) DO NOTHING
 	`
 
-	_, err := s.db.ExecContext(ctx, insertQuery, leagueID, teamID)
+	_, err := e.ExecContext(ctx, insertQuery, leagueID, teamID)
 	if err != nil {
 		return fmt.Errorf("failed to add team %d to league %d: %w", teamID, leagueID, err)
 	}
@@ -91,15 +450,15 @@ func (s *service) AddTeamToLeague(ctx context.Context, leagueID, teamID int) err
 	return nil
 }
 
-// InitializeStanding creates initial standing entry for a team in a league
-func (s *service) InitializeStanding(ctx context.Context, leagueID, teamID int) error {
+// initializeStanding creates an empty standings row for a team in a league
+func initializeStanding(ctx context.Context, e execer, leagueID, teamID int) error {
 	insertQuery := `
 		INSERT INTO standings (league_id, team_id, points, played, wins, draws, losses, goals_for, goals_against, goal_difference)
 		VALUES ($1, $2, 0, 0, 0, 0, 0, 0, 0, 0)
 		ON CONFLICT (league_id, team_id) DO NOTHING
 	`
 
-	_, err := s.db.ExecContext(ctx, insertQuery, leagueID, teamID)
+	_, err := e.ExecContext(ctx, insertQuery, leagueID, teamID)
 	if err != nil {
 		return fmt.Errorf("failed to initialize standing for team %d in league %d: %w", teamID, leagueID, err)
 	}
@@ -107,24 +466,298 @@ func (s *service) InitializeStanding(ctx context.Context, leagueID, teamID int)
 	return nil
 }
 
-// GetLeagueByID retrieves a league by its ID
-func (s *service) GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error) {
-	query := `SELECT id, name, status, current_week, created_at FROM leagues WHERE id = $1`
+// GetLeagueByID retrieves a league by its ID
+func (s *service) GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error) {
+	query := `SELECT ` + leagueColumns + ` FROM leagues WHERE id = $1`
+
+	league, err := scanLeague(s.db.QueryRowContext(ctx, query, leagueID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league by ID %d: %w", leagueID, err)
+	}
+
+	return league, nil
+}
+
+// UpdateLeagueConfig saves a league's settings, only while the league is still in "created" status
+func (s *service) UpdateLeagueConfig(ctx context.Context, leagueID int, config models.LeagueConfig) error {
+	updateQuery := `
+		UPDATE leagues
+		SET champion_spots = $1, promotion_spots = $2, relegation_spots = $3,
+		    score_correlation = $4, upset_factor = $5,
+		    expectancy_base = $6, expectancy_min = $7, expectancy_max = $8,
+		    clean_sheet_bonus = $9, final_tiebreak = $10, tiebreak_seed = $11,
+		    fatigue_penalty = $12, matches_per_week = $13, bye_points = $14,
+		    big_win_margin = $15, big_win_bonus = $16, week_numbering = $17
+		WHERE id = $18 AND status = 'created'
+	`
+
+	result, err := s.db.ExecContext(ctx, updateQuery,
+		config.Zones.ChampionSpots,
+		config.Zones.PromotionSpots,
+		config.Zones.RelegationSpots,
+		config.ScoreCorrelation,
+		config.UpsetFactor,
+		config.Scoring.ExpectancyBase,
+		config.Scoring.ExpectancyMin,
+		config.Scoring.ExpectancyMax,
+		config.CleanSheetBonus,
+		config.FinalTiebreak,
+		config.TiebreakSeed,
+		config.FatiguePenalty,
+		config.MatchesPerWeek,
+		config.ByePoints,
+		config.BigWinMargin,
+		config.BigWinBonus,
+		config.WeekNumbering,
+		leagueID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update config of league %d: %w", leagueID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating league %d config: %w", leagueID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no created league found with ID %d", leagueID)
+	}
+
+	return nil
+}
+
+// GetAllLeagues retrieves all leagues ordered by ID. An empty status returns leagues in every status.
+func (s *service) GetAllLeagues(ctx context.Context, status string) ([]*models.League, error) {
+	query := `SELECT ` + leagueColumns + ` FROM leagues WHERE ($1::text = '' OR status = $1::text) ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leagues: %w", err)
+	}
+	defer rows.Close()
+
+	leagues := []*models.League{}
+	for rows.Next() {
+		league, err := scanLeague(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan league: %w", err)
+		}
+		leagues = append(leagues, league)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leagues: %w", err)
+	}
+
+	return leagues, nil
+}
+
+// GetLeagueSummary counts leagues by status and the total number of teams in a single query
+func (s *service) GetLeagueSummary(ctx context.Context) (*models.LeagueSummary, error) {
+	// The LEFT JOIN keeps one row with a NULL status when there are no leagues,
+	// so the team total is still returned
+	query := `
+		SELECT l.status, COUNT(l.id), t.total
+		FROM (SELECT COUNT(*) AS total FROM teams) t
+		LEFT JOIN leagues l ON true
+		GROUP BY l.status, t.total
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query league summary: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &models.LeagueSummary{LeaguesByStatus: make(map[string]int)}
+	for rows.Next() {
+		var status sql.NullString
+		var count int
+		if err := rows.Scan(&status, &count, &summary.TotalTeams); err != nil {
+			return nil, fmt.Errorf("failed to scan league summary: %w", err)
+		}
+		if status.Valid {
+			summary.LeaguesByStatus[status.String] = count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating league summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+// RecordLeagueEvent adds an entry to a league's audit log
+func (s *service) RecordLeagueEvent(ctx context.Context, leagueID int, eventType, details string) error {
+	insertQuery := `
+		INSERT INTO league_events (league_id, event_type, details)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := s.db.ExecContext(ctx, insertQuery, leagueID, eventType, details); err != nil {
+		return fmt.Errorf("failed to record %s event for league %d: %w", eventType, leagueID, err)
+	}
+
+	return nil
+}
+
+// GetLeagueEvents retrieves a league's audit log, oldest event first
+func (s *service) GetLeagueEvents(ctx context.Context, leagueID int) ([]models.LeagueEvent, error) {
+	query := `
+		SELECT id, league_id, event_type, details, created_at
+		FROM league_events
+		WHERE league_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	events := []models.LeagueEvent{}
+	for rows.Next() {
+		var event models.LeagueEvent
+		if err := rows.Scan(&event.ID, &event.LeagueID, &event.Type, &event.Details, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan league event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over league events: %w", err)
+	}
+
+	return events, nil
+}
+
+// RecordSimulationRun saves a play-all run and returns it with its ID and creation time
+func (s *service) RecordSimulationRun(ctx context.Context, run models.SimulationRun) (*models.SimulationRun, error) {
+	insertQuery := `
+		INSERT INTO simulation_runs (league_id, mode, temperature, starting_week, final_week, table_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := s.db.QueryRowContext(ctx, insertQuery, run.LeagueID, run.Mode, run.Temperature, run.StartingWeek, run.FinalWeek, run.TableHash).
+		Scan(&run.ID, &run.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record simulation run for league %d: %w", run.LeagueID, err)
+	}
+
+	return &run, nil
+}
+
+// GetSimulationRuns retrieves a league's play-all runs, oldest first
+func (s *service) GetSimulationRuns(ctx context.Context, leagueID int) ([]models.SimulationRun, error) {
+	query := `
+		SELECT id, league_id, mode, temperature, starting_week, final_week, table_hash, created_at
+		FROM simulation_runs
+		WHERE league_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query simulation runs for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	runs := []models.SimulationRun{}
+	for rows.Next() {
+		var run models.SimulationRun
+		var temperature sql.NullFloat64
+		if err := rows.Scan(&run.ID, &run.LeagueID, &run.Mode, &temperature, &run.StartingWeek, &run.FinalWeek, &run.TableHash, &run.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan simulation run: %w", err)
+		}
+		if temperature.Valid {
+			run.Temperature = &temperature.Float64
+		}
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over simulation runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// DeleteFinishedLeagues deletes the finished leagues that finished before a time, with their
+// matches, standings and events, and returns their IDs. A league finishes at its last "finished"
+// event; one finished before the audit log existed is dated by its creation.
+func (s *service) DeleteFinishedLeagues(ctx context.Context, finishedBefore time.Time) ([]int, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM leagues l
+			WHERE l.status = 'finished'
+			  AND COALESCE(
+				(SELECT MAX(e.created_at) FROM league_events e WHERE e.league_id = l.id AND e.event_type = 'finished'),
+				l.created_at
+			  ) < $1
+			RETURNING l.id
+		)
+		SELECT id FROM deleted ORDER BY id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, finishedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete finished leagues: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted league ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted leagues: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetLeaderboard sums each team's standings across finished leagues, ranked by points, then wins, then name
+func (s *service) GetLeaderboard(ctx context.Context, limit int) ([]models.LeaderboardEntry, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(*), SUM(s.points), SUM(s.wins)
+		FROM standings s
+		JOIN leagues l ON l.id = s.league_id
+		JOIN teams t ON t.id = s.team_id
+		WHERE l.status = 'finished'
+		GROUP BY t.id, t.name
+		ORDER BY SUM(s.points) DESC, SUM(s.wins) DESC, t.name
+		LIMIT $1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
 
-	league := &models.League{}
-	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(
-		&league.ID,
-		&league.Name,
-		&league.Status,
-		&league.CurrentWeek,
-		&league.CreatedAt,
-	)
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		var entry models.LeaderboardEntry
+		if err := rows.Scan(&entry.TeamID, &entry.TeamName, &entry.Leagues, &entry.Points, &entry.Wins); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entry.Rank = len(entries) + 1
+		entries = append(entries, entry)
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to get league by ID %d: %w", leagueID, err)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leaderboard: %w", err)
 	}
 
-	return league, nil
+	return entries, nil
 }
 
 // RemoveTeamFromLeague removes a team from a league and their standings
@@ -170,11 +803,10 @@ func (s *service) RemoveTeamFromLeague(ctx context.Context, leagueID, teamID int
 // GetTeamsInLeague retrieves all teams that are part of a specific league
 func (s *service) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
 	query := `
-		SELECT t.id, t.name, t.strength 
-		FROM teams t
-		INNER JOIN league_teams lt ON t.id = lt.team_id
-		WHERE lt.league_id = $1
-		ORDER BY t.name
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE id IN (SELECT team_id FROM league_teams WHERE league_id = $1)
+		ORDER BY name
 	`
 
 	rows, err := s.db.QueryContext(ctx, query, leagueID)
@@ -183,18 +815,32 @@ func (s *service) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models
 	}
 	defer rows.Close()
 
-	var teams []*models.Team
-	for rows.Next() {
-		team := &models.Team{}
-		err := rows.Scan(&team.ID, &team.Name, &team.Strength)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan team: %w", err)
-		}
-		teams = append(teams, team)
+	teams, err := scanTeams(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over teams: %w", err)
+	return teams, nil
+}
+
+// GetTeamsNotInLeague retrieves all teams that are not part of a specific league, ordered by name
+func (s *service) GetTeamsNotInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		WHERE NOT EXISTS (SELECT 1 FROM league_teams lt WHERE lt.league_id = $1 AND lt.team_id = t.id)
+		ORDER BY name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams not in league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	teams, err := scanTeams(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	return teams, nil
@@ -203,13 +849,11 @@ func (s *service) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models
 // CreateMatch creates a new match in the database
 func (s *service) CreateMatch(ctx context.Context, match *models.Match) (*models.Match, error) {
 	insertQuery := `
-		INSERT INTO matches (league_id, home_team_id, away_team_id, week, status)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, league_id, home_team_id, away_team_id, week, home_goals, away_goals, status, played_at, created_at
-	`
+		INSERT INTO matches (league_id, home_team_id, away_team_id, week, status, kickoff_time)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + matchColumns
 
-	createdMatch := &models.Match{}
-	err := s.db.QueryRowContext(
+	createdMatch, err := scanMatch(s.db.QueryRowContext(
 		ctx,
 		insertQuery,
 		match.LeagueID,
@@ -217,18 +861,8 @@ func (s *service) CreateMatch(ctx context.Context, match *models.Match) (*models
 		match.AwayTeamID,
 		match.Week,
 		match.Status,
-	).Scan(
-		&createdMatch.ID,
-		&createdMatch.LeagueID,
-		&createdMatch.HomeTeamID,
-		&createdMatch.AwayTeamID,
-		&createdMatch.Week,
-		&createdMatch.HomeGoals,
-		&createdMatch.AwayGoals,
-		&createdMatch.Status,
-		&createdMatch.PlayedAt,
-		&createdMatch.CreatedAt,
-	)
+		match.KickoffTime,
+	))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create match: %w", err)
@@ -237,6 +871,87 @@ func (s *service) CreateMatch(ctx context.Context, match *models.Match) (*models
 	return createdMatch, nil
 }
 
+// ReplaceScheduledMatches deletes a league's scheduled matches and creates the given matches in
+// their place, in one transaction. Played and cancelled matches are kept.
+func (s *service) ReplaceScheduledMatches(ctx context.Context, leagueID int, matches []models.Match) ([]*models.Match, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM matches WHERE league_id = $1 AND status = 'scheduled'`, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete scheduled matches for league %d: %w", leagueID, err)
+	}
+
+	created := make([]*models.Match, 0, len(matches))
+	for _, match := range matches {
+		createdMatch, err := scanMatch(tx.QueryRowContext(ctx, `
+			INSERT INTO matches (league_id, home_team_id, away_team_id, week, status, kickoff_time)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING `+matchColumns,
+			leagueID, match.HomeTeamID, match.AwayTeamID, match.Week, match.Status, match.KickoffTime))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create match: %w", err)
+		}
+		created = append(created, createdMatch)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetVenueCounts counts a team's scheduled and played fixtures in a league at home and away.
+// Cancelled matches are not counted.
+func (s *service) GetVenueCounts(ctx context.Context, leagueID, teamID int) (home, away int, err error) {
+	query := `
+		SELECT COUNT(*) FILTER (WHERE home_team_id = $2),
+		       COUNT(*) FILTER (WHERE away_team_id = $2)
+		FROM matches
+		WHERE league_id = $1 AND status IN ('scheduled', 'played')
+	`
+
+	err = s.db.QueryRowContext(ctx, query, leagueID, teamID).Scan(&home, &away)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count fixtures by venue for team %d in league %d: %w", teamID, leagueID, err)
+	}
+
+	return home, away, nil
+}
+
+// GetHomeAwaySplit totals home wins, away wins, draws and goals by venue over a league's played matches
+func (s *service) GetHomeAwaySplit(ctx context.Context, leagueID int) (*models.HomeAwaySplit, error) {
+	query := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE home_goals > away_goals),
+		       COUNT(*) FILTER (WHERE home_goals < away_goals),
+		       COUNT(*) FILTER (WHERE home_goals = away_goals),
+		       COALESCE(SUM(home_goals), 0),
+		       COALESCE(SUM(away_goals), 0)
+		FROM matches
+		WHERE league_id = $1 AND status = 'played'
+	`
+
+	split := &models.HomeAwaySplit{}
+	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(
+		&split.MatchesPlayed,
+		&split.HomeWins,
+		&split.AwayWins,
+		&split.Draws,
+		&split.HomeGoals,
+		&split.AwayGoals,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home and away split for league %d: %w", leagueID, err)
+	}
+
+	return split, nil
+}
+
 // UpdateLeagueStatus updates the status of a league
 func (s *service) UpdateLeagueStatus(ctx context.Context, leagueID int, status string) error {
 	updateQuery := `UPDATE leagues SET status = $1 WHERE id = $2`
@@ -258,10 +973,31 @@ func (s *service) UpdateLeagueStatus(ctx context.Context, leagueID int, status s
 	return nil
 }
 
+// TransitionLeagueStatus moves a league from one status to another, failing if it is not in the expected status
+func (s *service) TransitionLeagueStatus(ctx context.Context, leagueID int, fromStatus, toStatus string) error {
+	updateQuery := `UPDATE leagues SET status = $1 WHERE id = $2 AND status = $3`
+
+	result, err := s.db.ExecContext(ctx, updateQuery, toStatus, leagueID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update status of league %d: %w", leagueID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating status of league %d: %w", leagueID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no %s league found with ID %d", fromStatus, leagueID)
+	}
+
+	return nil
+}
+
 // GetMatchesByWeekAndLeague retrieves matches for a specific league and week
 func (s *service) GetMatchesByWeekAndLeague(ctx context.Context, leagueID, week int) ([]*models.Match, error) {
 	query := `
-		SELECT id, league_id, home_team_id, away_team_id, week, home_goals, away_goals, status, played_at, created_at
+		SELECT ` + matchColumns + `
 		FROM matches 
 		WHERE league_id = $1 AND week = $2
 		ORDER BY id
@@ -273,28 +1009,86 @@ func (s *service) GetMatchesByWeekAndLeague(ctx context.Context, leagueID, week
 	}
 	defer rows.Close()
 
+	return scanMatches(rows)
+}
+
+// GetMatchesByKickoffRange retrieves the matches of a league kicking off at or after from
+// and before to, in chronological order. Matches without a kickoff time are not included.
+func (s *service) GetMatchesByKickoffRange(ctx context.Context, leagueID int, from, to time.Time) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches
+		WHERE league_id = $1 AND kickoff_time >= $2 AND kickoff_time < $3
+		ORDER BY kickoff_time, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches for league %d between %s and %s: %w", leagueID, from.Format(time.RFC3339), to.Format(time.RFC3339), err)
+	}
+	defer rows.Close()
+
+	return scanMatches(rows)
+}
+
+// GetMatchesByLeague retrieves all matches of a league ordered by week.
+// An empty status returns matches of every status.
+func (s *service) GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM matches 
+		WHERE league_id = $1 AND ($2::text = '' OR status = $2::text)
+		ORDER BY week, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	return scanMatches(rows)
+}
+
+// matchColumns lists the matches table columns in the order scanMatches expects
+const matchColumns = `id, league_id, home_team_id, away_team_id, week, home_goals, away_goals, status, played_at, created_at, kickoff_time, updated_at`
+
+// scanMatch scans a match row selected with matchColumns
+func scanMatch(row rowScanner) (*models.Match, error) {
+	match := &models.Match{}
+	err := row.Scan(
+		&match.ID,
+		&match.LeagueID,
+		&match.HomeTeamID,
+		&match.AwayTeamID,
+		&match.Week,
+		&match.HomeGoals,
+		&match.AwayGoals,
+		&match.Status,
+		&match.PlayedAt,
+		&match.CreatedAt,
+		&match.KickoffTime,
+		&match.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	match.Edited = match.UpdatedAt != nil
+	return match, nil
+}
+
+// scanMatches reads every row of a query selecting matchColumns
+func scanMatches(rows *sql.Rows) ([]*models.Match, error) {
 	var matches []*models.Match
 	for rows.Next() {
-		match := &models.Match{}
-		err := rows.Scan(
-			&match.ID,
-			&match.LeagueID,
-			&match.HomeTeamID,
-			&match.AwayTeamID,
-			&match.Week,
-			&match.HomeGoals,
-			&match.AwayGoals,
-			&match.Status,
-			&match.PlayedAt,
-			&match.CreatedAt,
-		)
+		match, err := scanMatch(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan match: %w", err)
 		}
 		matches = append(matches, match)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating over matches: %w", err)
 	}
 
@@ -326,7 +1120,28 @@ func (s *service) PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals i
 	return nil
 }
 
-// UpdateStandings updates team standings after a match
+// AddStandingPoints adds points to a team's standing without recording a match, such as for a bye
+func (s *service) AddStandingPoints(ctx context.Context, leagueID, teamID, points int) error {
+	updateQuery := `UPDATE standings SET points = points + $1 WHERE league_id = $2 AND team_id = $3`
+
+	result, err := s.db.ExecContext(ctx, updateQuery, points, leagueID, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to add points to team %d standing in league %d: %w", teamID, leagueID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no standing found for team %d in league %d", teamID, leagueID)
+	}
+
+	return nil
+}
+
+// UpdateStandings updates team standings after a match, creating the standings row of a team
+// in the league that is missing one
 func (s *service) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
 	// Determine match result
 	var homePoints, awayPoints int
@@ -353,42 +1168,40 @@ func (s *service) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awa
 		awayDraws = 1
 	}
 
-	// Update home team standings
-	homeUpdateQuery := `
-		UPDATE standings 
-		SET points = points + $1,
-		    played = played + 1,
-		    wins = wins + $2,
-		    draws = draws + $3,
-		    losses = losses + $4,
-		    goals_for = goals_for + $5,
-		    goals_against = goals_against + $6,
-		    goal_difference = goals_for + $5 - (goals_against + $6)
-		WHERE league_id = $7 AND team_id = $8
+	// Clean sheets and big wins earn the league's bonus points
+	bonuses, err := leagueBonusPoints(ctx, s.db, leagueID)
+	if err != nil {
+		return err
+	}
+	homeBonus, awayBonus := bonuses.Points(homeGoals, awayGoals)
+	homePoints += homeBonus
+	awayPoints += awayBonus
+
+	// Record the result for each side, creating a missing standings row for a team that is in the
+	// league so the result is never lost to an UPDATE that matches no rows
+	upsertQuery := `
+		INSERT INTO standings (league_id, team_id, points, played, wins, draws, losses, goals_for, goals_against, goal_difference)
+		SELECT $1, $2, $3, 1, $4, $5, $6, $7, $8, $7 - $8
+		WHERE EXISTS (SELECT 1 FROM league_teams WHERE league_id = $1 AND team_id = $2)
+		ON CONFLICT (league_id, team_id) DO UPDATE
+		SET points = standings.points + EXCLUDED.points,
+		    played = standings.played + 1,
+		    wins = standings.wins + EXCLUDED.wins,
+		    draws = standings.draws + EXCLUDED.draws,
+		    losses = standings.losses + EXCLUDED.losses,
+		    goals_for = standings.goals_for + EXCLUDED.goals_for,
+		    goals_against = standings.goals_against + EXCLUDED.goals_against,
+		    goal_difference = standings.goal_difference + EXCLUDED.goal_difference
 	`
 
-	_, err := s.db.ExecContext(ctx, homeUpdateQuery,
-		homePoints, homeWins, homeDraws, homeLosses, homeGoals, awayGoals, leagueID, homeTeamID)
+	_, err = s.db.ExecContext(ctx, upsertQuery,
+		leagueID, homeTeamID, homePoints, homeWins, homeDraws, homeLosses, homeGoals, awayGoals)
 	if err != nil {
 		return fmt.Errorf("failed to update home team %d standings: %w", homeTeamID, err)
 	}
 
-	// Update away team standings
-	awayUpdateQuery := `
-		UPDATE standings 
-		SET points = points + $1,
-		    played = played + 1,
-		    wins = wins + $2,
-		    draws = draws + $3,
-		    losses = losses + $4,
-		    goals_for = goals_for + $5,
-		    goals_against = goals_against + $6,
-		    goal_difference = goals_for + $5 - (goals_against + $6)
-		WHERE league_id = $7 AND team_id = $8
-	`
-
-	_, err = s.db.ExecContext(ctx, awayUpdateQuery,
-		awayPoints, awayWins, awayDraws, awayLosses, awayGoals, homeGoals, leagueID, awayTeamID)
+	_, err = s.db.ExecContext(ctx, upsertQuery,
+		leagueID, awayTeamID, awayPoints, awayWins, awayDraws, awayLosses, awayGoals, homeGoals)
 	if err != nil {
 		return fmt.Errorf("failed to update away team %d standings: %w", awayTeamID, err)
 	}
@@ -396,6 +1209,17 @@ func (s *service) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awa
 	return nil
 }
 
+// leagueBonusPoints returns the bonus points a league awards for a match
+func leagueBonusPoints(ctx context.Context, q queryRower, leagueID int) (models.MatchBonuses, error) {
+	var bonuses models.MatchBonuses
+	err := q.QueryRowContext(ctx, `SELECT clean_sheet_bonus, big_win_margin, big_win_bonus FROM leagues WHERE id = $1`, leagueID).
+		Scan(&bonuses.CleanSheet, &bonuses.BigWinMargin, &bonuses.BigWin)
+	if err != nil {
+		return models.MatchBonuses{}, fmt.Errorf("failed to get bonus points of league %d: %w", leagueID, err)
+	}
+	return bonuses, nil
+}
+
 // AdvanceLeagueWeek increments the current week of a league
 func (s *service) AdvanceLeagueWeek(ctx context.Context, leagueID int) error {
 	updateQuery := `UPDATE leagues SET current_week = current_week + 1 WHERE id = $1`
@@ -417,16 +1241,16 @@ func (s *service) AdvanceLeagueWeek(ctx context.Context, leagueID int) error {
 	return nil
 }
 
-// GetStandings retrieves league standings sorted by points and goal difference
+// GetStandings retrieves league standings sorted by points, goal difference, goals for and the league's final tiebreak
 func (s *service) GetStandings(ctx context.Context, leagueID int) ([]models.StandingWithTeam, error) {
 	query := `
 		SELECT s.league_id, s.team_id, s.points, s.played, s.wins, s.draws, s.losses, 
 		       s.goals_for, s.goals_against, s.goal_difference, t.name as team_name
 		FROM standings s
 		INNER JOIN teams t ON s.team_id = t.id
+		INNER JOIN leagues l ON s.league_id = l.id
 		WHERE s.league_id = $1
-		ORDER BY s.points DESC, s.goal_difference DESC, s.goals_for DESC, t.name ASC
-	`
+		ORDER BY ` + standingsOrder
 
 	rows, err := s.db.QueryContext(ctx, query, leagueID)
 	if err != nil {
@@ -466,30 +1290,64 @@ func (s *service) GetStandings(ctx context.Context, leagueID int) ([]models.Stan
 // GetMatchByID retrieves a match by its ID
 func (s *service) GetMatchByID(ctx context.Context, matchID int) (*models.Match, error) {
 	query := `
-		SELECT id, league_id, home_team_id, away_team_id, week, home_goals, away_goals, status, played_at, created_at
+		SELECT ` + matchColumns + `
 		FROM matches 
 		WHERE id = $1
 	`
 
-	var match models.Match
-	err := s.db.QueryRowContext(ctx, query, matchID).Scan(
-		&match.ID,
-		&match.LeagueID,
-		&match.HomeTeamID,
-		&match.AwayTeamID,
-		&match.Week,
-		&match.HomeGoals,
-		&match.AwayGoals,
-		&match.Status,
-		&match.PlayedAt,
-		&match.CreatedAt,
-	)
-
+	match, err := scanMatch(s.db.QueryRowContext(ctx, query, matchID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get match by ID %d: %w", matchID, err)
 	}
 
-	return &match, nil
+	return match, nil
+}
+
+// UpdateMatchStatus moves a match from one status to another, such as cancelling a scheduled match.
+// It fails if the match is not currently in fromStatus.
+func (s *service) UpdateMatchStatus(ctx context.Context, matchID int, fromStatus, toStatus string) error {
+	updateQuery := `UPDATE matches SET status = $1 WHERE id = $2 AND status = $3`
+
+	result, err := s.db.ExecContext(ctx, updateQuery, toStatus, matchID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update status of match %d: %w", matchID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating status of match %d: %w", matchID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no %s match found with ID %d", fromStatus, matchID)
+	}
+
+	return nil
+}
+
+// SwapMatchVenue swaps the home and away teams of a match that is still scheduled
+func (s *service) SwapMatchVenue(ctx context.Context, matchID int) error {
+	updateQuery := `
+		UPDATE matches
+		SET home_team_id = away_team_id, away_team_id = home_team_id
+		WHERE id = $1 AND status = 'scheduled'
+	`
+
+	result, err := s.db.ExecContext(ctx, updateQuery, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to swap venue for match %d: %w", matchID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after swapping venue for match %d: %w", matchID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no scheduled match found with ID %d", matchID)
+	}
+
+	return nil
 }
 
 // EditMatch updates match result and recalculates standings
@@ -501,11 +1359,12 @@ func (s *service) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayG
 	}
 	defer tx.Rollback()
 
-	// Get the current match details
+	// Get the current match details, locking the match so concurrent edits of it are serialized
 	getMatchQuery := `
 		SELECT league_id, home_team_id, away_team_id, home_goals, away_goals, status
 		FROM matches 
 		WHERE id = $1
+		FOR UPDATE
 	`
 
 	var leagueID, homeTeamID, awayTeamID int
@@ -528,10 +1387,28 @@ func (s *service) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayG
 		return fmt.Errorf("match has no existing result to edit")
 	}
 
+	// Lock both teams' standings rows before changing them. Rows are locked in team ID
+	// order so two edits sharing a team can't deadlock.
+	lockStandingsQuery := `
+		SELECT team_id
+		FROM standings
+		WHERE league_id = $1 AND team_id IN ($2, $3)
+		ORDER BY team_id
+		FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, lockStandingsQuery, leagueID, homeTeamID, awayTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to lock standings: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to lock standings: %w", err)
+	}
+
 	// Update the match with new results
 	updateMatchQuery := `
 		UPDATE matches 
-		SET home_goals = $1, away_goals = $2 
+		SET home_goals = $1, away_goals = $2, updated_at = NOW()
 		WHERE id = $3
 	`
 
@@ -540,14 +1417,20 @@ func (s *service) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayG
 		return fmt.Errorf("failed to update match: %w", err)
 	}
 
+	// Bonus points are reversed and applied along with the result points
+	bonuses, err := leagueBonusPoints(ctx, tx, leagueID)
+	if err != nil {
+		return err
+	}
+
 	// Reverse the old standings effect
-	err = s.reverseStandingsEffect(ctx, tx, leagueID, homeTeamID, awayTeamID, *oldHomeGoals, *oldAwayGoals)
+	err = s.reverseStandingsEffect(ctx, tx, leagueID, homeTeamID, awayTeamID, *oldHomeGoals, *oldAwayGoals, bonuses)
 	if err != nil {
 		return fmt.Errorf("failed to reverse old standings: %w", err)
 	}
 
 	// Apply the new standings effect
-	err = s.applyStandingsEffect(ctx, tx, leagueID, homeTeamID, awayTeamID, newHomeGoals, newAwayGoals)
+	err = s.applyStandingsEffect(ctx, tx, leagueID, homeTeamID, awayTeamID, newHomeGoals, newAwayGoals, bonuses)
 	if err != nil {
 		return fmt.Errorf("failed to apply new standings: %w", err)
 	}
@@ -560,8 +1443,8 @@ func (s *service) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayG
 	return nil
 }
 
-// reverseStandingsEffect removes the effect of the old match result from standings
-func (s *service) reverseStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
+// reverseStandingsEffect removes the effect of the old match result, including any bonus points, from standings
+func (s *service) reverseStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int, bonuses models.MatchBonuses) error {
 	// Calculate what needs to be reversed
 	var homePoints, awayPoints int
 	var homeWins, homeDraws, homeLosses int
@@ -586,6 +1469,9 @@ func (s *service) reverseStandingsEffect(ctx context.Context, tx *sql.Tx, league
 		homeDraws = 1
 		awayDraws = 1
 	}
+	homeBonus, awayBonus := bonuses.Points(homeGoals, awayGoals)
+	homePoints += homeBonus
+	awayPoints += awayBonus
 
 	// Reverse home team standings
 	homeQuery := `
@@ -632,8 +1518,8 @@ func (s *service) reverseStandingsEffect(ctx context.Context, tx *sql.Tx, league
 	return nil
 }
 
-// applyStandingsEffect applies the effect of the new match result to standings
-func (s *service) applyStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
+// applyStandingsEffect applies the effect of the new match result, including any bonus points, to standings
+func (s *service) applyStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int, bonuses models.MatchBonuses) error {
 	// Calculate what needs to be applied
 	var homePoints, awayPoints int
 	var homeWins, homeDraws, homeLosses int
@@ -658,6 +1544,9 @@ func (s *service) applyStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID
 		homeDraws = 1
 		awayDraws = 1
 	}
+	homeBonus, awayBonus := bonuses.Points(homeGoals, awayGoals)
+	homePoints += homeBonus
+	awayPoints += awayBonus
 
 	// Apply home team standings
 	homeQuery := `
@@ -703,3 +1592,51 @@ func (s *service) applyStandingsEffect(ctx context.Context, tx *sql.Tx, leagueID
 
 	return nil
 }
+
+// SetTeamGroup assigns a team to a group within a league; an empty group removes it from its group
+func (s *service) SetTeamGroup(ctx context.Context, leagueID, teamID int, group string) error {
+	query := `UPDATE league_teams SET group_name = $3 WHERE league_id = $1 AND team_id = $2`
+
+	result, err := s.db.ExecContext(ctx, query, leagueID, teamID, group)
+	if err != nil {
+		return fmt.Errorf("failed to set group of team %d in league %d: %w", teamID, leagueID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after setting group of team %d in league %d: %w", teamID, leagueID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no team found with ID %d in league %d", teamID, leagueID)
+	}
+
+	return nil
+}
+
+// GetTeamGroups retrieves the group of every team in a league that has been assigned one, keyed by team ID
+func (s *service) GetTeamGroups(ctx context.Context, leagueID int) (map[int]string, error) {
+	query := `SELECT team_id, group_name FROM league_teams WHERE league_id = $1 AND group_name <> ''`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups in league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	groups := make(map[int]string)
+	for rows.Next() {
+		var teamID int
+		var group string
+		if err := rows.Scan(&teamID, &group); err != nil {
+			return nil, fmt.Errorf("failed to scan team group: %w", err)
+		}
+		groups[teamID] = group
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team groups: %w", err)
+	}
+
+	return groups, nil
+}
@@ -30,6 +30,22 @@ func (s *service) InitializeTables(ctx context.Context) error {
 		return fmt.Errorf("failed to create standings table: %w", err)
 	}
 
+	if err := s.createIdempotencyKeysTable(ctx); err != nil {
+		return fmt.Errorf("failed to create idempotency_keys table: %w", err)
+	}
+
+	if err := s.createLeagueEventsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create league_events table: %w", err)
+	}
+
+	if err := s.createRivalriesTable(ctx); err != nil {
+		return fmt.Errorf("failed to create rivalries table: %w", err)
+	}
+
+	if err := s.createSimulationRunsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create simulation_runs table: %w", err)
+	}
+
 	if err := s.insertDefaultTeams(ctx); err != nil {
 		return fmt.Errorf("failed to insert default teams: %w", err)
 	}
@@ -44,7 +60,9 @@ func (s *service) createTeamsTable(ctx context.Context) error {
 		CREATE TABLE IF NOT EXISTS teams (
 			id SERIAL PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
-			strength INTEGER NOT NULL DEFAULT 0
+			strength INTEGER NOT NULL DEFAULT 0,
+			primary_color VARCHAR(7) NOT NULL DEFAULT '',
+			logo_url TEXT NOT NULL DEFAULT ''
 		);
 	`
 
@@ -52,6 +70,17 @@ func (s *service) createTeamsTable(ctx context.Context) error {
 		return fmt.Errorf("failed to create teams table: %w", err)
 	}
 
+	// Add the branding columns to teams tables created before they existed
+	alterTableQuery := `
+		ALTER TABLE teams
+			ADD COLUMN IF NOT EXISTS primary_color VARCHAR(7) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS logo_url TEXT NOT NULL DEFAULT '';
+	`
+
+	if _, err := s.db.ExecContext(ctx, alterTableQuery); err != nil {
+		return fmt.Errorf("failed to add columns to teams table: %w", err)
+	}
+
 	return nil
 }
 
@@ -63,7 +92,24 @@ func (s *service) createLeaguesTable(ctx context.Context) error {
 			name VARCHAR(255) NOT NULL,
 			status VARCHAR(50) NOT NULL DEFAULT 'created',
 			current_week INTEGER NOT NULL DEFAULT 0,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			champion_spots INTEGER NOT NULL DEFAULT 1,
+			promotion_spots INTEGER NOT NULL DEFAULT 0,
+			relegation_spots INTEGER NOT NULL DEFAULT 0,
+			score_correlation DOUBLE PRECISION NOT NULL DEFAULT 0,
+			upset_factor DOUBLE PRECISION NOT NULL DEFAULT 0,
+			expectancy_base DOUBLE PRECISION NOT NULL DEFAULT 1.5,
+			expectancy_min DOUBLE PRECISION NOT NULL DEFAULT 0.5,
+			expectancy_max DOUBLE PRECISION NOT NULL DEFAULT 3.0,
+			clean_sheet_bonus INTEGER NOT NULL DEFAULT 0,
+			final_tiebreak VARCHAR(20) NOT NULL DEFAULT 'name',
+			tiebreak_seed BIGINT NOT NULL DEFAULT 0,
+			fatigue_penalty INTEGER NOT NULL DEFAULT 0,
+			matches_per_week INTEGER NOT NULL DEFAULT 0,
+			bye_points INTEGER NOT NULL DEFAULT 0,
+			big_win_margin INTEGER NOT NULL DEFAULT 0,
+			big_win_bonus INTEGER NOT NULL DEFAULT 0,
+			week_numbering VARCHAR(20) NOT NULL DEFAULT 'zero_based'
 		);
 	`
 
@@ -71,6 +117,32 @@ func (s *service) createLeaguesTable(ctx context.Context) error {
 		return fmt.Errorf("failed to create leagues table: %w", err)
 	}
 
+	// Add the zone threshold and simulation columns to leagues tables created before they existed
+	alterTableQuery := `
+		ALTER TABLE leagues
+			ADD COLUMN IF NOT EXISTS champion_spots INTEGER NOT NULL DEFAULT 1,
+			ADD COLUMN IF NOT EXISTS promotion_spots INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS relegation_spots INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS score_correlation DOUBLE PRECISION NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS upset_factor DOUBLE PRECISION NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS expectancy_base DOUBLE PRECISION NOT NULL DEFAULT 1.5,
+			ADD COLUMN IF NOT EXISTS expectancy_min DOUBLE PRECISION NOT NULL DEFAULT 0.5,
+			ADD COLUMN IF NOT EXISTS expectancy_max DOUBLE PRECISION NOT NULL DEFAULT 3.0,
+			ADD COLUMN IF NOT EXISTS clean_sheet_bonus INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS final_tiebreak VARCHAR(20) NOT NULL DEFAULT 'name',
+			ADD COLUMN IF NOT EXISTS tiebreak_seed BIGINT NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS fatigue_penalty INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS matches_per_week INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS bye_points INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS big_win_margin INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS big_win_bonus INTEGER NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS week_numbering VARCHAR(20) NOT NULL DEFAULT 'zero_based';
+	`
+
+	if _, err := s.db.ExecContext(ctx, alterTableQuery); err != nil {
+		return fmt.Errorf("failed to add columns to leagues table: %w", err)
+	}
+
 	return nil
 }
 
@@ -81,6 +153,7 @@ func (s *service) createLeagueTeamsTable(ctx context.Context) error {
 			league_id INTEGER NOT NULL,
 			team_id INTEGER NOT NULL,
 			joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			group_name VARCHAR(50) NOT NULL DEFAULT '',
 			PRIMARY KEY (league_id, team_id),
 			FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
 			FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
@@ -91,6 +164,16 @@ func (s *service) createLeagueTeamsTable(ctx context.Context) error {
 		return fmt.Errorf("failed to create league_teams table: %w", err)
 	}
 
+	// Add the group column to league_teams tables created before it existed
+	alterTableQuery := `
+		ALTER TABLE league_teams
+			ADD COLUMN IF NOT EXISTS group_name VARCHAR(50) NOT NULL DEFAULT '';
+	`
+
+	if _, err := s.db.ExecContext(ctx, alterTableQuery); err != nil {
+		return fmt.Errorf("failed to add columns to league_teams table: %w", err)
+	}
+
 	return nil
 }
 
@@ -108,6 +191,8 @@ func (s *service) createMatchesTable(ctx context.Context) error {
 			status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
 			played_at TIMESTAMP WITH TIME ZONE,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			kickoff_time TIMESTAMP WITH TIME ZONE,
+			updated_at TIMESTAMP WITH TIME ZONE,
 			FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
 			FOREIGN KEY (home_team_id) REFERENCES teams(id) ON DELETE CASCADE,
 			FOREIGN KEY (away_team_id) REFERENCES teams(id) ON DELETE CASCADE,
@@ -119,6 +204,17 @@ func (s *service) createMatchesTable(ctx context.Context) error {
 		return fmt.Errorf("failed to create matches table: %w", err)
 	}
 
+	// Add the kickoff and edit columns to matches tables created before they existed
+	alterTableQuery := `
+		ALTER TABLE matches
+			ADD COLUMN IF NOT EXISTS kickoff_time TIMESTAMP WITH TIME ZONE,
+			ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+	`
+
+	if _, err := s.db.ExecContext(ctx, alterTableQuery); err != nil {
+		return fmt.Errorf("failed to add columns to matches table: %w", err)
+	}
+
 	return nil
 }
 
@@ -149,6 +245,91 @@ func (s *service) createStandingsTable(ctx context.Context) error {
 	return nil
 }
 
+// createIdempotencyKeysTable creates the idempotency_keys table
+func (s *service) createIdempotencyKeysTable(ctx context.Context) error {
+	createTableQuery := `
+		CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key VARCHAR(255) PRIMARY KEY,
+			league_id INTEGER,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE
+		);
+	`
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("failed to create idempotency_keys table: %w", err)
+	}
+
+	return nil
+}
+
+// createLeagueEventsTable creates the league_events table holding each league's audit log
+func (s *service) createLeagueEventsTable(ctx context.Context) error {
+	createTableQuery := `
+		CREATE TABLE IF NOT EXISTS league_events (
+			id SERIAL PRIMARY KEY,
+			league_id INTEGER NOT NULL,
+			event_type VARCHAR(50) NOT NULL,
+			details TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_league_events_league_id ON league_events(league_id);
+	`
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("failed to create league_events table: %w", err)
+	}
+
+	return nil
+}
+
+// createSimulationRunsTable creates the simulation_runs table recording the parameters and
+// resulting table of each play-all run
+func (s *service) createSimulationRunsTable(ctx context.Context) error {
+	createTableQuery := `
+		CREATE TABLE IF NOT EXISTS simulation_runs (
+			id SERIAL PRIMARY KEY,
+			league_id INTEGER NOT NULL,
+			mode VARCHAR(20) NOT NULL,
+			temperature DOUBLE PRECISION,
+			starting_week INTEGER NOT NULL,
+			final_week INTEGER NOT NULL,
+			table_hash VARCHAR(64) NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_simulation_runs_league_id ON simulation_runs(league_id);
+	`
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("failed to create simulation_runs table: %w", err)
+	}
+
+	return nil
+}
+
+// createRivalriesTable creates the rivalries table; each pair is stored once with the lower team ID first
+func (s *service) createRivalriesTable(ctx context.Context) error {
+	createTableQuery := `
+		CREATE TABLE IF NOT EXISTS rivalries (
+			team1_id INTEGER NOT NULL,
+			team2_id INTEGER NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (team1_id, team2_id),
+			FOREIGN KEY (team1_id) REFERENCES teams(id) ON DELETE CASCADE,
+			FOREIGN KEY (team2_id) REFERENCES teams(id) ON DELETE CASCADE,
+			CHECK (team1_id < team2_id)
+		);
+	`
+
+	if _, err := s.db.ExecContext(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("failed to create rivalries table: %w", err)
+	}
+
+	return nil
+}
+
 // insertDefaultTeams inserts default teams if they don't already exist
 func (s *service) insertDefaultTeams(ctx context.Context) error {
 	defaultTeams := []struct {
@@ -2,76 +2,136 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"insider-league-manager/internal/models"
 )
 
+// uniqueViolationCode is the Postgres error code for a unique constraint violation
+const uniqueViolationCode = "23505"
+
+// isUniqueViolation reports whether err was caused by a unique constraint, such as one on team names
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// teamColumns lists the teams columns in the order scanTeam reads them
+const teamColumns = `id, name, strength, primary_color, logo_url`
+
+// scanTeam scans a team row selected with teamColumns
+func scanTeam(row rowScanner) (*models.Team, error) {
+	team := &models.Team{}
+	err := row.Scan(
+		&team.ID,
+		&team.Name,
+		&team.Strength,
+		&team.PrimaryColor,
+		&team.LogoURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// scanTeams scans all team rows selected with teamColumns
+func scanTeams(rows *sql.Rows) ([]*models.Team, error) {
+	var teams []*models.Team
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over teams: %w", err)
+	}
+
+	return teams, nil
+}
+
 // CreateTeam creates a new team in the database
 func (s *service) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
 	// Insert the new team
 	insertQuery := `
-		INSERT INTO teams (name, strength)
-		VALUES ($1, $2)
-		RETURNING id, name, strength
-	`
+		INSERT INTO teams (name, strength, primary_color, logo_url)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + teamColumns
 
-	team := &models.Team{}
-	err := s.db.QueryRowContext(
+	team, err := scanTeam(s.db.QueryRowContext(
 		ctx,
 		insertQuery,
 		req.Name,
 		req.Strength,
-	).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Strength,
-	)
+		req.PrimaryColor,
+		req.LogoURL,
+	))
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to create team: team name %q is already taken: %w", req.Name, err)
+		}
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
 	return team, nil
 }
 
-// GetAllTeams retrieves all teams from the database
-func (s *service) GetAllTeams(ctx context.Context) ([]*models.Team, error) {
-	query := `SELECT id, name, strength FROM teams ORDER BY id`
-
-	rows, err := s.db.QueryContext(ctx, query)
+// CreateTeams creates several teams in one transaction, so either all of them are created or none
+func (s *service) CreateTeams(ctx context.Context, reqs []models.CreateTeamRequest) ([]*models.Team, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query teams: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	var teams []*models.Team
-	for rows.Next() {
-		team := &models.Team{}
-		err := rows.Scan(&team.ID, &team.Name, &team.Strength)
+	teams := make([]*models.Team, 0, len(reqs))
+	for _, req := range reqs {
+		team, err := scanTeam(tx.QueryRowContext(ctx, `
+			INSERT INTO teams (name, strength, primary_color, logo_url)
+			VALUES ($1, $2, $3, $4)
+			RETURNING `+teamColumns, req.Name, req.Strength, req.PrimaryColor, req.LogoURL))
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan team: %w", err)
+			if isUniqueViolation(err) {
+				return nil, fmt.Errorf("failed to create team: team name %q is already taken: %w", req.Name, err)
+			}
+			return nil, fmt.Errorf("failed to create team %s: %w", req.Name, err)
 		}
 		teams = append(teams, team)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over teams: %w", err)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return teams, nil
 }
 
+// GetAllTeams retrieves all teams from the database
+func (s *service) GetAllTeams(ctx context.Context) ([]*models.Team, error) {
+	query := `SELECT ` + teamColumns + ` FROM teams ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
 // GetTeamByID retrieves a team by its ID
 func (s *service) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
-	query := `SELECT id, name, strength FROM teams WHERE id = $1`
+	query := `SELECT ` + teamColumns + ` FROM teams WHERE id = $1`
 
-	team := &models.Team{}
-	err := s.db.QueryRowContext(ctx, query, teamID).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Strength,
-	)
+	team, err := scanTeam(s.db.QueryRowContext(ctx, query, teamID))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team by ID %d: %w", teamID, err)
@@ -84,25 +144,24 @@ func (s *service) GetTeamByID(ctx context.Context, teamID int) (*models.Team, er
 func (s *service) UpdateTeam(ctx context.Context, teamID int, req *models.CreateTeamRequest) (*models.Team, error) {
 	updateQuery := `
 		UPDATE teams 
-		SET name = $1, strength = $2
-		WHERE id = $3
-		RETURNING id, name, strength
-	`
+		SET name = $1, strength = $2, primary_color = $3, logo_url = $4
+		WHERE id = $5
+		RETURNING ` + teamColumns
 
-	team := &models.Team{}
-	err := s.db.QueryRowContext(
+	team, err := scanTeam(s.db.QueryRowContext(
 		ctx,
 		updateQuery,
 		req.Name,
 		req.Strength,
+		req.PrimaryColor,
+		req.LogoURL,
 		teamID,
-	).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Strength,
-	)
+	))
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to update team with ID %d: team name %q is already taken: %w", teamID, req.Name, err)
+		}
 		return nil, fmt.Errorf("failed to update team with ID %d: %w", teamID, err)
 	}
 
@@ -129,3 +188,150 @@ func (s *service) DeleteTeam(ctx context.Context, teamID int) error {
 
 	return nil
 }
+
+// GetLeaguesForTeam retrieves all leagues a team belongs to with the team's current standing position
+func (s *service) GetLeaguesForTeam(ctx context.Context, teamID int) ([]models.TeamLeague, error) {
+	query := `
+		SELECT l.id, l.name, l.status, l.current_week, l.created_at,
+		       l.champion_spots, l.promotion_spots, l.relegation_spots, l.score_correlation, l.upset_factor,
+		       l.expectancy_base, l.expectancy_min, l.expectancy_max, l.clean_sheet_bonus, l.final_tiebreak, l.tiebreak_seed,
+		       l.fatigue_penalty, l.matches_per_week, l.bye_points,
+		       l.big_win_margin, l.big_win_bonus, l.week_numbering, ranked.position
+		FROM league_teams lt
+		INNER JOIN leagues l ON l.id = lt.league_id
+		LEFT JOIN (
+			SELECT s.league_id, s.team_id,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY s.league_id
+			           ORDER BY ` + standingsOrder + `
+			       ) AS position
+			FROM standings s
+			INNER JOIN teams t ON s.team_id = t.id
+			INNER JOIN leagues l ON s.league_id = l.id
+		) ranked ON ranked.league_id = lt.league_id AND ranked.team_id = lt.team_id
+		WHERE lt.team_id = $1
+		ORDER BY l.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leagues for team %d: %w", teamID, err)
+	}
+	defer rows.Close()
+
+	leagues := []models.TeamLeague{}
+	for rows.Next() {
+		var league models.League
+		var teamLeague models.TeamLeague
+		err := rows.Scan(
+			&league.ID,
+			&league.Name,
+			&league.Status,
+			&league.CurrentWeek,
+			&league.CreatedAt,
+			&league.Zones.ChampionSpots,
+			&league.Zones.PromotionSpots,
+			&league.Zones.RelegationSpots,
+			&league.ScoreCorrelation,
+			&league.UpsetFactor,
+			&league.Scoring.ExpectancyBase,
+			&league.Scoring.ExpectancyMin,
+			&league.Scoring.ExpectancyMax,
+			&league.CleanSheetBonus,
+			&league.FinalTiebreak,
+			&league.TiebreakSeed,
+			&league.FatiguePenalty,
+			&league.MatchesPerWeek,
+			&league.ByePoints,
+			&league.BigWinMargin,
+			&league.BigWinBonus,
+			&league.WeekNumbering,
+			&teamLeague.Position,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team league: %w", err)
+		}
+		teamLeague.League = models.NewLeagueResponse(&league)
+		leagues = append(leagues, teamLeague)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over team leagues: %w", err)
+	}
+
+	return leagues, nil
+}
+
+// GetHeadToHead retrieves played matches between two teams in either venue, most recent first.
+// A leagueID of 0 searches across all leagues.
+func (s *service) GetHeadToHead(ctx context.Context, teamID, opponentID, leagueID, limit int) ([]*models.Match, error) {
+	query := `SELECT ` + matchColumns + ` FROM matches
+		WHERE status = 'played'
+		AND ((home_team_id = $1 AND away_team_id = $2) OR (home_team_id = $2 AND away_team_id = $1))
+		AND ($3::int = 0 OR league_id = $3::int)
+		ORDER BY played_at DESC, id DESC
+		LIMIT $4`
+
+	rows, err := s.db.QueryContext(ctx, query, teamID, opponentID, leagueID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head-to-head matches for teams %d and %d: %w", teamID, opponentID, err)
+	}
+	defer rows.Close()
+
+	return scanMatches(rows)
+}
+
+// AddRivalry registers two teams as rivals. The pair is stored with the lower team ID first,
+// so a rivalry is the same whichever side registers it.
+func (s *service) AddRivalry(ctx context.Context, teamID, rivalID int) error {
+	insertQuery := `
+		INSERT INTO rivalries (team1_id, team2_id)
+		VALUES (LEAST($1::int, $2::int), GREATEST($1::int, $2::int))
+		ON CONFLICT (team1_id, team2_id) DO NOTHING
+	`
+
+	if _, err := s.db.ExecContext(ctx, insertQuery, teamID, rivalID); err != nil {
+		return fmt.Errorf("failed to add rivalry between teams %d and %d: %w", teamID, rivalID, err)
+	}
+
+	return nil
+}
+
+// GetRivals retrieves the teams registered as rivals of a team, ordered by name
+func (s *service) GetRivals(ctx context.Context, teamID int) ([]*models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE id IN (
+			SELECT team2_id FROM rivalries WHERE team1_id = $1
+			UNION
+			SELECT team1_id FROM rivalries WHERE team2_id = $1
+		)
+		ORDER BY name
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rivals of team %d: %w", teamID, err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+// IsRivalry reports whether two teams are registered rivals
+func (s *service) IsRivalry(ctx context.Context, teamID, rivalID int) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM rivalries
+			WHERE team1_id = LEAST($1::int, $2::int) AND team2_id = GREATEST($1::int, $2::int)
+		)
+	`
+
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, query, teamID, rivalID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check rivalry between teams %d and %d: %w", teamID, rivalID, err)
+	}
+
+	return exists, nil
+}
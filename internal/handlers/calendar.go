@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"insider-league-manager/internal/models"
+)
+
+// calendarMatchDuration is how long a fixture blocks out in a calendar, covering both halves and the break
+const calendarMatchDuration = 2 * time.Hour
+
+// icsTimeFormat is the RFC 5545 UTC date-time format, e.g. 20240803T150000Z
+const icsTimeFormat = "20060102T150405Z"
+
+// writeCalendar writes a league's fixtures as an RFC 5545 iCalendar with one event per match.
+// Matches without a kickoff time have nothing to place in a calendar and are left out.
+func writeCalendar(w http.ResponseWriter, league *models.League, matches []*models.Match, teamNames map[int]string) {
+	stamp := time.Now().UTC().Format(icsTimeFormat)
+
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Insider League Manager//Fixtures//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:"+escapeICSText(league.Name),
+	)
+
+	for _, match := range matches {
+		if match.KickoffTime == nil {
+			continue
+		}
+
+		description := fmt.Sprintf("%s week %d", league.Name, match.Week)
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			description += fmt.Sprintf(", final score %d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+
+		kickoff := match.KickoffTime.UTC()
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:league-%d-match-%d@insider-league-manager", league.ID, match.ID),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+kickoff.Format(icsTimeFormat),
+			"DTEND:"+kickoff.Add(calendarMatchDuration).Format(icsTimeFormat),
+			"SUMMARY:"+escapeICSText(fmt.Sprintf("%s vs %s", teamNames[match.HomeTeamID], teamNames[match.AwayTeamID])),
+			"DESCRIPTION:"+escapeICSText(description),
+		)
+		if match.Status == "cancelled" {
+			lines = append(lines, "STATUS:CANCELLED")
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var body strings.Builder
+	for _, line := range lines {
+		body.WriteString(foldICSLine(line))
+		body.WriteString("\r\n")
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="league-%d.ics"`, league.ID))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(body.String()))
+}
+
+// escapeICSText escapes the characters RFC 5545 reserves in TEXT values
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// foldICSLine splits a content line longer than 75 octets into continuation lines,
+// each starting with a space, without breaking a UTF-8 character in two
+func foldICSLine(line string) string {
+	const limit = 75
+
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// parsePathID parses a league, team or match ID taken from a URL path.
+// Only canonical positive integers are accepted: no leading zeros, signs or whitespace.
+func parsePathID(value string) (int, error) {
+	if value == "" {
+		return 0, fmt.Errorf("ID is empty")
+	}
+
+	for i, c := range value {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q is not a positive integer", value)
+		}
+		if i == 0 && c == '0' {
+			return 0, fmt.Errorf("%q must be a positive integer without leading zeros", value)
+		}
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is out of range", value)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePathID(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "1", want: 1},
+		{value: "42", want: 42},
+		{value: "007", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "+1", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: " 1", wantErr: true},
+		{value: "1 ", wantErr: true},
+		{value: "", wantErr: true},
+		{value: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePathID(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parsePathID(%q) expected error, got %d", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePathID(%q) unexpected error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePathID(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGetTeamByIDHandler_NonCanonicalID(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	for _, path := range []string{"/api/teams/007", "/api/teams/+1", "/api/teams/%201"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+
+		handler.GetTeamByIDHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, path, w.Code)
+		}
+	}
+}
@@ -2,11 +2,18 @@ package handlers
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"math/rand"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +32,9 @@ func NewLeagueHandler(db database.Service) *LeagueHandler {
 	}
 }
 
+// maxIdempotencyKeyLength matches the size of the idempotency_keys.key column
+const maxIdempotencyKeyLength = 255
+
 // CreateLeagueHandler handles POST /api/leagues/create
 func (lh *LeagueHandler) CreateLeagueHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -33,40 +43,49 @@ func (lh *LeagueHandler) CreateLeagueHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	var req models.CreateLeagueRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
-	// Basic validation
-	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "League name is required", http.StatusBadRequest)
+	// Validate every field, reporting all problems together
+	if errs := validateLeagueRequest(&req); len(errs) > 0 {
+		writeValidationErrors(w, r, errs)
 		return
 	}
 
-	// Create the league
-	league, err := lh.db.CreateLeague(r.Context(), &req)
+	// Create the league, at most once per Idempotency-Key when one is provided
+	var league *models.League
+	var err error
+	created := true // false when an Idempotency-Key replays an earlier request
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if key != "" {
+		if len(key) > maxIdempotencyKeyLength {
+			http.Error(w, fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength), http.StatusBadRequest)
+			return
+		}
+
+		league, created, err = lh.db.CreateLeagueWithIdempotencyKey(r.Context(), key, &req)
+		if err == nil && !created {
+			w.Header().Set("Idempotent-Replayed", "true")
+		}
+	} else {
+		league, err = lh.db.CreateLeague(r.Context(), &req)
+	}
 	if err != nil {
 		log.Printf("Failed to create league: %v", err)
 		http.Error(w, "Failed to create league", http.StatusInternalServerError)
 		return
 	}
 
-	// Convert to response format
-	resp := models.LeagueResponse{
-		ID:          league.ID,
-		Name:        league.Name,
-		Status:      league.Status,
-		CurrentWeek: league.CurrentWeek,
-		CreatedAt:   league.CreatedAt,
+	if created {
+		lh.recordEvent(r.Context(), league.ID, models.LeagueEventCreated, fmt.Sprintf("League '%s' created", league.Name))
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	// Convert to response format
+	resp := models.NewLeagueResponse(league)
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusCreated, resp)
 }
 
 // InitializeLeagueHandler handles POST /api/leagues/initialize
@@ -77,8 +96,106 @@ func (lh *LeagueHandler) InitializeLeagueHandler(w http.ResponseWriter, r *http.
 	}
 
 	var req models.CreateLeagueRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Validate every field, reporting all problems together
+	if errs := validateLeagueRequest(&req); len(errs) > 0 {
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Get default teams
+	teams, err := lh.db.GetDefaultTeams(ctx)
+	if err != nil {
+		log.Printf("Failed to get default teams: %v", err)
+		http.Error(w, "Failed to get default teams", http.StatusInternalServerError)
+		return
+	}
+
+	// Corrupted seed strengths still initialize the league, but are flagged
+	warning := implausibleDefaultTeams(teams)
+	if warning != "" {
+		log.Printf("WARN %s", warning)
+	}
+
+	// 2. Create the league, add the teams and initialize their standings atomically
+	league, err := lh.db.InitializeLeagueWithTeams(ctx, &req, teams)
+	if err != nil {
+		log.Printf("Failed to initialize league: %v", err)
+		http.Error(w, "Failed to initialize league", http.StatusInternalServerError)
+		return
+	}
+
+	lh.recordEvent(ctx, league.ID, models.LeagueEventCreated, fmt.Sprintf("League '%s' initialized with %d default teams", league.Name, len(teams)))
+
+	// Convert teams to response format
+	var teamResponses []models.Team
+	for _, team := range teams {
+		teamResponses = append(teamResponses, *team)
+	}
+
+	// Create response
+	resp := models.InitializeLeagueResponse{
+		League:  models.NewLeagueResponse(league),
+		Teams:   teamResponses,
+		Message: fmt.Sprintf("League '%s' initialized successfully with %d teams", league.Name, len(teams)),
+		Warning: warning,
+	}
+
+	writeJSON(w, r, http.StatusCreated, resp)
+}
+
+// The default teams are seeded with strengths in the 80s. One outside this band suggests the seed
+// data has been corrupted, such as a strength of 0, which makes simulated results degenerate.
+const (
+	defaultTeamMinStrength = 50
+	defaultTeamMaxStrength = 100
+)
+
+// implausibleDefaultTeams describes the default teams with a strength outside the plausible band,
+// or returns "" when every strength is plausible
+func implausibleDefaultTeams(teams []*models.Team) string {
+	var implausible []string
+	for _, team := range teams {
+		if team.Strength < defaultTeamMinStrength || team.Strength > defaultTeamMaxStrength {
+			implausible = append(implausible, fmt.Sprintf("%s (%d)", team.Name, team.Strength))
+		}
+	}
+	if len(implausible) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Default teams have strengths outside %d-%d, so simulated results may be degenerate: %s",
+		defaultTeamMinStrength, defaultTeamMaxStrength, strings.Join(implausible, ", "))
+}
+
+// CloneLeagueHandler handles POST /api/leagues/:leagueID/clone
+func (lh *LeagueHandler) CloneLeagueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "clone" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateLeagueRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
@@ -88,66 +205,169 @@ func (lh *LeagueHandler) InitializeLeagueHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Start transaction-like behavior with multiple operations
 	ctx := r.Context()
 
-	// 1. Create the league
-	league, err := lh.db.CreateLeague(ctx, &req)
+	// 1. Create the new league with the source league's teams
+	league, err := lh.db.CloneLeague(ctx, leagueID, req.Name)
 	if err != nil {
-		log.Printf("Failed to create league: %v", err)
-		http.Error(w, "Failed to create league", http.StatusInternalServerError)
+		log.Printf("Failed to clone league %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to clone league", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// 2. Get default teams
-	teams, err := lh.db.GetDefaultTeams(ctx)
+	lh.recordEvent(ctx, league.ID, models.LeagueEventCreated, fmt.Sprintf("League '%s' cloned from league %d", league.Name, leagueID))
+
+	// 2. Get the copied teams for the response
+	teams, err := lh.db.GetTeamsInLeague(ctx, league.ID)
 	if err != nil {
-		log.Printf("Failed to get default teams: %v", err)
-		http.Error(w, "Failed to get default teams", http.StatusInternalServerError)
+		log.Printf("Failed to get teams in league %d: %v", league.ID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
 		return
 	}
 
-	// 3. Add teams to league and initialize standings
+	teamResponses := make([]models.Team, 0, len(teams))
 	for _, team := range teams {
-		// Add team to league
-		if err := lh.db.AddTeamToLeague(ctx, league.ID, team.ID); err != nil {
-			log.Printf("Failed to add team %d to league %d: %v", team.ID, league.ID, err)
-			http.Error(w, "Failed to add teams to league", http.StatusInternalServerError)
-			return
-		}
+		teamResponses = append(teamResponses, *team)
+	}
+
+	resp := models.CloneLeagueResponse{
+		League:         models.NewLeagueResponse(league),
+		SourceLeagueID: leagueID,
+		Teams:          teamResponses,
+		Message:        fmt.Sprintf("League '%s' created from league %d with %d teams", league.Name, leagueID, len(teams)),
+	}
+
+	writeJSON(w, r, http.StatusCreated, resp)
+}
+
+// ImportLeagueHandler handles POST /api/leagues/import
+func (lh *LeagueHandler) ImportLeagueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-		// Initialize standings for the team
-		if err := lh.db.InitializeStanding(ctx, league.ID, team.ID); err != nil {
-			log.Printf("Failed to initialize standing for team %d in league %d: %v", team.ID, league.ID, err)
-			http.Error(w, "Failed to initialize standings", http.StatusInternalServerError)
+	// normalize=true rescales team strengths from an arbitrary scale into the simulator's 0-100 band
+	normalize := false
+	if value := r.URL.Query().Get("normalize"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid normalize value '%s'. Must be true or false", value), http.StatusBadRequest)
 			return
 		}
+		normalize = parsed
+	}
+
+	var req models.ImportLeagueRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Basic validation
+	if strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "League name is required", http.StatusBadRequest)
+		return
+	}
+
+	// 1. Validate the document so matches only reference imported teams
+	if err := validateImportDocument(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if normalize {
+		normalizeImportStrengths(req.Teams)
 	}
 
+	// 2. Create the league, teams and matches, remapping document team IDs
+	league, teams, err := lh.db.ImportLeague(r.Context(), &req)
+	if err != nil {
+		log.Printf("Failed to import league: %v", err)
+		http.Error(w, "Failed to import league", http.StatusInternalServerError)
+		return
+	}
+
+	lh.recordEvent(r.Context(), league.ID, models.LeagueEventCreated, fmt.Sprintf("League '%s' imported with %d teams and %d matches", league.Name, len(teams), len(req.Matches)))
+
 	// Convert teams to response format
-	var teamResponses []models.Team
+	teamResponses := make([]models.Team, 0, len(teams))
 	for _, team := range teams {
 		teamResponses = append(teamResponses, *team)
 	}
 
-	// Create response
-	resp := models.InitializeLeagueResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
-		Teams:   teamResponses,
-		Message: fmt.Sprintf("League '%s' initialized successfully with %d teams", league.Name, len(teams)),
+	resp := models.ImportLeagueResponse{
+		League:       models.NewLeagueResponse(league),
+		Teams:        teamResponses,
+		MatchesCount: len(req.Matches),
+		Message:      fmt.Sprintf("League '%s' imported successfully with %d teams and %d matches", league.Name, len(teams), len(req.Matches)),
+	}
+
+	writeJSON(w, r, http.StatusCreated, resp)
+}
+
+// validateImportDocument checks that imported teams are well formed and that every
+// imported match references teams from the imported set
+func validateImportDocument(req *models.ImportLeagueRequest) error {
+	teamIDs := make(map[int]bool, len(req.Teams))
+	for _, team := range req.Teams {
+		if strings.TrimSpace(team.Name) == "" {
+			return fmt.Errorf("team %d has no name", team.ID)
+		}
+		if teamIDs[team.ID] {
+			return fmt.Errorf("duplicate team id %d", team.ID)
+		}
+		teamIDs[team.ID] = true
+	}
+
+	for i, match := range req.Matches {
+		if !teamIDs[match.HomeTeamID] {
+			return fmt.Errorf("match %d references unknown home team %d", i+1, match.HomeTeamID)
+		}
+		if !teamIDs[match.AwayTeamID] {
+			return fmt.Errorf("match %d references unknown away team %d", i+1, match.AwayTeamID)
+		}
+		if match.HomeTeamID == match.AwayTeamID {
+			return fmt.Errorf("match %d has the same home and away team", i+1)
+		}
+		if match.Week < 1 {
+			return fmt.Errorf("match %d has invalid week %d", i+1, match.Week)
+		}
+		if (match.HomeGoals == nil) != (match.AwayGoals == nil) {
+			return fmt.Errorf("match %d must have both or neither goals set", i+1)
+		}
+		if (match.HomeGoals != nil && *match.HomeGoals < 0) || (match.AwayGoals != nil && *match.AwayGoals < 0) {
+			return fmt.Errorf("match %d has negative goals", i+1)
+		}
+	}
+
+	return nil
+}
+
+// normalizeImportStrengths linearly rescales team strengths so the weakest team gets 0 and the
+// strongest 100, preserving their relative order. Teams that all share one strength get neutralStrength.
+func normalizeImportStrengths(teams []models.ImportTeam) {
+	if len(teams) == 0 {
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	minStrength, maxStrength := teams[0].Strength, teams[0].Strength
+	for _, team := range teams[1:] {
+		minStrength = min(minStrength, team.Strength)
+		maxStrength = max(maxStrength, team.Strength)
+	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	for i := range teams {
+		if maxStrength == minStrength {
+			teams[i].Strength = neutralStrength
+			continue
+		}
+		scaled := float64(teams[i].Strength-minStrength) * 100 / float64(maxStrength-minStrength)
+		teams[i].Strength = int(math.Round(scaled))
 	}
 }
 
@@ -165,15 +385,15 @@ func (lh *LeagueHandler) AddTeamToLeagueHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[3])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	teamID, err := strconv.Atoi(pathParts[4])
+	teamID, err := parsePathID(pathParts[4])
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -206,7 +426,11 @@ func (lh *LeagueHandler) AddTeamToLeagueHandler(w http.ResponseWriter, r *http.R
 	// 3. Add team to league
 	if err := lh.db.AddTeamToLeague(ctx, leagueID, teamID); err != nil {
 		log.Printf("Failed to add team %d to league %d: %v", teamID, leagueID, err)
-		http.Error(w, "Failed to add team to league", http.StatusInternalServerError)
+		if strings.Contains(err.Error(), "already in league") {
+			http.Error(w, "Team is already in this league", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to add team to league", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -217,15 +441,11 @@ func (lh *LeagueHandler) AddTeamToLeagueHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	lh.recordEvent(ctx, leagueID, models.LeagueEventTeamAdded, fmt.Sprintf("Team '%s' added", team.Name))
+
 	// Create response
 	resp := models.AddTeamToLeagueResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
+		League: models.NewLeagueResponse(league),
 		Team: models.Team{
 			ID:       team.ID,
 			Name:     team.Name,
@@ -234,12 +454,7 @@ func (lh *LeagueHandler) AddTeamToLeagueHandler(w http.ResponseWriter, r *http.R
 		Message: fmt.Sprintf("Team '%s' added to league '%s' successfully", team.Name, league.Name),
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusCreated, resp)
 }
 
 // RemoveTeamFromLeagueHandler handles POST /api/leagues/remove-team/:leagueID/:teamID
@@ -256,15 +471,15 @@ func (lh *LeagueHandler) RemoveTeamFromLeagueHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[3])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	teamID, err := strconv.Atoi(pathParts[4])
+	teamID, err := parsePathID(pathParts[4])
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -294,7 +509,24 @@ func (lh *LeagueHandler) RemoveTeamFromLeagueHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	// 3. Remove team from league
+	// 3. A started league must keep at least 2 teams
+	if league.Status != "created" {
+		teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+		if err != nil {
+			log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+			http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+			return
+		}
+
+		for _, t := range teams {
+			if t.ID == teamID && len(teams) <= 2 {
+				http.Error(w, "Removing this team would leave a started league with fewer than 2 teams", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// 4. Remove team from league
 	if err := lh.db.RemoveTeamFromLeague(ctx, leagueID, teamID); err != nil {
 		log.Printf("Failed to remove team %d from league %d: %v", teamID, leagueID, err)
 		if strings.Contains(err.Error(), "is not in league") {
@@ -305,15 +537,11 @@ func (lh *LeagueHandler) RemoveTeamFromLeagueHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	lh.recordEvent(ctx, leagueID, models.LeagueEventTeamRemoved, fmt.Sprintf("Team '%s' removed", team.Name))
+
 	// Create response
 	resp := models.RemoveTeamFromLeagueResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
+		League: models.NewLeagueResponse(league),
 		Team: models.Team{
 			ID:       team.ID,
 			Name:     team.Name,
@@ -322,12 +550,61 @@ func (lh *LeagueHandler) RemoveTeamFromLeagueHandler(w http.ResponseWriter, r *h
 		Message: fmt.Sprintf("Team '%s' removed from league '%s' successfully", team.Name, league.Name),
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// AvailableTeamsHandler handles GET /api/leagues/:leagueID/available-teams
+// It lists the teams that are not yet in the league, ordered by name.
+func (lh *LeagueHandler) AvailableTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "available-teams" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the teams outside the league
+	teams, err := lh.db.GetTeamsNotInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams not in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get available teams", http.StatusInternalServerError)
+		return
+	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	resp := models.AvailableTeamsResponse{
+		League: models.NewLeagueResponse(league),
+		Teams:  make([]models.TeamResponse, 0, len(teams)),
+	}
+	for _, team := range teams {
+		resp.Teams = append(resp.Teams, models.NewTeamResponse(team))
 	}
+
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
 // StartLeagueHandler handles POST /api/leagues/start/:leagueID
@@ -344,9 +621,27 @@ func (lh *LeagueHandler) StartLeagueHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[3])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Optional first_kickoff (RFC 3339) schedules week 1 at that time and each later week 7 days on
+	var firstKickoff *time.Time
+	if value := r.URL.Query().Get("first_kickoff"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid first_kickoff '%s'. Must be an RFC 3339 timestamp", value), http.StatusBadRequest)
+			return
+		}
+		firstKickoff = &parsed
+	}
+
+	// Optional seeding=balanced orders the rounds so the opening weeks pair teams of similar strength
+	seeding := r.URL.Query().Get("seeding")
+	if seeding != "" && seeding != seedingBalanced {
+		http.Error(w, fmt.Sprintf("Invalid seeding '%s'. Must be '%s'", seeding, seedingBalanced), http.StatusBadRequest)
 		return
 	}
 
@@ -384,21 +679,36 @@ func (lh *LeagueHandler) StartLeagueHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// 5. Generate round-robin match schedule
-	matches := lh.generateRoundRobinMatches(teams, leagueID)
+	// 5. Generate the round-robin match schedule, within each group when teams are grouped
+	groups, err := lh.leagueGroups(ctx, leagueID, teams)
+	if err != nil {
+		log.Printf("Failed to get groups for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league groups", http.StatusInternalServerError)
+		return
+	}
+	if problem := validateGroups(groups); problem != "" {
+		http.Error(w, problem, http.StatusBadRequest)
+		return
+	}
 
-	// 6. Create all matches in database
-	createdMatches := 0
-	for _, match := range matches {
-		_, err := lh.db.CreateMatch(ctx, &match)
-		if err != nil {
-			log.Printf("Failed to create match: %v", err)
-			http.Error(w, "Failed to create match schedule", http.StatusInternalServerError)
-			return
+	matches := lh.generateGroupMatches(groups, leagueID, league.MatchesPerWeek, seeding == seedingBalanced)
+
+	// 6. Create all matches in database, replacing any schedule generated before the start
+	if firstKickoff != nil {
+		for i := range matches {
+			kickoff := firstKickoff.AddDate(0, 0, 7*(matches[i].Week-1))
+			matches[i].KickoffTime = &kickoff
 		}
-		createdMatches++
 	}
 
+	created, err := lh.db.ReplaceScheduledMatches(ctx, leagueID, matches)
+	if err != nil {
+		log.Printf("Failed to create match schedule for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to create match schedule", http.StatusInternalServerError)
+		return
+	}
+	createdMatches := len(created)
+
 	// 7. Update league status to "started"
 	if err := lh.db.UpdateLeagueStatus(ctx, leagueID, "started"); err != nil {
 		log.Printf("Failed to update league status: %v", err)
@@ -406,68 +716,364 @@ func (lh *LeagueHandler) StartLeagueHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	league.Status = "started"
+
 	// 8. Calculate total weeks
-	totalWeeks := lh.calculateTotalWeeks(len(teams))
+	totalWeeks := lh.groupTotalWeeks(groups, league.MatchesPerWeek)
+
+	lh.recordEvent(ctx, leagueID, models.LeagueEventStarted, fmt.Sprintf("League started with %d teams, %d matches over %d weeks", len(teams), createdMatches, totalWeeks))
 
 	// Create response
 	resp := models.StartLeagueResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      "started",
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
+		League:       models.NewLeagueResponse(league),
 		TeamsCount:   len(teams),
 		MatchesCount: createdMatches,
 		TotalWeeks:   totalWeeks,
 		Message:      fmt.Sprintf("League '%s' started successfully with %d teams and %d matches scheduled over %d weeks", league.Name, len(teams), createdMatches, totalWeeks),
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	if len(groups) == 1 && len(teams)%2 == 1 {
+		resp.Warning = fmt.Sprintf("An odd number of teams (%d) means one team has a bye each week; an even team count avoids byes", len(teams))
+	}
+	for _, group := range groups {
+		if len(groups) > 1 && len(group.teams)%2 == 1 {
+			resp.Warning = fmt.Sprintf("Group '%s' has an odd number of teams (%d), so one of its teams has a bye each week", group.name, len(group.teams))
+			break
+		}
 	}
-}
 
-// generateRoundRobinMatches creates a Premier League style schedule where each team plays every other team twice (home and away)
-// First half: each team plays every other team once, properly distributed across weeks
-// Second half: each team plays every other team again with home/away reversed
-func (lh *LeagueHandler) generateRoundRobinMatches(teams []*models.Team, leagueID int) []models.Match {
-	var matches []models.Match
-	n := len(teams)
+	writeJSON(w, r, http.StatusOK, resp)
+}
 
-	if n < 2 {
-		return matches
+// RegenerateScheduleHandler handles POST /api/leagues/:leagueID/regenerate-schedule
+// It replaces a created league's scheduled matches with a fresh round-robin for its current teams.
+func (lh *LeagueHandler) RegenerateScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
 
-	// For proper round-robin scheduling, we need to handle even and odd number of teams
-	if n%2 == 1 {
-		// Add a "bye" team for odd number of teams to make scheduling easier
-		byeTeam := &models.Team{ID: -1, Name: "BYE"}
-		teams = append(teams, byeTeam)
-		n = len(teams)
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "regenerate-schedule" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
 	}
 
-	var firstHalfMatches []models.Match
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
 
-	// Generate first half using round-robin algorithm
-	// Each round has n/2 matches, and we need n-1 rounds for everyone to play everyone once
-	for round := 0; round < n-1; round++ {
-		weekMatches := lh.generateRoundMatches(teams, round)
+	ctx := r.Context()
 
-		for _, match := range weekMatches {
-			// Skip matches involving the "bye" team
-			if match.HomeTeamID == -1 || match.AwayTeamID == -1 {
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. The schedule is fixed once the league has started
+	if league.Status != "created" {
+		http.Error(w, fmt.Sprintf("The schedule can only be regenerated before the league starts. Current status: %s", league.Status), http.StatusConflict)
+		return
+	}
+
+	// 3. Get the league's current teams
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		return
+	}
+
+	if len(teams) < 2 {
+		http.Error(w, "League must have at least 2 teams to schedule matches", http.StatusBadRequest)
+		return
+	}
+
+	groups, err := lh.leagueGroups(ctx, leagueID, teams)
+	if err != nil {
+		log.Printf("Failed to get groups for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league groups", http.StatusInternalServerError)
+		return
+	}
+	if problem := validateGroups(groups); problem != "" {
+		http.Error(w, problem, http.StatusBadRequest)
+		return
+	}
+
+	// 4. Replace the scheduled matches with a new round-robin
+	created, err := lh.db.ReplaceScheduledMatches(ctx, leagueID, lh.generateGroupMatches(groups, leagueID, league.MatchesPerWeek, false))
+	if err != nil {
+		log.Printf("Failed to regenerate schedule for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to regenerate match schedule", http.StatusInternalServerError)
+		return
+	}
+
+	totalWeeks := lh.groupTotalWeeks(groups, league.MatchesPerWeek)
+
+	resp := models.RegenerateScheduleResponse{
+		League:       models.NewLeagueResponse(league),
+		TeamsCount:   len(teams),
+		MatchesCount: len(created),
+		TotalWeeks:   totalWeeks,
+		Message:      fmt.Sprintf("Schedule for league '%s' regenerated with %d matches for %d teams over %d weeks", league.Name, len(created), len(teams), totalWeeks),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// teamGroup is a set of a league's teams that play a round-robin among themselves
+type teamGroup struct {
+	name  string
+	teams []*models.Team
+}
+
+// leagueGroups splits a league's teams by their group, ordered by group name. Teams without a
+// group share the unnamed group, so a league with no groups assigned is one group of every team.
+func (lh *LeagueHandler) leagueGroups(ctx context.Context, leagueID int, teams []*models.Team) ([]teamGroup, error) {
+	assigned, err := lh.db.GetTeamGroups(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team groups for league %d: %w", leagueID, err)
+	}
+
+	byName := make(map[string][]*models.Team)
+	var names []string
+	for _, team := range teams {
+		name := assigned[team.ID]
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], team)
+	}
+	sort.Strings(names)
+
+	groups := make([]teamGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, teamGroup{name: name, teams: byName[name]})
+	}
+	return groups, nil
+}
+
+// scheduledGroups splits the league's teams that have a fixture among matches by their group, as
+// leagueGroups does. A team added after the schedule was drawn has no fixtures, so it neither
+// lengthens the season nor sits out weeks on a bye.
+func (lh *LeagueHandler) scheduledGroups(ctx context.Context, leagueID int, matches []*models.Match) ([]teamGroup, error) {
+	scheduled := make(map[int]bool)
+	for _, match := range matches {
+		scheduled[match.HomeTeamID] = true
+		scheduled[match.AwayTeamID] = true
+	}
+
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams in league %d: %w", leagueID, err)
+	}
+	var scheduledTeams []*models.Team
+	for _, team := range teams {
+		if scheduled[team.ID] {
+			scheduledTeams = append(scheduledTeams, team)
+		}
+	}
+	return lh.leagueGroups(ctx, leagueID, scheduledTeams)
+}
+
+// validateGroups describes why a league's groups can't be scheduled, or returns "" if they can.
+// Either no team or every team must be in a group, and each group needs an opponent for its teams.
+func validateGroups(groups []teamGroup) string {
+	if len(groups) < 2 {
+		return ""
+	}
+	for _, group := range groups {
+		if group.name == "" {
+			return fmt.Sprintf("%d teams have no group. Assign every team to a group, or none", len(group.teams))
+		}
+		if len(group.teams) < 2 {
+			return fmt.Sprintf("Group '%s' must have at least 2 teams", group.name)
+		}
+	}
+	return ""
+}
+
+// seedingBalanced is the StartLeague seeding that plays the rounds with the closest matchups first
+const seedingBalanced = "balanced"
+
+// generateGroupMatches schedules a double round-robin within each group, every group starting in week 1.
+// balanced reorders each group's rounds with balanceRoundOrder. A positive matchesPerWeek spreads
+// each round over as many weeks as it needs to stay within the limit.
+func (lh *LeagueHandler) generateGroupMatches(groups []teamGroup, leagueID, matchesPerWeek int, balanced bool) []models.Match {
+	var matches []models.Match
+	for _, group := range groups {
+		groupMatches := lh.generateRoundRobinMatches(group.teams, leagueID)
+		if balanced {
+			groupMatches = balanceRoundOrder(groupMatches, group.teams)
+		}
+		matches = append(matches, groupMatches...)
+	}
+	if matchesPerWeek > 0 {
+		matches = spreadWeeks(matches, matchesPerWeek)
+	}
+	return matches
+}
+
+// balanceRoundOrder reorders the rounds of a double round-robin so that, in each half, the rounds
+// with the smallest average strength gap between opponents are played first. Only the week of each
+// match changes: the same teams meet at the same venues, and the second half still mirrors the first.
+func balanceRoundOrder(matches []models.Match, teams []*models.Team) []models.Match {
+	strengths := make(map[int]int, len(teams))
+	for _, team := range teams {
+		strengths[team.ID] = team.Strength
+	}
+
+	halfWeeks := 0
+	for _, match := range matches {
+		halfWeeks = max(halfWeeks, match.Week)
+	}
+	halfWeeks /= 2
+
+	// Total the strength gaps of each first-half round
+	gaps := make(map[int]int, halfWeeks)
+	counts := make(map[int]int, halfWeeks)
+	for _, match := range matches {
+		if match.Week > halfWeeks {
+			continue
+		}
+		gap := strengths[match.HomeTeamID] - strengths[match.AwayTeamID]
+		if gap < 0 {
+			gap = -gap
+		}
+		gaps[match.Week] += gap
+		counts[match.Week]++
+	}
+
+	// Order the rounds by their average gap, comparing the averages without dividing
+	rounds := make([]int, 0, halfWeeks)
+	for week := 1; week <= halfWeeks; week++ {
+		rounds = append(rounds, week)
+	}
+	sort.SliceStable(rounds, func(i, j int) bool {
+		a, b := rounds[i], rounds[j]
+		return gaps[a]*counts[b] < gaps[b]*counts[a]
+	})
+
+	newWeek := make(map[int]int, halfWeeks)
+	for i, round := range rounds {
+		newWeek[round] = i + 1
+	}
+	for i := range matches {
+		if week := matches[i].Week; week <= halfWeeks {
+			matches[i].Week = newWeek[week]
+		} else {
+			matches[i].Week = newWeek[week-halfWeeks] + halfWeeks
+		}
+	}
+	return matches
+}
+
+// spreadWeeks renumbers the weeks of a schedule so no week holds more than matchesPerWeek matches.
+// Each round keeps its matches together and in order, split across consecutive weeks.
+func spreadWeeks(matches []models.Match, matchesPerWeek int) []models.Match {
+	byWeek := make(map[int][]int)
+	lastRound := 0
+	for i, match := range matches {
+		byWeek[match.Week] = append(byWeek[match.Week], i)
+		lastRound = max(lastRound, match.Week)
+	}
+
+	week := 1
+	for round := 1; round <= lastRound; round++ {
+		indexes := byWeek[round]
+		for j, i := range indexes {
+			matches[i].Week = week + j/matchesPerWeek
+		}
+		week += weeksPerRound(len(indexes), matchesPerWeek)
+	}
+	return matches
+}
+
+// weeksPerRound returns the number of weeks a round of matches takes with at most matchesPerWeek in each.
+// A matchesPerWeek of 0 means no limit, so every round fits in a single week.
+func weeksPerRound(matches, matchesPerWeek int) int {
+	if matches == 0 {
+		return 0
+	}
+	if matchesPerWeek <= 0 || matches <= matchesPerWeek {
+		return 1
+	}
+	return (matches + matchesPerWeek - 1) / matchesPerWeek
+}
+
+// groupTotalWeeks returns the number of weeks needed for every group's schedule.
+// Without a limit this is the length of the longest group's schedule; with one, each
+// round takes as many weeks as the matches of all groups playing that round need.
+func (lh *LeagueHandler) groupTotalWeeks(groups []teamGroup, matchesPerWeek int) int {
+	rounds := 0
+	for _, group := range groups {
+		rounds = max(rounds, lh.calculateTotalWeeks(len(group.teams), 0))
+	}
+
+	totalWeeks := 0
+	for round := 1; round <= rounds; round++ {
+		roundMatches := 0
+		for _, group := range groups {
+			if round <= lh.calculateTotalWeeks(len(group.teams), 0) {
+				roundMatches += len(group.teams) / 2
+			}
+		}
+		totalWeeks += weeksPerRound(roundMatches, matchesPerWeek)
+	}
+	return totalWeeks
+}
+
+// generateRoundRobinMatches creates a Premier League style schedule where each team plays every other team twice (home and away)
+// First half: each team plays every other team once, properly distributed across weeks
+// Second half: each team plays every other team again with home/away reversed
+func (lh *LeagueHandler) generateRoundRobinMatches(teams []*models.Team, leagueID int) []models.Match {
+	var matches []models.Match
+	n := len(teams)
+
+	if n < 2 {
+		return matches
+	}
+
+	// For proper round-robin scheduling, we need to handle even and odd number of teams
+	slots := make([]scheduleSlot, 0, n+1)
+	for _, team := range teams {
+		slots = append(slots, scheduleSlot{team: team})
+	}
+	if n%2 == 1 {
+		// Add a bye slot for odd number of teams to make scheduling easier. It is flagged rather
+		// than given a sentinel ID, so no real team's ID can be mistaken for it.
+		slots = append(slots, scheduleSlot{isBye: true})
+		n = len(slots)
+	}
+
+	var firstHalfMatches []models.Match
+
+	// Generate first half using round-robin algorithm
+	// Each round has n/2 matches, and we need n-1 rounds for everyone to play everyone once
+	for round := 0; round < n-1; round++ {
+		for _, pairing := range lh.generateRoundMatches(slots, round) {
+			// Skip the pairing with the bye
+			if pairing.home.isBye || pairing.away.isBye {
 				continue
 			}
 
-			match.LeagueID = leagueID
-			match.Week = round + 1
-			match.Status = "scheduled"
-			firstHalfMatches = append(firstHalfMatches, match)
+			firstHalfMatches = append(firstHalfMatches, models.Match{
+				LeagueID:   leagueID,
+				HomeTeamID: pairing.home.team.ID,
+				AwayTeamID: pairing.away.team.ID,
+				Week:       round + 1,
+				Status:     "scheduled",
+			})
 		}
 	}
 
@@ -490,16 +1096,27 @@ func (lh *LeagueHandler) generateRoundRobinMatches(teams []*models.Team, leagueI
 	return matches
 }
 
-// generateRoundMatches generates matches for a specific round using round-robin algorithm
-func (lh *LeagueHandler) generateRoundMatches(teams []*models.Team, round int) []models.Match {
-	var matches []models.Match
+// scheduleSlot is a place in the round-robin rotation: a team, or the bye added for an odd number of teams
+type scheduleSlot struct {
+	team  *models.Team
+	isBye bool
+}
+
+// roundPairing is a home and away pairing of slots in one round; a pairing with the bye is no match
+type roundPairing struct {
+	home, away scheduleSlot
+}
+
+// generateRoundMatches generates the pairings for a specific round using round-robin algorithm
+func (lh *LeagueHandler) generateRoundMatches(teams []scheduleSlot, round int) []roundPairing {
+	var matches []roundPairing
 	n := len(teams)
 
 	// In round-robin, team 0 is fixed, others rotate
 	// The algorithm pairs teams in a specific pattern for each round
 
 	for i := 0; i < n/2; i++ {
-		var homeTeam, awayTeam *models.Team
+		var homeTeam, awayTeam scheduleSlot
 
 		if i == 0 {
 			// Team 0 is always fixed
@@ -524,26 +1141,58 @@ func (lh *LeagueHandler) generateRoundMatches(teams []*models.Team, round int) [
 			homeTeam, awayTeam = awayTeam, homeTeam
 		}
 
-		match := models.Match{
-			HomeTeamID: homeTeam.ID,
-			AwayTeamID: awayTeam.ID,
-		}
-		matches = append(matches, match)
+		matches = append(matches, roundPairing{home: homeTeam, away: awayTeam})
 	}
 
 	return matches
 }
 
-// calculateTotalWeeks calculates the total number of weeks needed for the league (including both halves)
-func (lh *LeagueHandler) calculateTotalWeeks(numTeams int) int {
+// calculateTotalWeeks calculates the total number of weeks needed for the league (including both halves).
+// A positive matchesPerWeek spreads each round over as many weeks as it needs to stay within the limit.
+func (lh *LeagueHandler) calculateTotalWeeks(numTeams, matchesPerWeek int) int {
 	if numTeams < 2 {
 		return 0
 	}
 
 	// Each team plays every other team twice (home and away)
-	// First half: (n-1) weeks, Second half: (n-1) weeks
-	// Total: 2 * (n-1) weeks
-	return 2 * (numTeams - 1)
+	// First half: (n-1) rounds, Second half: (n-1) rounds
+	// Total: 2 * (n-1) rounds
+	// With an odd number of teams a bye team is added, so each half has n rounds
+	rounds := 2 * (numTeams - 1)
+	if numTeams%2 == 1 {
+		rounds = 2 * numTeams
+	}
+	return rounds * weeksPerRound(numTeams/2, matchesPerWeek)
+}
+
+// leagueTotalWeeks returns the number of weeks in a league's season.
+// It is calculated from the size of the largest group (or the whole league when teams
+// aren't grouped), extended to the last scheduled week for imported schedules that
+// run longer than a standard double round robin. The league's matches-per-week limit
+// stretches each round over several weeks.
+func (lh *LeagueHandler) leagueTotalWeeks(ctx context.Context, league *models.League) (int, error) {
+	leagueID := league.ID
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get teams in league %d: %w", leagueID, err)
+	}
+
+	groups, err := lh.leagueGroups(ctx, leagueID, teams)
+	if err != nil {
+		return 0, err
+	}
+
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get matches for league %d: %w", leagueID, err)
+	}
+
+	totalWeeks := lh.groupTotalWeeks(groups, league.MatchesPerWeek)
+	for _, match := range matches {
+		totalWeeks = max(totalWeeks, match.Week)
+	}
+
+	return totalWeeks, nil
 }
 
 // AdvanceWeekHandler handles POST /api/leagues/advance-week/:leagueID
@@ -560,9 +1209,9 @@ func (lh *LeagueHandler) AdvanceWeekHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[3])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -586,273 +1235,377 @@ func (lh *LeagueHandler) AdvanceWeekHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// 3. Calculate which week to play (current_week + 1)
-	weekToPlay := league.CurrentWeek + 1
-
-	// 4. Get all matches for the week to be played
-	matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, weekToPlay)
+	// 3. Play the next week's matches and advance the league
+	matchResults, err := lh.advanceOneWeek(ctx, league)
 	if err != nil {
-		log.Printf("Failed to get matches for league %d week %d: %v", leagueID, weekToPlay, err)
-		http.Error(w, "Failed to get matches for the week", http.StatusInternalServerError)
+		log.Printf("Failed to advance league %d: %v", leagueID, err)
+		writeAdvanceError(w, r, league, err)
 		return
 	}
 
-	// 5. If no matches for this week, the league might be finished
-	if len(matches) == 0 {
-		http.Error(w, "No matches found for the next week. League may be finished.", http.StatusBadRequest)
-		return
+	// 4. Create response
+	resp := models.AdvanceWeekResponse{
+		League:        models.NewLeagueResponse(league),
+		WeekAdvanced:  league.CurrentWeek,
+		MatchesPlayed: matchResults,
+		Message:       fmt.Sprintf("League '%s' advanced to week %d. %d matches played.", league.Name, league.CurrentWeek, len(matchResults)),
 	}
 
-	// 6. Play all matches for this week
-	var matchResults []models.MatchResult
-	for _, match := range matches {
-		// DEBUG: Log match status before playing
-		log.Printf("DEBUG: Playing match ID %d, status: %s, home_goals: %v, away_goals: %v",
-			match.ID, match.Status, match.HomeGoals, match.AwayGoals)
+	writeJSON(w, r, http.StatusOK, resp)
+}
 
-		// Generate match result based on team strengths
-		homeGoals, awayGoals := lh.generateMatchResult(match.HomeTeamID, match.AwayTeamID)
-		log.Printf("DEBUG: Generated result for match %d: %d-%d", match.ID, homeGoals, awayGoals)
+// writeAdvanceError answers a request whose advanceOneWeek call failed
+func writeAdvanceError(w http.ResponseWriter, r *http.Request, league *models.League, err error) {
+	var unplayed *unplayedMatchesError
+	if errors.As(err, &unplayed) {
+		writeJSON(w, r, http.StatusConflict, models.UnplayedMatchesErrorResponse{
+			Error:    fmt.Sprintf("Weeks up to %d still have scheduled matches; play them before advancing", league.CurrentWeek),
+			MatchIDs: unplayed.matchIDs,
+		})
+	} else if errors.Is(err, errNoMatchesForWeek) {
+		http.Error(w, "No matches found for the next week. League may be finished.", http.StatusBadRequest)
+	} else {
+		http.Error(w, "Failed to advance league week", http.StatusInternalServerError)
+	}
+}
 
-		// Update match in database
-		if err := lh.db.PlayMatch(ctx, match.ID, homeGoals, awayGoals); err != nil {
-			log.Printf("Failed to play match %d: %v", match.ID, err)
-			http.Error(w, "Failed to play matches", http.StatusInternalServerError)
-			return
-		}
-		log.Printf("DEBUG: Successfully updated match %d in database with %d-%d", match.ID, homeGoals, awayGoals)
+// errNoMatchesForWeek is returned by advanceOneWeek when the next week has no matches
+var errNoMatchesForWeek = errors.New("no matches found for the next week")
 
-		// Update standings
-		if err := lh.db.UpdateStandings(ctx, leagueID, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals); err != nil {
-			log.Printf("Failed to update standings for match %d: %v", match.ID, err)
-			http.Error(w, "Failed to update standings", http.StatusInternalServerError)
-			return
-		}
+// unplayedMatchesError is returned by advanceOneWeek when weeks the league has already
+// advanced through still have scheduled matches, which advancing further would strand
+type unplayedMatchesError struct {
+	matchIDs []int
+}
 
-		// Get team names for response
-		homeTeam, err := lh.db.GetTeamByID(ctx, match.HomeTeamID)
-		if err != nil {
-			log.Printf("Failed to get home team %d: %v", match.HomeTeamID, err)
-			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-			return
-		}
+func (e *unplayedMatchesError) Error() string {
+	return fmt.Sprintf("matches %v from completed weeks are still scheduled", e.matchIDs)
+}
 
-		awayTeam, err := lh.db.GetTeamByID(ctx, match.AwayTeamID)
-		if err != nil {
-			log.Printf("Failed to get away team %d: %v", match.AwayTeamID, err)
-			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-			return
-		}
+// incompleteSeasonError is returned by checkSeasonComplete when fewer of a league's fixtures were
+// played or cancelled than its round-robin schedule holds, such as after a gap in the schedule
+type incompleteSeasonError struct {
+	accounted int
+	expected  int
+}
 
-		// Update match object with played results for response
-		match.HomeGoals = &homeGoals
-		match.AwayGoals = &awayGoals
-		match.Status = "played"
-		log.Printf("DEBUG: Match object updated for response: %d-%d", *match.HomeGoals, *match.AwayGoals)
+func (e *incompleteSeasonError) Error() string {
+	return fmt.Sprintf("only %d of %d expected matches were played or cancelled", e.accounted, e.expected)
+}
 
-		// Create match result for response
-		matchResult := models.MatchResult{
-			Match:    *match,
-			HomeTeam: homeTeam.Name,
-			AwayTeam: awayTeam.Name,
-			Result:   fmt.Sprintf("%d-%d", homeGoals, awayGoals),
+// checkSeasonComplete makes sure every fixture of a league's double round-robin, n*(n-1) matches
+// for each group of n scheduled teams, was played or cancelled before the league is marked finished.
+// Only teams with fixtures count, so a team added after the schedule was generated doesn't hold the
+// league open for matches that were never created.
+func (lh *LeagueHandler) checkSeasonComplete(ctx context.Context, league *models.League) error {
+	matches, err := lh.db.GetMatchesByLeague(ctx, league.ID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get matches for league %d: %w", league.ID, err)
+	}
+	accounted := 0
+	for _, match := range matches {
+		if match.Status == "played" || match.Status == "cancelled" {
+			accounted++
 		}
-		matchResults = append(matchResults, matchResult)
 	}
 
-	// 7. Advance the league week
-	if err := lh.db.AdvanceLeagueWeek(ctx, leagueID); err != nil {
-		log.Printf("Failed to advance league %d week: %v", leagueID, err)
-		http.Error(w, "Failed to advance league week", http.StatusInternalServerError)
-		return
+	groups, err := lh.scheduledGroups(ctx, league.ID, matches)
+	if err != nil {
+		return err
 	}
 
-	// 8. Check if league is finished (no more matches)
-	nextWeek := weekToPlay + 1
-	nextWeekMatches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, nextWeek)
-	if err != nil {
-		log.Printf("Failed to check next week matches: %v", err)
-		// Continue anyway, this is not critical
+	expected := 0
+	for _, group := range groups {
+		expected += len(group.teams) * (len(group.teams) - 1)
 	}
 
-	// If no matches next week, mark league as finished
-	if len(nextWeekMatches) == 0 {
-		if err := lh.db.UpdateLeagueStatus(ctx, leagueID, "finished"); err != nil {
-			log.Printf("Failed to mark league as finished: %v", err)
-			// Continue anyway, this is not critical
-		}
-		league.Status = "finished"
+	if accounted < expected {
+		return &incompleteSeasonError{accounted: accounted, expected: expected}
 	}
+	return nil
+}
 
-	// Update league current week for response
-	league.CurrentWeek = weekToPlay
+// advanceOneWeek plays the next week of a started league and advances its current week.
+// The league is updated in place with the new week, and marked finished once its final week is played.
+func (lh *LeagueHandler) advanceOneWeek(ctx context.Context, league *models.League) ([]models.MatchResult, error) {
+	weekToPlay := league.CurrentWeek + 1
 
-	// Create response
-	resp := models.AdvanceWeekResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
-		WeekAdvanced:  weekToPlay,
-		MatchesPlayed: matchResults,
-		Message:       fmt.Sprintf("League '%s' advanced to week %d. %d matches played.", league.Name, weekToPlay, len(matchResults)),
+	totalWeeks, err := lh.leagueTotalWeeks(ctx, league)
+	if err != nil {
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	// Past the final week there is nothing left to play
+	if weekToPlay > totalWeeks {
+		return nil, fmt.Errorf("week %d: %w", weekToPlay, errNoMatchesForWeek)
 	}
-}
 
-// generateMatchResult simulates a football match using team strengths to influence the result
-func (lh *LeagueHandler) generateMatchResult(homeTeamID, awayTeamID int) (int, int) {
-	// Get team strengths (we already validated teams exist earlier in the flow)
-	homeTeam, err := lh.db.GetTeamByID(context.Background(), homeTeamID)
+	// Refuse to move on while a completed week still has scheduled matches
+	scheduled, err := lh.db.GetMatchesByLeague(ctx, league.ID, "scheduled")
 	if err != nil {
-		// Fallback to basic random if we can't get team info
-		return lh.basicRandomGoals(), lh.basicRandomGoals()
+		return nil, fmt.Errorf("failed to get scheduled matches: %w", err)
+	}
+	var unplayedIDs []int
+	for _, match := range scheduled {
+		if match.Week <= league.CurrentWeek {
+			unplayedIDs = append(unplayedIDs, match.ID)
+		}
+	}
+	if len(unplayedIDs) > 0 {
+		return nil, &unplayedMatchesError{matchIDs: unplayedIDs}
 	}
 
-	awayTeam, err := lh.db.GetTeamByID(context.Background(), awayTeamID)
+	// Get all matches for the week to be played
+	matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, league.ID, weekToPlay)
 	if err != nil {
-		// Fallback to basic random if we can't get team info
-		return lh.basicRandomGoals(), lh.basicRandomGoals()
+		return nil, fmt.Errorf("failed to get matches for week %d: %w", weekToPlay, err)
 	}
 
-	// Simulate match based on team strengths
-	log.Printf("DEBUG: Team strengths - Home: %s (%d), Away: %s (%d)", homeTeam.Name, homeTeam.Strength, awayTeam.Name, awayTeam.Strength)
-	return lh.simulateMatch(homeTeam.Strength, awayTeam.Strength)
-}
-
-// simulateMatch generates realistic match results based on team strengths
-func (lh *LeagueHandler) simulateMatch(homeStrength, awayStrength int) (int, int) {
-	// Add home advantage (typically 3-5 points)
-	homeAdvantage := 4
-	adjustedHomeStrength := homeStrength + homeAdvantage
-
-	// Calculate strength difference (-100 to +100 range)
-	strengthDiff := adjustedHomeStrength - awayStrength
-
-	// Generate base goal expectancy based on strength (1.0 to 3.0 goals per team on average)
-	homeGoalExpectancy := 1.5 + float64(strengthDiff)/100.0 // Stronger teams score more
-	awayGoalExpectancy := 1.5 - float64(strengthDiff)/100.0 // Weaker teams score less
-
-	// Ensure reasonable bounds (0.5 to 3.0 goals expectancy)
-	if homeGoalExpectancy < 0.5 {
-		homeGoalExpectancy = 0.5
-	}
-	if homeGoalExpectancy > 3.0 {
-		homeGoalExpectancy = 3.0
-	}
-	if awayGoalExpectancy < 0.5 {
-		awayGoalExpectancy = 0.5
+	// Play all scheduled matches for this week
+	matchResults, err := lh.playWeek(ctx, league.ID, matches, lh.leagueMatchResult(league))
+	if err != nil {
+		return nil, fmt.Errorf("failed to play week %d: %w", weekToPlay, err)
 	}
-	if awayGoalExpectancy > 3.0 {
-		awayGoalExpectancy = 3.0
+
+	if err := lh.awardByePoints(ctx, league, matches); err != nil {
+		return nil, fmt.Errorf("failed to award bye points for week %d: %w", weekToPlay, err)
 	}
 
-	// Debug expectancy calculations
-	log.Printf("DEBUG: Expectancy - Home: %.2f, Away: %.2f (strengthDiff: %d)", homeGoalExpectancy, awayGoalExpectancy, strengthDiff)
+	// Advance the league week
+	if err := lh.db.AdvanceLeagueWeek(ctx, league.ID); err != nil {
+		return nil, fmt.Errorf("failed to advance league week: %w", err)
+	}
+	league.CurrentWeek = weekToPlay
+	lh.recordWeekAdvanced(ctx, league.ID, weekToPlay, len(matchResults))
+
+	// current_week counts completed weeks, so the league is finished once it reaches the final week,
+	// provided no fixture went missing from the schedule
+	if league.CurrentWeek >= totalWeeks {
+		if err := lh.checkSeasonComplete(ctx, league); err != nil {
+			log.Printf("WARN not marking league %d finished after week %d: %v", league.ID, league.CurrentWeek, err)
+			return matchResults, nil
+		}
+		if err := lh.db.UpdateLeagueStatus(ctx, league.ID, "finished"); err != nil {
+			log.Printf("Failed to mark league as finished: %v", err)
+			// Continue anyway, this is not critical
+		}
+		league.Status = "finished"
+		lh.recordFinished(ctx, league.ID, league.CurrentWeek)
+	}
 
-	// Use Poisson-like distribution for goal generation
-	homeGoals := lh.generateGoalsFromExpectancy(homeGoalExpectancy)
-	awayGoals := lh.generateGoalsFromExpectancy(awayGoalExpectancy)
+	return matchResults, nil
+}
 
-	log.Printf("DEBUG: Final goals - Home: %d, Away: %d", homeGoals, awayGoals)
-	return homeGoals, awayGoals
+// awardByePoints credits the league's bye points to every team sitting out a week.
+// Leagues with a matches-per-week limit leave teams idle most weeks, so they don't award byes.
+func (lh *LeagueHandler) awardByePoints(ctx context.Context, league *models.League, weekMatches []*models.Match) error {
+	if league.ByePoints == 0 || league.MatchesPerWeek > 0 {
+		return nil
+	}
+
+	matches, err := lh.db.GetMatchesByLeague(ctx, league.ID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get matches for league %d: %w", league.ID, err)
+	}
+	groups, err := lh.scheduledGroups(ctx, league.ID, matches)
+	if err != nil {
+		return err
+	}
+
+	for _, teamID := range byeTeamIDs(groups, weekMatches) {
+		if err := lh.db.AddStandingPoints(ctx, league.ID, teamID, league.ByePoints); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// generateGoalsFromExpectancy generates goals using weighted probability based on expectancy
-func (lh *LeagueHandler) generateGoalsFromExpectancy(expectancy float64) int {
-	// Use time-based seed with microseconds for better randomness
-	rand.Seed(time.Now().UnixNano())
+// byeTeamIDs returns the teams on a bye in a week: those whose group (or the whole league, when
+// teams aren't grouped) has matches that week without them. Groups with no matches that week,
+// such as a smaller group that has finished its schedule, have no byes.
+func byeTeamIDs(groups []teamGroup, weekMatches []*models.Match) []int {
+	playing := make(map[int]bool)
+	for _, match := range weekMatches {
+		playing[match.HomeTeamID] = true
+		playing[match.AwayTeamID] = true
+	}
+
+	var byes []int
+	for _, group := range groups {
+		groupPlays := false
+		for _, team := range group.teams {
+			groupPlays = groupPlays || playing[team.ID]
+		}
+		if !groupPlays {
+			continue
+		}
+		for _, team := range group.teams {
+			if !playing[team.ID] {
+				byes = append(byes, team.ID)
+			}
+		}
+	}
+	return byes
+}
 
-	// Generate a random number 0-99 for easier probability calculation
-	randNum := rand.Intn(100)
+// byePointsEarned totals the bye points each team has earned in weeks 1 to throughWeek of a
+// league, from the league's full schedule. It mirrors awardByePoints.
+func (lh *LeagueHandler) byePointsEarned(ctx context.Context, league *models.League, throughWeek int) (map[int]int, error) {
+	earned := make(map[int]int)
+	if league.ByePoints == 0 || league.MatchesPerWeek > 0 || throughWeek < 1 {
+		return earned, nil
+	}
 
-	// Debug the inputs and random number
-	log.Printf("DEBUG: generateGoalsFromExpectancy called with expectancy=%.2f, randNum=%d", expectancy, randNum)
+	matches, err := lh.db.GetMatchesByLeague(ctx, league.ID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches for league %d: %w", league.ID, err)
+	}
+	groups, err := lh.scheduledGroups(ctx, league.ID, matches)
+	if err != nil {
+		return nil, err
+	}
 
-	var goals int
-
-	// Simpler probability distribution based on expectancy
-	if expectancy <= 1.0 {
-		// Low scoring team: mostly 0-1 goals
-		if randNum < 50 {
-			goals = 0
-		} else if randNum < 85 {
-			goals = 1
-		} else if randNum < 95 {
-			goals = 2
-		} else {
-			goals = 3
-		}
-	} else if expectancy <= 2.0 {
-		// Medium scoring team: balanced scoring
-		if randNum < 25 {
-			goals = 0
-		} else if randNum < 50 {
-			goals = 1
-		} else if randNum < 75 {
-			goals = 2
-		} else if randNum < 90 {
-			goals = 3
-		} else if randNum < 97 {
-			goals = 4
-		} else {
-			goals = 5
+	byWeek := make(map[int][]*models.Match)
+	for _, match := range matches {
+		byWeek[match.Week] = append(byWeek[match.Week], match)
+	}
+
+	for week := 1; week <= throughWeek; week++ {
+		for _, teamID := range byeTeamIDs(groups, byWeek[week]) {
+			earned[teamID] += league.ByePoints
 		}
-	} else {
-		// High scoring team: more goals likely
-		if randNum < 15 {
-			goals = 0
-		} else if randNum < 30 {
-			goals = 1
-		} else if randNum < 50 {
-			goals = 2
-		} else if randNum < 70 {
-			goals = 3
-		} else if randNum < 85 {
-			goals = 4
-		} else if randNum < 95 {
-			goals = 5
+	}
+	return earned, nil
+}
+
+// pointScoringByes lists the teams on a bye in each of weeks 1 to throughWeek of a league, given
+// all its matches. It is empty when the league's byes earn no points, as in awardByePoints.
+func (lh *LeagueHandler) pointScoringByes(ctx context.Context, league *models.League, matches []*models.Match, throughWeek int) (map[int][]int, error) {
+	byes := make(map[int][]int)
+	if league.ByePoints == 0 || league.MatchesPerWeek > 0 {
+		return byes, nil
+	}
+
+	groups, err := lh.scheduledGroups(ctx, league.ID, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	byWeek := make(map[int][]*models.Match)
+	for _, match := range matches {
+		byWeek[match.Week] = append(byWeek[match.Week], match)
+	}
+	for week := 1; week <= throughWeek; week++ {
+		byes[week] = byeTeamIDs(groups, byWeek[week])
+	}
+	return byes, nil
+}
+
+// recordEvent adds an entry to a league's audit log. A failure is only logged, since the
+// operation being recorded has already succeeded.
+func (lh *LeagueHandler) recordEvent(ctx context.Context, leagueID int, eventType, details string) {
+	if err := lh.db.RecordLeagueEvent(ctx, leagueID, eventType, details); err != nil {
+		log.Printf("Failed to record %s event for league %d: %v", eventType, leagueID, err)
+	}
+}
+
+// recordWeekAdvanced records a played week in a league's audit log
+func (lh *LeagueHandler) recordWeekAdvanced(ctx context.Context, leagueID, week, matchesPlayed int) {
+	lh.recordEvent(ctx, leagueID, models.LeagueEventWeekAdvanced, fmt.Sprintf("Week %d played: %d matches", week, matchesPlayed))
+}
+
+// recordFinished records the end of a league's season in its audit log
+func (lh *LeagueHandler) recordFinished(ctx context.Context, leagueID, week int) {
+	lh.recordEvent(ctx, leagueID, models.LeagueEventFinished, fmt.Sprintf("League finished after week %d", week))
+}
+
+// LeagueEventsHandler handles GET /api/leagues/:leagueID/events
+// It returns the league's audit log of lifecycle events, oldest first.
+func (lh *LeagueHandler) LeagueEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "events" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
 		} else {
-			goals = 6
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
 		}
+		return
 	}
 
-	log.Printf("DEBUG: generateGoalsFromExpectancy returning %d goals", goals)
-	return goals
+	// 2. Get the league's events
+	events, err := lh.db.GetLeagueEvents(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get events for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league events", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.LeagueEventsResponse{
+		League: models.NewLeagueResponse(league),
+		Events: events,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
-// ViewMatchesHandler handles GET /api/leagues/view-matches/:leagueID
-func (lh *LeagueHandler) ViewMatchesHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+// PauseLeagueHandler handles POST /api/leagues/:leagueID/pause
+// A paused league can't advance or play matches until it is resumed.
+func (lh *LeagueHandler) PauseLeagueHandler(w http.ResponseWriter, r *http.Request) {
+	lh.changeLeagueStatus(w, r, "pause", "started", "paused", models.LeagueEventPaused)
+}
+
+// ResumeLeagueHandler handles POST /api/leagues/:leagueID/resume
+func (lh *LeagueHandler) ResumeLeagueHandler(w http.ResponseWriter, r *http.Request) {
+	lh.changeLeagueStatus(w, r, "resume", "paused", "started", models.LeagueEventResumed)
+}
+
+// changeLeagueStatus moves the league in POST /api/leagues/:leagueID/:action from fromStatus to
+// toStatus, rejecting the request with 409 if the league is in any other status.
+// The change is recorded in the league's audit log as eventType.
+func (lh *LeagueHandler) changeLeagueStatus(w http.ResponseWriter, r *http.Request, action, fromStatus, toStatus, eventType string) {
+	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	// Extract leagueID from URL path
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "view-matches" {
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != action {
 		http.Error(w, "Invalid URL path", http.StatusBadRequest)
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[2])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
 	ctx := r.Context()
 
-	// 1. Validate league exists and get its current state
+	// 1. Validate league exists and is in the status the action starts from
 	league, err := lh.db.GetLeagueByID(ctx, leagueID)
 	if err != nil {
 		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
@@ -864,104 +1617,187 @@ func (lh *LeagueHandler) ViewMatchesHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// 2. Check if league has been started
-	if league.Status == "created" {
-		http.Error(w, "League has not been started yet. No matches to view.", http.StatusBadRequest)
+	if league.Status != fromStatus {
+		http.Error(w, fmt.Sprintf("Can only %s '%s' leagues. Current status: %s", action, fromStatus, league.Status), http.StatusConflict)
 		return
 	}
 
-	// 3. Get all matches for the current week
-	matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, league.CurrentWeek)
+	// 2. Change the status, unless another request changed it first
+	if err := lh.db.TransitionLeagueStatus(ctx, leagueID, fromStatus, toStatus); err != nil {
+		log.Printf("Failed to %s league %d: %v", action, leagueID, err)
+		if strings.Contains(err.Error(), fmt.Sprintf("no %s league", fromStatus)) {
+			http.Error(w, fmt.Sprintf("League is no longer %s", fromStatus), http.StatusConflict)
+		} else {
+			http.Error(w, fmt.Sprintf("Failed to %s league", action), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	league.Status = toStatus
+	lh.recordEvent(ctx, leagueID, eventType, fmt.Sprintf("League %s in week %d", eventType, league.CurrentWeek))
+
+	resp := models.LeagueStatusResponse{
+		League:  models.NewLeagueResponse(league),
+		Message: fmt.Sprintf("League '%s' is now %s", league.Name, toStatus),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// AdvanceAllLeaguesHandler handles POST /api/admin/advance-all
+// It advances every started league by one week. A failure in one league is reported
+// in its result and does not stop the others from advancing.
+func (lh *LeagueHandler) AdvanceAllLeaguesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Get all started leagues
+	leagues, err := lh.db.GetAllLeagues(ctx, "started")
 	if err != nil {
-		log.Printf("Failed to get matches for league %d week %d: %v", leagueID, league.CurrentWeek, err)
-		http.Error(w, "Failed to get matches for the current week", http.StatusInternalServerError)
+		log.Printf("Failed to get started leagues: %v", err)
+		http.Error(w, "Failed to get leagues", http.StatusInternalServerError)
 		return
 	}
 
-	// 4. If no matches for current week, return empty result
-	if len(matches) == 0 {
-		resp := models.ViewMatchesResponse{
-			League: models.LeagueResponse{
-				ID:          league.ID,
-				Name:        league.Name,
-				Status:      league.Status,
-				CurrentWeek: league.CurrentWeek,
-				CreatedAt:   league.CreatedAt,
-			},
-			CurrentWeek: league.CurrentWeek,
-			Matches:     []models.MatchResult{},
-			Message:     fmt.Sprintf("No matches found for week %d in league '%s'", league.CurrentWeek, league.Name),
+	// 2. Advance each league independently
+	results := []models.AdvanceAllLeagueResult{}
+	advanced := 0
+	for _, league := range leagues {
+		result := models.AdvanceAllLeagueResult{
+			LeagueID:   league.ID,
+			LeagueName: league.Name,
+		}
+
+		matchResults, err := lh.advanceOneWeek(ctx, league)
+		if err != nil {
+			log.Printf("Failed to advance league %d: %v", league.ID, err)
+			result.Error = err.Error()
+		} else {
+			result.WeekAdvanced = league.CurrentWeek
+			result.MatchesPlayed = len(matchResults)
+			result.Finished = league.Status == "finished"
+			advanced++
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+		results = append(results, result)
+	}
+
+	// 3. Create response
+	resp := models.AdvanceAllLeaguesResponse{
+		LeaguesProcessed: len(leagues),
+		LeaguesAdvanced:  advanced,
+		LeaguesFailed:    len(leagues) - advanced,
+		Results:          results,
+		Message:          fmt.Sprintf("Advanced %d of %d started leagues", advanced, len(leagues)),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// defaultCleanupAgeDays is how many days ago a league must have finished to be cleaned up
+// when older_than_days isn't given
+const defaultCleanupAgeDays = 30
+
+// CleanupFinishedLeaguesHandler handles POST /api/admin/cleanup-finished?older_than_days=
+// It deletes the leagues that finished more than older_than_days ago, with their matches,
+// standings and events. Leagues in any other status are never deleted.
+func (lh *LeagueHandler) CleanupFinishedLeaguesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			log.Printf("Failed to encode response: %v", err)
+	olderThanDays := defaultCleanupAgeDays
+	if value := r.URL.Query().Get("older_than_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			http.Error(w, fmt.Sprintf("Invalid older_than_days '%s'. Must be a non-negative whole number", value), http.StatusBadRequest)
+			return
 		}
+		olderThanDays = days
+	}
+
+	ctx := r.Context()
+	finishedBefore := time.Now().AddDate(0, 0, -olderThanDays)
+
+	// 1. Delete the finished leagues; their matches, standings and events cascade
+	deleted, err := lh.db.DeleteFinishedLeagues(ctx, finishedBefore)
+	if err != nil {
+		log.Printf("Failed to delete leagues finished before %s: %v", finishedBefore.Format(time.RFC3339), err)
+		http.Error(w, "Failed to delete finished leagues", http.StatusInternalServerError)
 		return
 	}
 
-	// 5. Build match results with team information
-	var matchResults []models.MatchResult
+	// 2. Create response
+	resp := models.CleanupFinishedLeaguesResponse{
+		OlderThanDays:  olderThanDays,
+		FinishedBefore: finishedBefore,
+		LeaguesDeleted: len(deleted),
+		LeagueIDs:      deleted,
+		Message:        fmt.Sprintf("Deleted %d leagues finished more than %d days ago", len(deleted), olderThanDays),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// playWeek plays the given matches of a league week and returns their results.
+// Matches that are no longer scheduled (already played or cancelled) are skipped.
+func (lh *LeagueHandler) playWeek(ctx context.Context, leagueID int, matches []*models.Match, resultFn func(match *models.Match) (int, int)) ([]models.MatchResult, error) {
+	matchResults := []models.MatchResult{}
 	for _, match := range matches {
+		if match.Status != "scheduled" {
+			log.Printf("DEBUG: Skipping match ID %d with status %s", match.ID, match.Status)
+			continue
+		}
+
+		// Generate match result based on team strengths
+		homeGoals, awayGoals := resultFn(match)
+		log.Printf("DEBUG: Generated result for match %d (week %d): %d-%d", match.ID, match.Week, homeGoals, awayGoals)
+
+		// Update match in database
+		if err := lh.db.PlayMatch(ctx, match.ID, homeGoals, awayGoals); err != nil {
+			return nil, fmt.Errorf("failed to play match %d: %w", match.ID, err)
+		}
+
+		// Update standings
+		if err := lh.db.UpdateStandings(ctx, leagueID, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals); err != nil {
+			return nil, fmt.Errorf("failed to update standings for match %d: %w", match.ID, err)
+		}
+
 		// Get team names for response
 		homeTeam, err := lh.db.GetTeamByID(ctx, match.HomeTeamID)
 		if err != nil {
-			log.Printf("Failed to get home team %d: %v", match.HomeTeamID, err)
-			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("failed to get home team %d: %w", match.HomeTeamID, err)
 		}
 
 		awayTeam, err := lh.db.GetTeamByID(ctx, match.AwayTeamID)
 		if err != nil {
-			log.Printf("Failed to get away team %d: %v", match.AwayTeamID, err)
-			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("failed to get away team %d: %w", match.AwayTeamID, err)
 		}
 
-		// Create result string based on match status
-		var result string
-		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
-			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
-		} else {
-			result = "Not played yet"
-		}
+		// Update match object with played results for response
+		match.HomeGoals = &homeGoals
+		match.AwayGoals = &awayGoals
+		match.Status = "played"
 
-		// Create match result for response
-		matchResult := models.MatchResult{
+		matchResults = append(matchResults, models.MatchResult{
 			Match:    *match,
 			HomeTeam: homeTeam.Name,
 			AwayTeam: awayTeam.Name,
-			Result:   result,
-		}
-		matchResults = append(matchResults, matchResult)
-	}
-
-	// 6. Create response
-	resp := models.ViewMatchesResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
-		CurrentWeek: league.CurrentWeek,
-		Matches:     matchResults,
-		Message:     fmt.Sprintf("Matches for week %d in league '%s'", league.CurrentWeek, league.Name),
+			Result:   fmt.Sprintf("%d-%d", homeGoals, awayGoals),
+			Edited:   match.Edited,
+		})
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	return matchResults, nil
 }
 
-// PlayAllMatchesHandler handles POST /api/leagues/play-all-matches/:leagueID
-func (lh *LeagueHandler) PlayAllMatchesHandler(w http.ResponseWriter, r *http.Request) {
+// AdvanceWeeksHandler handles POST /api/leagues/advance-weeks/:leagueID
+func (lh *LeagueHandler) AdvanceWeeksHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -969,14 +1805,25 @@ func (lh *LeagueHandler) PlayAllMatchesHandler(w http.ResponseWriter, r *http.Re
 
 	// Extract leagueID from URL path
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "play-all-matches" {
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "advance-weeks" {
 		http.Error(w, "Invalid URL path", http.StatusBadRequest)
 		return
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[3])
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.AdvanceWeeksRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	if req.Count < 1 {
+		http.Error(w, "Count must be at least 1", http.StatusBadRequest)
 		return
 	}
 
@@ -994,556 +1841,4909 @@ func (lh *LeagueHandler) PlayAllMatchesHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// 2. Check if league is in correct status to play matches
+	// 2. Check if league is in correct status to advance
 	if league.Status != "started" {
-		http.Error(w, fmt.Sprintf("League must be 'started' to play matches. Current status: %s", league.Status), http.StatusBadRequest)
-		return
-	}
-
-	// 3. Calculate total weeks for this league
-	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
-	if err != nil {
-		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
-		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("League must be 'started' to advance weeks. Current status: %s", league.Status), http.StatusBadRequest)
 		return
 	}
 
-	totalWeeks := lh.calculateTotalWeeks(len(teams))
 	startingWeek := league.CurrentWeek
-	var allMatchResults []models.WeekResult
-	weeksPlayed := 0
+	weekResults := []models.WeekResult{}
+	totalMatchesPlayed := 0
 
-	// 4. Play all remaining weeks
-	for currentWeek := league.CurrentWeek + 1; currentWeek <= totalWeeks; currentWeek++ {
-		// Get all matches for this week
-		matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, currentWeek)
+	// 3. Play up to count weeks one at a time, stopping early once the league finishes or the
+	// season runs out of weeks
+	for i := 0; i < req.Count && league.Status == "started"; i++ {
+		matchResults, err := lh.advanceOneWeek(ctx, league)
+		if errors.Is(err, errNoMatchesForWeek) && len(weekResults) > 0 {
+			break
+		}
 		if err != nil {
-			log.Printf("Failed to get matches for league %d week %d: %v", leagueID, currentWeek, err)
-			http.Error(w, "Failed to get matches for week", http.StatusInternalServerError)
+			log.Printf("Failed to advance league %d: %v", leagueID, err)
+			writeAdvanceError(w, r, league, err)
 			return
 		}
 
-		// If no matches for this week, we're done
-		if len(matches) == 0 {
-			break
-		}
+		weekResults = append(weekResults, models.WeekResult{
+			Week:    league.CurrentWeek,
+			Matches: matchResults,
+		})
+		totalMatchesPlayed += len(matchResults)
+	}
 
-		// Play all matches for this week
-		var weekMatchResults []models.MatchResult
-		for _, match := range matches {
-			// Generate match result based on team strengths
-			homeGoals, awayGoals := lh.generateMatchResult(match.HomeTeamID, match.AwayTeamID)
-			log.Printf("DEBUG: Generated result for match %d (week %d): %d-%d", match.ID, currentWeek, homeGoals, awayGoals)
-
-			// Update match in database
-			if err := lh.db.PlayMatch(ctx, match.ID, homeGoals, awayGoals); err != nil {
-				log.Printf("Failed to play match %d: %v", match.ID, err)
-				http.Error(w, "Failed to play matches", http.StatusInternalServerError)
-				return
-			}
+	// 4. Create response
+	resp := models.AdvanceWeeksResponse{
+		League:             models.NewLeagueResponse(league),
+		RequestedWeeks:     req.Count,
+		StartingWeek:       startingWeek,
+		FinalWeek:          league.CurrentWeek,
+		WeeksPlayed:        len(weekResults),
+		TotalMatchesPlayed: totalMatchesPlayed,
+		WeekResults:        weekResults,
+		Message:            fmt.Sprintf("League '%s' advanced %d weeks to week %d. %d matches played.", league.Name, len(weekResults), league.CurrentWeek, totalMatchesPlayed),
+	}
 
-			// Update standings
-			if err := lh.db.UpdateStandings(ctx, leagueID, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals); err != nil {
-				log.Printf("Failed to update standings for match %d: %v", match.ID, err)
-				http.Error(w, "Failed to update standings", http.StatusInternalServerError)
-				return
-			}
+	writeJSON(w, r, http.StatusOK, resp)
+}
 
-			// Get team names for response
-			homeTeam, err := lh.db.GetTeamByID(ctx, match.HomeTeamID)
-			if err != nil {
-				log.Printf("Failed to get home team %d: %v", match.HomeTeamID, err)
-				http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-				return
-			}
+// neutralStrength is the strength assumed for a team whose details can't be looked up
+const neutralStrength = 50
+
+// defaultHomeAdvantage is the strength bonus given to the home side in simulated matches
+const defaultHomeAdvantage = 4
+
+// simulationSettings holds the league tunables that shape simulated results
+type simulationSettings struct {
+	scoreCorrelation float64               // see models.League.ScoreCorrelation
+	upsetFactor      float64               // see models.League.UpsetFactor
+	scoring          models.ScoringProfile // see models.League.Scoring; the default preset when unset
+	bonuses          models.MatchBonuses   // see models.League.Bonuses
+	fatiguePenalty   int                   // see models.League.FatiguePenalty
+	rivalry          bool                  // the match is between registered rivals
+	homeFatigued     bool                  // the home side played within fatigueWindow before this match
+	awayFatigued     bool                  // the away side played within fatigueWindow before this match
+}
 
-			awayTeam, err := lh.db.GetTeamByID(ctx, match.AwayTeamID)
-			if err != nil {
-				log.Printf("Failed to get away team %d: %v", match.AwayTeamID, err)
-				http.Error(w, "Failed to get team information", http.StatusInternalServerError)
-				return
-			}
+// fatigueWindow is how soon after its previous kickoff a team's next match counts as congested
+const fatigueWindow = 4 * 24 * time.Hour
 
-			// Update match object with played results for response
-			match.HomeGoals = &homeGoals
-			match.AwayGoals = &awayGoals
-			match.Status = "played"
+// Registered rivalries play with part of the home advantage removed and each side's goal
+// expectancy swung at random by up to rivalrySwing, making derby results less predictable
+const (
+	rivalryHomeAdvantageCut = 2
+	rivalrySwing            = 1.0
+)
 
-			// Create match result for response
-			matchResult := models.MatchResult{
-				Match:    *match,
-				HomeTeam: homeTeam.Name,
-				AwayTeam: awayTeam.Name,
-				Result:   fmt.Sprintf("%d-%d", homeGoals, awayGoals),
-			}
-			weekMatchResults = append(weekMatchResults, matchResult)
-		}
+// leagueSimulation returns the simulation settings configured for a league
+func leagueSimulation(league *models.League) simulationSettings {
+	return simulationSettings{
+		scoreCorrelation: league.ScoreCorrelation,
+		upsetFactor:      league.UpsetFactor,
+		scoring:          league.Scoring,
+		bonuses:          league.Bonuses(),
+		fatiguePenalty:   league.FatiguePenalty,
+	}
+}
 
-		// Add week result to all results
-		weekResult := models.WeekResult{
-			Week:    currentWeek,
-			Matches: weekMatchResults,
-		}
-		allMatchResults = append(allMatchResults, weekResult)
+// scoringProfile returns the settings' scoring profile, falling back to the default preset
+func (s simulationSettings) scoringProfile() models.ScoringProfile {
+	if s.scoring == (models.ScoringProfile{}) {
+		profile, _ := models.ResolveScoringPreset(models.DefaultScoringPreset)
+		return profile
+	}
+	return s.scoring
+}
 
-		// Advance the league week
-		if err := lh.db.AdvanceLeagueWeek(ctx, leagueID); err != nil {
-			log.Printf("Failed to advance league %d week: %v", leagueID, err)
-			http.Error(w, "Failed to advance league week", http.StatusInternalServerError)
-			return
+// leagueMatchResult returns a random match result function using the league's simulation settings
+func (lh *LeagueHandler) leagueMatchResult(league *models.League) func(match *models.Match) (int, int) {
+	return func(match *models.Match) (int, int) {
+		return lh.generateMatchResult(match.HomeTeamID, match.AwayTeamID, lh.matchSimulation(league, match))
+	}
+}
+
+// leagueExpectedResult returns an expected match result function using the league's simulation settings
+func (lh *LeagueHandler) leagueExpectedResult(league *models.League) func(match *models.Match) (int, int) {
+	return func(match *models.Match) (int, int) {
+		return lh.expectedMatchResult(match.HomeTeamID, match.AwayTeamID, lh.matchSimulation(league, match))
+	}
+}
+
+// leagueTemperedResult returns a match result function between the expected and random ones.
+// Each side's sampled goals are pulled towards its expected goals, keeping a temperature share of
+// the difference, so 0 always gives the expected scoreline and 1 gives the sampled one.
+func (lh *LeagueHandler) leagueTemperedResult(league *models.League, temperature float64) func(match *models.Match) (int, int) {
+	expectedFn := lh.leagueExpectedResult(league)
+	randomFn := lh.leagueMatchResult(league)
+	return func(match *models.Match) (int, int) {
+		homeExpected, awayExpected := expectedFn(match)
+		if temperature == 0 {
+			return homeExpected, awayExpected
 		}
+		homeSampled, awaySampled := randomFn(match)
+		return temperGoals(homeExpected, homeSampled, temperature), temperGoals(awayExpected, awaySampled, temperature)
+	}
+}
 
-		weeksPlayed++
-		league.CurrentWeek = currentWeek
+// temperGoals moves from the expected goals towards the sampled goals by a temperature share
+// of the difference, rounded to whole goals
+func temperGoals(expected, sampled int, temperature float64) int {
+	return int(math.Round(float64(expected) + temperature*float64(sampled-expected)))
+}
+
+// matchSimulation returns the league's simulation settings for one match, marking the sides
+// that are fatigued when the league has a fatigue penalty
+func (lh *LeagueHandler) matchSimulation(league *models.League, match *models.Match) simulationSettings {
+	settings := leagueSimulation(league)
+	if settings.fatiguePenalty > 0 {
+		settings.homeFatigued, settings.awayFatigued = lh.matchFatigue(match)
 	}
+	return settings
+}
 
-	// 5. Mark league as finished
-	if err := lh.db.UpdateLeagueStatus(ctx, leagueID, "finished"); err != nil {
-		log.Printf("Failed to mark league as finished: %v", err)
-		http.Error(w, "Failed to update league status", http.StatusInternalServerError)
-		return
+// matchFatigue reports whether each side of a match played a league match that kicked off
+// within fatigueWindow before it. Matches without a kickoff time are never congested.
+func (lh *LeagueHandler) matchFatigue(match *models.Match) (homeFatigued, awayFatigued bool) {
+	if match.KickoffTime == nil {
+		return false, false
 	}
-	league.Status = "finished"
 
-	// 6. Count total matches played
-	totalMatchesPlayed := 0
-	for _, weekResult := range allMatchResults {
-		totalMatchesPlayed += len(weekResult.Matches)
+	played, err := lh.db.GetMatchesByLeague(context.Background(), match.LeagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d, simulating match %d without fatigue: %v", match.LeagueID, match.ID, err)
+		return false, false
 	}
 
-	// 7. Create response
-	resp := models.PlayAllMatchesResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
-		StartingWeek:       startingWeek,
-		FinalWeek:          league.CurrentWeek,
-		WeeksPlayed:        weeksPlayed,
-		TotalMatchesPlayed: totalMatchesPlayed,
-		WeekResults:        allMatchResults,
-		Message:            fmt.Sprintf("League '%s' completed successfully. Played %d weeks with %d total matches.", league.Name, weeksPlayed, totalMatchesPlayed),
+	for _, previous := range played {
+		if previous.ID == match.ID || previous.KickoffTime == nil {
+			continue
+		}
+		gap := match.KickoffTime.Sub(*previous.KickoffTime)
+		if gap < 0 || gap >= fatigueWindow {
+			continue
+		}
+		for _, teamID := range []int{previous.HomeTeamID, previous.AwayTeamID} {
+			homeFatigued = homeFatigued || teamID == match.HomeTeamID
+			awayFatigued = awayFatigued || teamID == match.AwayTeamID
+		}
 	}
+	return homeFatigued, awayFatigued
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+// generateMatchResult simulates a football match using team strengths to influence the result
+func (lh *LeagueHandler) generateMatchResult(homeTeamID, awayTeamID int, settings simulationSettings) (int, int) {
+	homeStrength, awayStrength, known := lh.matchStrengths(homeTeamID, awayTeamID)
+	if !known {
+		// Fallback to basic random only if we can't get info for either team
+		return lh.basicRandomGoals(), lh.basicRandomGoals()
+	}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	// Registered rivalries are simulated with extra variance
+	rivalry, err := lh.db.IsRivalry(context.Background(), homeTeamID, awayTeamID)
+	if err != nil {
+		log.Printf("Failed to check rivalry between teams %d and %d, simulating as a normal match: %v", homeTeamID, awayTeamID, err)
 	}
+	settings.rivalry = rivalry
+
+	// Simulate match based on team strengths
+	return lh.simulateMatch(homeStrength, awayStrength, settings)
 }
 
-// PredictChampionHandler handles GET /api/leagues/predict-champion/:leagueID
-func (lh *LeagueHandler) PredictChampionHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+// matchStrengths looks up the strengths of both teams, substituting neutralStrength for a
+// team that can't be found. known is false when neither team could be found.
+func (lh *LeagueHandler) matchStrengths(homeTeamID, awayTeamID int) (homeStrength, awayStrength int, known bool) {
+	homeStrength, awayStrength = neutralStrength, neutralStrength
+
+	homeTeam, homeErr := lh.db.GetTeamByID(context.Background(), homeTeamID)
+	if homeErr != nil {
+		log.Printf("Failed to get home team %d, using neutral strength: %v", homeTeamID, homeErr)
+	} else {
+		homeStrength = homeTeam.Strength
 	}
 
-	// Extract leagueID from URL path
-	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "predict-champion" {
-		http.Error(w, "Invalid URL path", http.StatusBadRequest)
-		return
+	awayTeam, awayErr := lh.db.GetTeamByID(context.Background(), awayTeamID)
+	if awayErr != nil {
+		log.Printf("Failed to get away team %d, using neutral strength: %v", awayTeamID, awayErr)
+	} else {
+		awayStrength = awayTeam.Strength
 	}
 
-	leagueID, err := strconv.Atoi(pathParts[3])
-	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
-		return
+	if homeErr == nil && awayErr == nil {
+		log.Printf("DEBUG: Team strengths - Home: %s (%d), Away: %s (%d)", homeTeam.Name, homeStrength, awayTeam.Name, awayStrength)
 	}
 
-	ctx := r.Context()
+	return homeStrength, awayStrength, homeErr == nil || awayErr == nil
+}
 
-	// 1. Validate league exists and get its current state
-	league, err := lh.db.GetLeagueByID(ctx, leagueID)
-	if err != nil {
-		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
-		if strings.Contains(err.Error(), "no rows") {
-			http.Error(w, "League not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Failed to get league", http.StatusInternalServerError)
-		}
-		return
+// simulateMatch generates realistic match results based on team strengths.
+// A score correlation above 0 dampens one side's expectancy when the other scores above its own.
+func (lh *LeagueHandler) simulateMatch(homeStrength, awayStrength int, settings simulationSettings) (int, int) {
+	homeAdvantage := defaultHomeAdvantage
+	if settings.rivalry {
+		homeAdvantage -= rivalryHomeAdvantageCut
 	}
 
-	// 2. Check if league is in correct status and at least at week 4
-	if league.Status != "started" && league.Status != "finished" {
-		http.Error(w, fmt.Sprintf("League must be 'started' or 'finished' for predictions. Current status: %s", league.Status), http.StatusBadRequest)
-		return
+	homeGoalExpectancy, awayGoalExpectancy := lh.simulatedExpectancy(homeStrength, awayStrength, homeAdvantage, settings)
+	if settings.rivalry {
+		scoring := settings.scoringProfile()
+		homeGoalExpectancy = rivalryExpectancy(homeGoalExpectancy, scoring)
+		awayGoalExpectancy = rivalryExpectancy(awayGoalExpectancy, scoring)
 	}
+	correlation := settings.scoreCorrelation
 
-	if league.CurrentWeek < 4 {
-		http.Error(w, fmt.Sprintf("Championship prediction requires at least 4 weeks of play. Current week: %d", league.CurrentWeek), http.StatusBadRequest)
-		return
+	// Use Poisson-like distribution for goal generation
+	var homeGoals, awayGoals int
+	if correlation <= 0 {
+		homeGoals = lh.generateGoalsFromExpectancy(homeGoalExpectancy)
+		awayGoals = lh.generateGoalsFromExpectancy(awayGoalExpectancy)
+	} else if rand.Intn(2) == 0 {
+		// Sample a random side first so the dampening favours neither venue
+		homeGoals = lh.generateGoalsFromExpectancy(homeGoalExpectancy)
+		awayGoals = lh.generateGoalsFromExpectancy(dampenedExpectancy(awayGoalExpectancy, homeGoals, homeGoalExpectancy, correlation))
+	} else {
+		awayGoals = lh.generateGoalsFromExpectancy(awayGoalExpectancy)
+		homeGoals = lh.generateGoalsFromExpectancy(dampenedExpectancy(homeGoalExpectancy, awayGoals, awayGoalExpectancy, correlation))
 	}
 
-	// 3. Get current standings
-	standings, err := lh.db.GetStandings(ctx, leagueID)
-	if err != nil {
-		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
-		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+	log.Printf("DEBUG: Final goals - Home: %d, Away: %d", homeGoals, awayGoals)
+	return homeGoals, awayGoals
+}
+
+// rivalryExpectancy swings a goal expectancy at random by up to rivalrySwing either way,
+// keeping it within the scoring profile's bounds
+func rivalryExpectancy(expectancy float64, scoring models.ScoringProfile) float64 {
+	swung := expectancy + (rand.Float64()*2-1)*rivalrySwing
+	return min(max(swung, scoring.ExpectancyMin), scoring.ExpectancyMax)
+}
+
+// dampenedExpectancy reduces a side's goal expectancy in proportion to how far the opponent's
+// goals exceeded the opponent's own expectancy, scaled by the correlation (0 to 1)
+func dampenedExpectancy(expectancy float64, opponentGoals int, opponentExpectancy, correlation float64) float64 {
+	excess := float64(opponentGoals) - opponentExpectancy
+	if excess <= 0 {
+		return expectancy
+	}
+	return expectancy * (1 - correlation*excess/float64(opponentGoals))
+}
+
+// goalExpectancy calculates the expected goals for each side based on team strengths.
+// The upset factor (0 to 1) compresses the strength difference toward parity; at 1 both
+// sides get the same expectancy.
+func (lh *LeagueHandler) goalExpectancy(homeStrength, awayStrength int, upsetFactor float64) (float64, float64) {
+	// Add home advantage (typically 3-5 points)
+	return lh.simulatedExpectancy(homeStrength, awayStrength, defaultHomeAdvantage, simulationSettings{upsetFactor: upsetFactor})
+}
+
+// simulatedExpectancy calculates the expected goals for each side, adding the given home
+// advantage to the home team's strength and using the settings' scoring profile
+func (lh *LeagueHandler) simulatedExpectancy(homeStrength, awayStrength, homeAdvantage int, settings simulationSettings) (float64, float64) {
+	// Fatigued sides play below their usual strength
+	if settings.homeFatigued {
+		homeStrength -= settings.fatiguePenalty
+	}
+	if settings.awayFatigued {
+		awayStrength -= settings.fatiguePenalty
+	}
+
+	adjustedHomeStrength := homeStrength + homeAdvantage
+	scoring := settings.scoringProfile()
+
+	// Calculate strength difference (-100 to +100 range)
+	strengthDiff := adjustedHomeStrength - awayStrength
+
+	// Generate goal expectancy around the profile's base (1.5 goals per team for the balanced preset)
+	effectiveDiff := float64(strengthDiff) * (1 - settings.upsetFactor)
+	homeGoalExpectancy := scoring.ExpectancyBase + effectiveDiff/100.0 // Stronger teams score more
+	awayGoalExpectancy := scoring.ExpectancyBase - effectiveDiff/100.0 // Weaker teams score less
+
+	// Keep within the profile's bounds (0.5 to 3.0 goals expectancy for the balanced preset)
+	homeGoalExpectancy = min(max(homeGoalExpectancy, scoring.ExpectancyMin), scoring.ExpectancyMax)
+	awayGoalExpectancy = min(max(awayGoalExpectancy, scoring.ExpectancyMin), scoring.ExpectancyMax)
+
+	// Debug expectancy calculations
+	log.Printf("DEBUG: Expectancy - Home: %.2f, Away: %.2f (strengthDiff: %d)", homeGoalExpectancy, awayGoalExpectancy, strengthDiff)
+
+	return homeGoalExpectancy, awayGoalExpectancy
+}
+
+// SimulateMatchHandler handles POST /api/simulate-match
+// It simulates a single match from two strengths (or team IDs) without any league context or persistence.
+func (lh *LeagueHandler) SimulateMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// 4. If league is finished, return actual winner
-	if league.Status == "finished" {
-		championProbabilities := lh.getActualChampion(standings)
+	var req models.SimulateMatchRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
 
-		resp := models.PredictChampionResponse{
-			League: models.LeagueResponse{
-				ID:          league.ID,
-				Name:        league.Name,
-				Status:      league.Status,
-				CurrentWeek: league.CurrentWeek,
-				CreatedAt:   league.CreatedAt,
-			},
-			PredictionWeek:        league.CurrentWeek,
-			Simulations:           0,
-			CurrentStandings:      standings,
-			ChampionProbabilities: championProbabilities,
-			Message:               fmt.Sprintf("League '%s' is finished. Showing actual champion.", league.Name),
+	homeAdvantage := defaultHomeAdvantage
+	if req.HomeAdvantage != nil {
+		if *req.HomeAdvantage < 0 || *req.HomeAdvantage > 100 {
+			http.Error(w, "home_advantage must be between 0 and 100", http.StatusBadRequest)
+			return
 		}
+		homeAdvantage = *req.HomeAdvantage
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+	ctx := r.Context()
 
-		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			log.Printf("Failed to encode response: %v", err)
-		}
+	// 1. Resolve the strength of each side
+	homeStrength, status, err := lh.simulationStrength(ctx, "home", req.HomeStrength, req.HomeTeamID)
+	if err != nil {
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	// 5. Get teams and remaining matches for simulation
-	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	awayStrength, status, err := lh.simulationStrength(ctx, "away", req.AwayStrength, req.AwayTeamID)
 	if err != nil {
-		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
-		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	totalWeeks := lh.calculateTotalWeeks(len(teams))
+	// 2. Simulate the scoreline from the expectancy
+	homeExpectancy, awayExpectancy := lh.simulatedExpectancy(homeStrength, awayStrength, homeAdvantage, simulationSettings{})
 
-	// Get all remaining matches
-	var remainingMatches []*models.Match
-	for week := league.CurrentWeek + 1; week <= totalWeeks; week++ {
-		weekMatches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, week)
-		if err != nil {
-			log.Printf("Failed to get matches for week %d: %v", week, err)
-			continue
-		}
-		remainingMatches = append(remainingMatches, weekMatches...)
+	resp := models.SimulateMatchResponse{
+		HomeStrength:   homeStrength,
+		AwayStrength:   awayStrength,
+		HomeAdvantage:  homeAdvantage,
+		HomeExpectancy: homeExpectancy,
+		AwayExpectancy: awayExpectancy,
+		HomeGoals:      lh.generateGoalsFromExpectancy(homeExpectancy),
+		AwayGoals:      lh.generateGoalsFromExpectancy(awayExpectancy),
 	}
 
-	// 6. Run Monte Carlo simulation
-	const numSimulations = 10000
-	championCounts := make(map[int]int) // teamID -> number of times champion
+	writeJSON(w, r, http.StatusOK, resp)
+}
 
-	log.Printf("Running %d simulations to predict champion for league %d", numSimulations, leagueID)
+// maxSeriesMatches caps the number of matches in a simulated series
+const maxSeriesMatches = 1000
 
-	for sim := 0; sim < numSimulations; sim++ {
-		champion := lh.simulateRestOfSeason(standings, remainingMatches, teams)
-		championCounts[champion]++
+// SimulateSeriesHandler handles POST /api/simulate-series
+// It simulates a series between two teams outside any league, alternating which side is at home,
+// and reports the wins, draws and average scoreline.
+func (lh *LeagueHandler) SimulateSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
 
-	// 7. Calculate probabilities
-	championProbabilities := make([]models.ChampionProbability, 0, len(championCounts))
-	for _, standing := range standings {
-		count := championCounts[standing.TeamID]
-		probability := float64(count) / float64(numSimulations) * 100.0
+	var req models.SimulateSeriesRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
 
-		championProbabilities = append(championProbabilities, models.ChampionProbability{
-			TeamID:      standing.TeamID,
-			TeamName:    standing.TeamName,
-			Probability: probability,
-		})
+	if req.Team1ID == req.Team2ID {
+		http.Error(w, "team1_id and team2_id must be different teams", http.StatusBadRequest)
+		return
+	}
+	if req.Matches < 1 || req.Matches > maxSeriesMatches {
+		http.Error(w, fmt.Sprintf("matches must be between 1 and %d", maxSeriesMatches), http.StatusBadRequest)
+		return
 	}
 
-	// Sort by probability (highest first)
-	for i := 0; i < len(championProbabilities)-1; i++ {
-		for j := i + 1; j < len(championProbabilities); j++ {
-			if championProbabilities[j].Probability > championProbabilities[i].Probability {
-				championProbabilities[i], championProbabilities[j] = championProbabilities[j], championProbabilities[i]
+	ctx := r.Context()
+
+	// 1. Get both teams
+	teams := make([]*models.Team, 0, 2)
+	for _, teamID := range []int{req.Team1ID, req.Team2ID} {
+		team, err := lh.db.GetTeamByID(ctx, teamID)
+		if err != nil {
+			log.Printf("Failed to get team %d: %v", teamID, err)
+			if strings.Contains(err.Error(), "no rows") {
+				http.Error(w, fmt.Sprintf("Team %d not found", teamID), http.StatusNotFound)
+			} else {
+				http.Error(w, "Failed to get team", http.StatusInternalServerError)
 			}
+			return
 		}
+		teams = append(teams, team)
 	}
+	team1, team2 := teams[0], teams[1]
 
-	// 8. Create response
-	resp := models.PredictChampionResponse{
-		League: models.LeagueResponse{
-			ID:          league.ID,
-			Name:        league.Name,
-			Status:      league.Status,
-			CurrentWeek: league.CurrentWeek,
-			CreatedAt:   league.CreatedAt,
-		},
-		PredictionWeek:        league.CurrentWeek,
-		Simulations:           numSimulations,
-		CurrentStandings:      standings,
-		ChampionProbabilities: championProbabilities,
-		Message:               fmt.Sprintf("Championship prediction for league '%s' after week %d based on %d simulations.", league.Name, league.CurrentWeek, numSimulations),
+	// 2. Simulate the series, team 1 at home in odd-numbered matches and team 2 in the others
+	resp := models.SimulateSeriesResponse{
+		Team1:   models.NewTeamResponse(team1),
+		Team2:   models.NewTeamResponse(team2),
+		Matches: req.Matches,
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	team1Goals, team2Goals := 0, 0
+	for i := 0; i < req.Matches; i++ {
+		var goals1, goals2 int
+		if i%2 == 0 {
+			goals1, goals2 = lh.simulateMatch(team1.Strength, team2.Strength, simulationSettings{})
+		} else {
+			goals2, goals1 = lh.simulateMatch(team2.Strength, team1.Strength, simulationSettings{})
+		}
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+		team1Goals += goals1
+		team2Goals += goals2
+		switch {
+		case goals1 > goals2:
+			resp.Team1Wins++
+		case goals1 < goals2:
+			resp.Team2Wins++
+		default:
+			resp.Draws++
+		}
 	}
+
+	resp.Team1AverageGoals = math.Round(float64(team1Goals)/float64(req.Matches)*100) / 100
+	resp.Team2AverageGoals = math.Round(float64(team2Goals)/float64(req.Matches)*100) / 100
+
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
-// getActualChampion returns 100% probability for the actual champion when league is finished
-func (lh *LeagueHandler) getActualChampion(standings []models.StandingWithTeam) []models.ChampionProbability {
-	var championProbabilities []models.ChampionProbability
+// simulationStrength resolves one side of a simulated match from either a strength or a team ID.
+// On error it also returns the HTTP status to respond with.
+func (lh *LeagueHandler) simulationStrength(ctx context.Context, side string, strength, teamID *int) (int, int, error) {
+	if (strength == nil) == (teamID == nil) {
+		return 0, http.StatusBadRequest, fmt.Errorf("exactly one of %s_strength or %s_team_id is required", side, side)
+	}
 
-	for i, standing := range standings {
-		probability := 0.0
-		if i == 0 { // First place is the champion
-			probability = 100.0
+	if strength != nil {
+		if *strength < 0 || *strength > 100 {
+			return 0, http.StatusBadRequest, fmt.Errorf("%s_strength must be between 0 and 100", side)
 		}
+		return *strength, 0, nil
+	}
 
-		championProbabilities = append(championProbabilities, models.ChampionProbability{
-			TeamID:      standing.TeamID,
-			TeamName:    standing.TeamName,
-			Probability: probability,
-		})
+	team, err := lh.db.GetTeamByID(ctx, *teamID)
+	if err != nil {
+		log.Printf("Failed to get %s team %d: %v", side, *teamID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			return 0, http.StatusNotFound, fmt.Errorf("%s team not found", side)
+		}
+		return 0, http.StatusInternalServerError, fmt.Errorf("failed to get %s team", side)
 	}
 
-	return championProbabilities
+	return team.Strength, 0, nil
 }
 
-// simulateRestOfSeason simulates all remaining matches and returns the champion team ID
-func (lh *LeagueHandler) simulateRestOfSeason(currentStandings []models.StandingWithTeam, remainingMatches []*models.Match, teams []*models.Team) int {
-	// Create a copy of current standings for simulation
-	standings := make(map[int]*models.Standing)
-	for _, s := range currentStandings {
-		standings[s.TeamID] = &models.Standing{
-			TeamID:         s.TeamID,
-			Points:         s.Points,
-			Played:         s.Played,
-			Wins:           s.Wins,
-			Draws:          s.Draws,
-			Losses:         s.Losses,
-			GoalsFor:       s.GoalsFor,
-			GoalsAgainst:   s.GoalsAgainst,
-			GoalDifference: s.GoalDifference,
-		}
-	}
+// expectedMatchResult returns the most likely scoreline for a match instead of a random sample,
+// so completing a league this way is deterministic and repeatable
+func (lh *LeagueHandler) expectedMatchResult(homeTeamID, awayTeamID int, settings simulationSettings) (int, int) {
+	homeStrength, awayStrength, _ := lh.matchStrengths(homeTeamID, awayTeamID)
 
-	// Get team strengths for simulation
-	teamStrengths := make(map[int]int)
-	for _, team := range teams {
-		teamStrengths[team.ID] = team.Strength
-	}
+	homeGoalExpectancy, awayGoalExpectancy := lh.simulatedExpectancy(homeStrength, awayStrength, defaultHomeAdvantage, settings)
 
-	// Simulate all remaining matches
-	for _, match := range remainingMatches {
-		homeStrength := teamStrengths[match.HomeTeamID]
-		awayStrength := teamStrengths[match.AwayTeamID]
+	return modeScore(homeGoalExpectancy, awayGoalExpectancy)
+}
 
-		homeGoals, awayGoals := lh.simulateMatch(homeStrength, awayStrength)
+// modeScore returns the modal goals for each side, treating goals as Poisson distributed with the
+// given expectancies. The mode of a Poisson distribution is the floor of its expectancy; a whole
+// number expectancy has two modes, and the higher one is used. Expectancies below zero give 0.
+func modeScore(homeExpectancy, awayExpectancy float64) (int, int) {
+	mode := func(expectancy float64) int {
+		if !(expectancy > 0) {
+			return 0
+		}
+		return int(math.Floor(expectancy))
+	}
+	return mode(homeExpectancy), mode(awayExpectancy)
+}
 
-		// Update standings based on match result
-		lh.updateStandingsInMemory(standings, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals)
+// goalDistribution returns the percentage chance of a side scoring 0, 1, 2... goals for its goal
+// expectancy. The simpler distributions are banded: mostly 0-1 goals for a low-scoring side, a
+// balanced spread up to 5 for a medium-scoring side and more goals likely for a high-scoring side.
+func goalDistribution(expectancy float64) []int {
+	switch {
+	case expectancy <= 1.0:
+		return []int{50, 35, 10, 5}
+	case expectancy <= 2.0:
+		return []int{25, 25, 25, 15, 7, 3}
+	default:
+		return []int{15, 15, 20, 20, 15, 10, 5}
 	}
+}
 
-	// Find champion (team with most points, then best goal difference)
-	var championID int
+// resultProbabilities returns the chances of a home win, a draw and an away win for two goal
+// expectancies, from each side's goalDistribution sampled independently
+func resultProbabilities(homeExpectancy, awayExpectancy float64) (homeWin, draw, awayWin float64) {
+	homeDistribution, awayDistribution := goalDistribution(homeExpectancy), goalDistribution(awayExpectancy)
+	for homeGoals, homeChance := range homeDistribution {
+		for awayGoals, awayChance := range awayDistribution {
+			chance := float64(homeChance*awayChance) / 10000
+			switch {
+			case homeGoals > awayGoals:
+				homeWin += chance
+			case homeGoals < awayGoals:
+				awayWin += chance
+			default:
+				draw += chance
+			}
+		}
+	}
+	return homeWin, draw, awayWin
+}
+
+// generateGoalsFromExpectancy generates goals using weighted probability based on expectancy
+func (lh *LeagueHandler) generateGoalsFromExpectancy(expectancy float64) int {
+	// Use time-based seed with microseconds for better randomness
+	rand.Seed(time.Now().UnixNano())
+
+	// Generate a random number 0-99 for easier probability calculation
+	randNum := rand.Intn(100)
+
+	// Debug the inputs and random number
+	log.Printf("DEBUG: generateGoalsFromExpectancy called with expectancy=%.2f, randNum=%d", expectancy, randNum)
+
+	// Walk the cumulative distribution until it passes the random number
+	distribution := goalDistribution(expectancy)
+	goals, cumulative := 0, 0
+	for goals < len(distribution)-1 {
+		cumulative += distribution[goals]
+		if randNum < cumulative {
+			break
+		}
+		goals++
+	}
+
+	log.Printf("DEBUG: generateGoalsFromExpectancy returning %d goals", goals)
+	return goals
+}
+
+// ViewMatchesHandler handles GET /api/leagues/view-matches/:leagueID
+func (lh *LeagueHandler) ViewMatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "view-matches" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[3])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists and get its current state
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Check if league has been started
+	if league.Status == "created" {
+		http.Error(w, "League has not been started yet. No matches to view.", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Get all matches for the current week
+	matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, league.CurrentWeek)
+	if err != nil {
+		log.Printf("Failed to get matches for league %d week %d: %v", leagueID, league.CurrentWeek, err)
+		http.Error(w, "Failed to get matches for the current week", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. If no matches for current week, return empty result
+	if len(matches) == 0 {
+		resp := models.ViewMatchesResponse{
+			League:           models.NewLeagueResponse(league),
+			CurrentWeek:      league.CurrentWeek,
+			Matches:          []models.MatchResult{},
+			PlayedMatches:    []models.MatchResult{},
+			ScheduledMatches: []models.MatchResult{},
+			Message:          fmt.Sprintf("No matches found for week %d in league '%s'", league.CurrentWeek, league.Name),
+		}
+
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	// 5. Build match results with team information, split into those played and still to play
+	var matchResults []models.MatchResult
+	played := []models.MatchResult{}
+	scheduled := []models.MatchResult{}
+	for _, match := range matches {
+		// Get team names for response
+		homeTeam, err := lh.db.GetTeamByID(ctx, match.HomeTeamID)
+		if err != nil {
+			log.Printf("Failed to get home team %d: %v", match.HomeTeamID, err)
+			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+			return
+		}
+
+		awayTeam, err := lh.db.GetTeamByID(ctx, match.AwayTeamID)
+		if err != nil {
+			log.Printf("Failed to get away team %d: %v", match.AwayTeamID, err)
+			http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+			return
+		}
+
+		// Create result string based on match status
+		var result string
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		} else {
+			result = "Not played yet"
+		}
+
+		// Create match result for response
+		matchResult := models.MatchResult{
+			Match:    *match,
+			HomeTeam: homeTeam.Name,
+			AwayTeam: awayTeam.Name,
+			Result:   result,
+			Edited:   match.Edited,
+		}
+		matchResults = append(matchResults, matchResult)
+
+		switch match.Status {
+		case "played":
+			played = append(played, matchResult)
+		case "scheduled":
+			scheduled = append(scheduled, matchResult)
+		}
+	}
+
+	// 6. Create response
+	resp := models.ViewMatchesResponse{
+		League:           models.NewLeagueResponse(league),
+		CurrentWeek:      league.CurrentWeek,
+		Matches:          matchResults,
+		PlayedMatches:    played,
+		ScheduledMatches: scheduled,
+		Message:          fmt.Sprintf("Matches for week %d in league '%s'", league.CurrentWeek, league.Name),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// validMatchStatuses lists the statuses a match can be filtered by
+var validMatchStatuses = map[string]bool{
+	"scheduled": true,
+	"played":    true,
+	"cancelled": true,
+}
+
+// ListMatchesHandler handles GET /api/leagues/:leagueID/matches?status=
+// and GET /api/leagues/:leagueID/cancelled, which lists only cancelled matches
+func (lh *LeagueHandler) ListMatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || (pathParts[3] != "matches" && pathParts[3] != "cancelled") {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Validate the optional status filter
+	status := r.URL.Query().Get("status")
+	if pathParts[3] == "cancelled" {
+		status = "cancelled"
+	}
+	if status != "" && !validMatchStatuses[status] {
+		http.Error(w, fmt.Sprintf("Invalid status '%s'. Must be one of: scheduled, played, cancelled", status), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get matches, filtered by status in the query
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, status)
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Resolve team names once for all matches
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		return
+	}
+
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	matchResults := make([]models.MatchResult, 0, len(matches))
+	for _, match := range matches {
+		result := "Not played yet"
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+
+		matchResults = append(matchResults, models.MatchResult{
+			Match:    *match,
+			HomeTeam: teamNames[match.HomeTeamID],
+			AwayTeam: teamNames[match.AwayTeamID],
+			Result:   result,
+			Edited:   match.Edited,
+		})
+	}
+
+	// 4. Create response
+	resp := models.LeagueMatchesResponse{
+		League:  models.NewLeagueResponse(league),
+		Status:  status,
+		Matches: matchResults,
+		Message: fmt.Sprintf("Found %d matches in league '%s'", len(matchResults), league.Name),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// maxPlayAllWeeks caps how many weeks a single play-all request will play, guarding
+// against a runaway loop if a league's schedule is ever inconsistent
+var maxPlayAllWeeks = 1000
+
+// PlayAllMatchesHandler handles POST /api/leagues/play-all-matches/:leagueID
+// The league is only marked finished once none of its matches are left scheduled.
+func (lh *LeagueHandler) PlayAllMatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "play-all-matches" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[3])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Simulation mode: "random" (default) samples results, "expected" uses the most likely scoreline
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = "random"
+	}
+	if mode != "random" && mode != "expected" {
+		http.Error(w, fmt.Sprintf("Invalid mode '%s'. Must be one of: random, expected", mode), http.StatusBadRequest)
+		return
+	}
+
+	// Optional temperature (0 to 1) spans the two modes: 0 plays the expected scoreline, 1 samples
+	// at random, and values between pull sampled goals towards the expected ones
+	var temperature *float64
+	if value := r.URL.Query().Get("temperature"); value != "" {
+		if r.URL.Query().Get("mode") != "" {
+			http.Error(w, "Use either mode or temperature, not both", http.StatusBadRequest)
+			return
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || !(parsed >= 0 && parsed <= 1) {
+			http.Error(w, fmt.Sprintf("Invalid temperature '%s'. Must be a number between 0 and 1", value), http.StatusBadRequest)
+			return
+		}
+		temperature = &parsed
+		mode = "tempered"
+	}
+	ctx := r.Context()
+
+	// 1. Validate league exists and get its current state
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Check if league is in correct status to play matches
+	if league.Status != "started" {
+		http.Error(w, fmt.Sprintf("League must be 'started' to play matches. Current status: %s", league.Status), http.StatusBadRequest)
+		return
+	}
+
+	resultFn := lh.leagueMatchResult(league)
+	switch mode {
+	case "expected":
+		resultFn = lh.leagueExpectedResult(league)
+	case "tempered":
+		resultFn = lh.leagueTemperedResult(league, *temperature)
+	}
+
+	// 3. Calculate total weeks for this league
+	totalWeeks, err := lh.leagueTotalWeeks(ctx, league)
+	if err != nil {
+		log.Printf("Failed to get total weeks for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league schedule", http.StatusInternalServerError)
+		return
+	}
+	startingWeek := league.CurrentWeek
+	var allMatchResults []models.WeekResult
+	weeksPlayed := 0
+
+	// 4. Play all remaining weeks, up to the safety cap
+	lastWeek := min(totalWeeks, league.CurrentWeek+maxPlayAllWeeks)
+	for currentWeek := league.CurrentWeek + 1; currentWeek <= lastWeek; currentWeek++ {
+		// Get all matches for this week
+		matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, currentWeek)
+		if err != nil {
+			log.Printf("Failed to get matches for league %d week %d: %v", leagueID, currentWeek, err)
+			http.Error(w, "Failed to get matches for week", http.StatusInternalServerError)
+			return
+		}
+
+		// If no matches for this week, we're done
+		if len(matches) == 0 {
+			break
+		}
+
+		// Play all scheduled matches for this week
+		weekMatchResults, err := lh.playWeek(ctx, leagueID, matches, resultFn)
+		if err != nil {
+			log.Printf("Failed to play week %d for league %d: %v", currentWeek, leagueID, err)
+			http.Error(w, "Failed to play matches", http.StatusInternalServerError)
+			return
+		}
+
+		if err := lh.awardByePoints(ctx, league, matches); err != nil {
+			log.Printf("Failed to award bye points for week %d of league %d: %v", currentWeek, leagueID, err)
+			http.Error(w, "Failed to award bye points", http.StatusInternalServerError)
+			return
+		}
+
+		// Add week result to all results
+		weekResult := models.WeekResult{
+			Week:    currentWeek,
+			Matches: weekMatchResults,
+		}
+		allMatchResults = append(allMatchResults, weekResult)
+
+		// Advance the league week
+		if err := lh.db.AdvanceLeagueWeek(ctx, leagueID); err != nil {
+			log.Printf("Failed to advance league %d week: %v", leagueID, err)
+			http.Error(w, "Failed to advance league week", http.StatusInternalServerError)
+			return
+		}
+
+		weeksPlayed++
+		league.CurrentWeek = currentWeek
+		lh.recordWeekAdvanced(ctx, leagueID, currentWeek, len(weekMatchResults))
+	}
+
+	// 5. Make sure every match was played; a match the loop never reached leaves the league unfinished
+	leftover, err := lh.db.GetMatchesByLeague(ctx, leagueID, "scheduled")
+	if err != nil {
+		log.Printf("Failed to get scheduled matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+	if len(leftover) > 0 {
+		leftoverIDs := make([]int, len(leftover))
+		for i, match := range leftover {
+			leftoverIDs[i] = match.ID
+		}
+		log.Printf("League %d still has scheduled matches %v after playing up to week %d", leagueID, leftoverIDs, league.CurrentWeek)
+		writeJSON(w, r, http.StatusConflict, models.UnplayedMatchesErrorResponse{
+			Error:    fmt.Sprintf("Played up to week %d but matches are still scheduled; the league was not marked finished", league.CurrentWeek),
+			MatchIDs: leftoverIDs,
+		})
+		return
+	}
+
+	// 6. Make sure no fixture went missing from the schedule, then mark the league as finished
+	if err := lh.checkSeasonComplete(ctx, league); err != nil {
+		log.Printf("WARN not marking league %d finished after week %d: %v", leagueID, league.CurrentWeek, err)
+		var incomplete *incompleteSeasonError
+		if errors.As(err, &incomplete) {
+			http.Error(w, fmt.Sprintf("Played up to week %d but %d of %d expected matches were played or cancelled; the league was not marked finished",
+				league.CurrentWeek, incomplete.accounted, incomplete.expected), http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to check league matches", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := lh.db.UpdateLeagueStatus(ctx, leagueID, "finished"); err != nil {
+		log.Printf("Failed to mark league as finished: %v", err)
+		http.Error(w, "Failed to update league status", http.StatusInternalServerError)
+		return
+	}
+	league.Status = "finished"
+	lh.recordFinished(ctx, leagueID, league.CurrentWeek)
+
+	// 7. Record the run's parameters and final table for provenance
+	runID := lh.recordSimulationRun(ctx, models.SimulationRun{
+		LeagueID:     leagueID,
+		Mode:         mode,
+		Temperature:  temperature,
+		StartingWeek: startingWeek,
+		FinalWeek:    league.CurrentWeek,
+	})
+
+	// 8. Count total matches played
+	totalMatchesPlayed := 0
+	for _, weekResult := range allMatchResults {
+		totalMatchesPlayed += len(weekResult.Matches)
+	}
+
+	// 9. Create response
+	resp := models.PlayAllMatchesResponse{
+		League:             models.NewLeagueResponse(league),
+		Mode:               mode,
+		Temperature:        temperature,
+		StartingWeek:       startingWeek,
+		FinalWeek:          league.CurrentWeek,
+		WeeksPlayed:        weeksPlayed,
+		TotalMatchesPlayed: totalMatchesPlayed,
+		WeekResults:        allMatchResults,
+		RunID:              runID,
+		Message:            fmt.Sprintf("League '%s' completed successfully. Played %d weeks with %d total matches.", league.Name, weeksPlayed, totalMatchesPlayed),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// recordSimulationRun saves a finished play-all run with the hash of the league's final table and
+// returns its ID. Like recordEvent, a failure is only logged and gives a nil ID, since the league
+// has already been played.
+func (lh *LeagueHandler) recordSimulationRun(ctx context.Context, run models.SimulationRun) *int {
+	standings, err := lh.db.GetStandings(ctx, run.LeagueID)
+	if err != nil {
+		log.Printf("Failed to get standings to record simulation run for league %d: %v", run.LeagueID, err)
+		return nil
+	}
+	run.TableHash = standingsHash(standings)
+
+	recorded, err := lh.db.RecordSimulationRun(ctx, run)
+	if err != nil {
+		log.Printf("Failed to record simulation run for league %d: %v", run.LeagueID, err)
+		return nil
+	}
+	return &recorded.ID
+}
+
+// standingsHash returns the hex SHA-256 of a table's rows in order, so two runs ending in the same
+// table share a hash
+func standingsHash(standings []models.StandingWithTeam) string {
+	hash := sha256.New()
+	for _, standing := range standings {
+		fmt.Fprintf(hash, "%d:%d:%d:%d:%d:%d:%d:%d\n", standing.TeamID, standing.Played, standing.Wins, standing.Draws,
+			standing.Losses, standing.GoalsFor, standing.GoalsAgainst, standing.Points)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SimulationRunsHandler handles GET /api/leagues/:leagueID/runs
+// It lists the league's recorded play-all runs, oldest first.
+func (lh *LeagueHandler) SimulationRunsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "runs" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the league's runs
+	runs, err := lh.db.GetSimulationRuns(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get simulation runs for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get simulation runs", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.SimulationRunsResponse{
+		League: models.NewLeagueResponse(league),
+		Runs:   runs,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// PredictChampionHandler handles GET /api/leagues/predict-champion/:leagueID
+func (lh *LeagueHandler) PredictChampionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "predict-champion" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[3])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists and get its current state
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Check if league is in correct status and at least at week 4
+	if league.Status != "started" && league.Status != "paused" && league.Status != "finished" {
+		http.Error(w, fmt.Sprintf("League must be 'started', 'paused' or 'finished' for predictions. Current status: %s", league.Status), http.StatusBadRequest)
+		return
+	}
+
+	if league.CurrentWeek < 4 {
+		http.Error(w, fmt.Sprintf("Championship prediction requires at least 4 weeks of play. Current week: %d", league.CurrentWeek), http.StatusBadRequest)
+		return
+	}
+
+	// 3. Get current standings
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. If league is finished, return actual winner
+	if league.Status == "finished" {
+		matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+		if err != nil {
+			log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+			http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+			return
+		}
+
+		champions := lh.titleWinners(standings, matches)
+		resp := models.PredictChampionResponse{
+			League:                models.NewLeagueResponse(league),
+			PredictionWeek:        league.CurrentWeek,
+			Simulations:           0,
+			CurrentStandings:      standings,
+			ChampionProbabilities: lh.getActualChampion(standings, champions),
+			Message:               fmt.Sprintf("League '%s' is finished. Showing actual champion.", league.Name),
+		}
+
+		// Teams that no tiebreak can separate share the title
+		if len(champions) > 1 {
+			names := make([]string, 0, len(champions))
+			for _, champion := range champions {
+				names = append(names, champion.TeamName)
+			}
+			resp.CoChampions = champions
+			resp.Message = fmt.Sprintf("League '%s' is finished with a shared title between %s.", league.Name, strings.Join(names, ", "))
+		}
+
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	// 5. Get teams and remaining matches for simulation
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		return
+	}
+
+	totalWeeks, err := lh.leagueTotalWeeks(ctx, league)
+	if err != nil {
+		log.Printf("Failed to calculate total weeks for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to calculate league length", http.StatusInternalServerError)
+		return
+	}
+
+	// Get all remaining matches. Future matches already settled by a forfeit are in the standings,
+	// and cancelled ones will never be played, so only scheduled matches are simulated.
+	var remainingMatches []*models.Match
+	for week := league.CurrentWeek + 1; week <= totalWeeks; week++ {
+		weekMatches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, week)
+		if err != nil {
+			log.Printf("Failed to get matches for week %d: %v", week, err)
+			continue
+		}
+		for _, match := range weekMatches {
+			if match.Status == "scheduled" {
+				remainingMatches = append(remainingMatches, match)
+			}
+		}
+	}
+
+	// 6. Run Monte Carlo simulation
+	const numSimulations = 10000
+	championCounts := make(map[int]int) // teamID -> number of times champion
+
+	log.Printf("Running %d simulations to predict champion for league %d", numSimulations, leagueID)
+
+	for sim := 0; sim < numSimulations; sim++ {
+		champion := lh.simulateRestOfSeason(standings, remainingMatches, teams, leagueSimulation(league))
+		championCounts[champion]++
+	}
+
+	// 7. Calculate probabilities
+	championProbabilities := make([]models.ChampionProbability, 0, len(championCounts))
+	for _, standing := range standings {
+		count := championCounts[standing.TeamID]
+		probability := float64(count) / float64(numSimulations) * 100.0
+
+		championProbabilities = append(championProbabilities, models.ChampionProbability{
+			TeamID:      standing.TeamID,
+			TeamName:    standing.TeamName,
+			Probability: probability,
+		})
+	}
+
+	// Sort by probability (highest first)
+	for i := 0; i < len(championProbabilities)-1; i++ {
+		for j := i + 1; j < len(championProbabilities); j++ {
+			if championProbabilities[j].Probability > championProbabilities[i].Probability {
+				championProbabilities[i], championProbabilities[j] = championProbabilities[j], championProbabilities[i]
+			}
+		}
+	}
+
+	// 8. Create response
+	resp := models.PredictChampionResponse{
+		League:                models.NewLeagueResponse(league),
+		PredictionWeek:        league.CurrentWeek,
+		Simulations:           numSimulations,
+		CurrentStandings:      standings,
+		ChampionProbabilities: championProbabilities,
+		Message:               fmt.Sprintf("Championship prediction for league '%s' after week %d based on %d simulations.", league.Name, league.CurrentWeek, numSimulations),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// LeagueConfigHandler handles GET and PATCH /api/leagues/:leagueID/config
+// PATCH only changes the provided settings and is rejected once the league has started.
+func (lh *LeagueHandler) LeagueConfigHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPatch {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "config" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateLeagueConfigRequest
+	if r.Method == http.MethodPatch {
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				writeDecodeError(w, r, errEmptyBody)
+				return
+			}
+			http.Error(w, fmt.Sprintf("Invalid JSON payload: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if errs := validateLeagueConfigUpdate(&req); len(errs) > 0 {
+			writeValidationErrors(w, r, errs)
+			return
+		}
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	config := models.NewLeagueConfig(league)
+
+	if r.Method == http.MethodPatch {
+		// 2. Settings are fixed once the league has started
+		if league.Status != "created" {
+			http.Error(w, fmt.Sprintf("League configuration can only be changed before the league starts. Current status: %s", league.Status), http.StatusConflict)
+			return
+		}
+
+		// 3. Apply the provided settings and save them
+		if req.Zones != nil {
+			config.Zones = *req.Zones
+		}
+		if req.ScoreCorrelation != nil {
+			config.ScoreCorrelation = *req.ScoreCorrelation
+		}
+		if req.UpsetFactor != nil {
+			config.UpsetFactor = *req.UpsetFactor
+		}
+		if req.ScoringPreset != nil {
+			config.Scoring, _ = models.ResolveScoringPreset(*req.ScoringPreset)
+		}
+		if req.CleanSheetBonus != nil {
+			config.CleanSheetBonus = *req.CleanSheetBonus
+		}
+		if req.FinalTiebreak != nil {
+			config.FinalTiebreak = *req.FinalTiebreak
+		}
+		if req.TiebreakSeed != nil {
+			config.TiebreakSeed = *req.TiebreakSeed
+		}
+		if req.FatiguePenalty != nil {
+			config.FatiguePenalty = *req.FatiguePenalty
+		}
+		if req.MatchesPerWeek != nil {
+			config.MatchesPerWeek = *req.MatchesPerWeek
+		}
+		if req.ByePoints != nil {
+			config.ByePoints = *req.ByePoints
+		}
+		if req.BigWinMargin != nil {
+			config.BigWinMargin = *req.BigWinMargin
+		}
+		if req.BigWinBonus != nil {
+			config.BigWinBonus = *req.BigWinBonus
+		}
+		if req.WeekNumbering != nil {
+			config.WeekNumbering = *req.WeekNumbering
+		}
+
+		if err := lh.db.UpdateLeagueConfig(ctx, leagueID, config); err != nil {
+			log.Printf("Failed to update config for league %d: %v", leagueID, err)
+			if strings.Contains(err.Error(), "no created league") {
+				http.Error(w, "League has already started", http.StatusConflict)
+			} else {
+				http.Error(w, "Failed to update league configuration", http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	resp := models.LeagueConfigResponse{
+		LeagueID: league.ID,
+		Status:   league.Status,
+		Config:   config,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// StandingsHandler handles GET /api/leagues/:leagueID/standings?as_of=&teams=
+// teams is a comma-separated list of team IDs that narrows the table to a mini-table of those
+// teams; each keeps its league position and zone.
+func (lh *LeagueHandler) StandingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "standings" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Optional as_of (RFC 3339 or YYYY-MM-DD, a date covering the whole day) limits the table to matches played by then
+	var asOf *time.Time
+	if value := r.URL.Query().Get("as_of"); value != "" {
+		parsed, err := parseScheduleTime(value, true)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid as_of: %v", err), http.StatusBadRequest)
+			return
+		}
+		asOf = &parsed
+	}
+
+	// Optional teams limits the response to the listed teams
+	var teamFilter map[int]bool
+	if value := r.URL.Query().Get("teams"); value != "" {
+		teamFilter = make(map[int]bool)
+		for _, part := range strings.Split(value, ",") {
+			teamID, err := parsePathID(strings.TrimSpace(part))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid teams: %v", err), http.StatusBadRequest)
+				return
+			}
+			if teamFilter[teamID] {
+				http.Error(w, fmt.Sprintf("Invalid teams: team %d is listed more than once", teamID), http.StatusBadRequest)
+				return
+			}
+			teamFilter[teamID] = true
+		}
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get current standings
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. For a past as_of, rebuild the table from the matches played by then.
+	// A future as_of is served from the current standings, which already include every played match.
+	if asOf != nil && asOf.Before(time.Now()) {
+		matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+		if err != nil {
+			log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+			http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+			return
+		}
+		standings, err = lh.standingsAsOf(ctx, league, standings, matches, *asOf)
+		if err != nil {
+			log.Printf("Failed to rebuild standings for league %d: %v", leagueID, err)
+			http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// 4. Label each team with its zone
+	rows := assignZones(standings, league.Zones)
+
+	// 5. Narrow to the requested teams. Positions and zones come from the full table, and
+	// filtering keeps the league's tiebreak order.
+	if teamFilter != nil {
+		inLeague := make(map[int]bool, len(rows))
+		for _, row := range rows {
+			inLeague[row.TeamID] = true
+		}
+		for teamID := range teamFilter {
+			if !inLeague[teamID] {
+				http.Error(w, fmt.Sprintf("Team %d is not in this league", teamID), http.StatusNotFound)
+				return
+			}
+		}
+
+		filtered := make([]models.StandingRow, 0, len(teamFilter))
+		for _, row := range rows {
+			if teamFilter[row.TeamID] {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+
+	resp := models.StandingsResponse{
+		League:    models.NewLeagueResponse(league),
+		AsOf:      asOf,
+		Standings: rows,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// standingsAsOf rebuilds the table for the teams in current using only the played matches dated
+// before asOf. A match is dated by its kickoff time, or by when it was played if it had no kickoff.
+// matches holds every match of the league, so the bye points of each played week whose fixtures
+// are all dated before asOf can be added as well.
+func (lh *LeagueHandler) standingsAsOf(ctx context.Context, league *models.League, current []models.StandingWithTeam, matches []*models.Match, asOf time.Time) ([]models.StandingWithTeam, error) {
+	teamIDs := make([]int, 0, len(current))
+	for _, standing := range current {
+		teamIDs = append(teamIDs, standing.TeamID)
+	}
+
+	played := make([]*models.Match, 0, len(matches))
+	weekDone := make(map[int]bool)
+	for _, match := range matches {
+		date := match.KickoffTime
+		if date == nil {
+			date = match.PlayedAt
+		}
+		before := date != nil && date.Before(asOf)
+		if before && match.Status == "played" {
+			played = append(played, match)
+		}
+		if done, seen := weekDone[match.Week]; !seen || done {
+			weekDone[match.Week] = before
+		}
+	}
+
+	computed := lh.computeStandingsFromMatches(league.ID, teamIDs, played, league.Bonuses())
+
+	byes, err := lh.pointScoringByes(ctx, league, matches, league.CurrentWeek)
+	if err != nil {
+		return nil, err
+	}
+	for week, teamIDs := range byes {
+		if !weekDone[week] {
+			continue
+		}
+		for _, teamID := range teamIDs {
+			if standing, ok := computed[teamID]; ok {
+				standing.Points += league.ByePoints
+			}
+		}
+	}
+
+	standings := make([]models.StandingWithTeam, 0, len(current))
+	for _, standing := range current {
+		standings = append(standings, models.StandingWithTeam{
+			Standing: *computed[standing.TeamID],
+			TeamName: standing.TeamName,
+		})
+	}
+
+	sortStandings(league, standings)
+	return standings, nil
+}
+
+// sortStandings orders a table the same way as the standings query
+func sortStandings(league *models.League, standings []models.StandingWithTeam) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalDifference != b.GoalDifference {
+			return a.GoalDifference > b.GoalDifference
+		}
+		if a.GoalsFor != b.GoalsFor {
+			return a.GoalsFor > b.GoalsFor
+		}
+		return finalTiebreakKey(league, a.TeamID, a.TeamName) < finalTiebreakKey(league, b.TeamID, b.TeamName)
+	})
+}
+
+// WhatIfStandingsHandler handles POST /api/leagues/:leagueID/what-if
+// It replays the league's played matches with hypothetical results swapped in and returns the
+// resulting table. Overrides may also give a result to a scheduled match. Nothing is saved.
+func (lh *LeagueHandler) WhatIfStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "what-if" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.WhatIfRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the league's matches and current table
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	current, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Validate the overrides against the league's matches
+	matchesByID := make(map[int]*models.Match, len(matches))
+	for _, match := range matches {
+		matchesByID[match.ID] = match
+	}
+	if errs := validateWhatIfOverrides(req.Overrides, matchesByID); len(errs) > 0 {
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	// 4. Replay the matches with each override applied to a copy, leaving the stored matches alone
+	overrides := make(map[int]models.WhatIfResult, len(req.Overrides))
+	for _, override := range req.Overrides {
+		overrides[override.MatchID] = override
+	}
+
+	replayed := make([]*models.Match, 0, len(matches))
+	for _, match := range matches {
+		if override, ok := overrides[match.ID]; ok {
+			hypothetical := *match
+			hypothetical.Status = "played"
+			hypothetical.HomeGoals = &override.HomeGoals
+			hypothetical.AwayGoals = &override.AwayGoals
+			match = &hypothetical
+		}
+		replayed = append(replayed, match)
+	}
+
+	teamIDs := make([]int, 0, len(current))
+	for _, standing := range current {
+		teamIDs = append(teamIDs, standing.TeamID)
+	}
+	computed := lh.computeStandingsFromMatches(league.ID, teamIDs, replayed, league.Bonuses())
+
+	// Byes already taken keep their points
+	byePoints, err := lh.byePointsEarned(ctx, league, league.CurrentWeek)
+	if err != nil {
+		log.Printf("Failed to count bye points for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to count bye points", http.StatusInternalServerError)
+		return
+	}
+	for teamID, points := range byePoints {
+		if standing, ok := computed[teamID]; ok {
+			standing.Points += points
+		}
+	}
+
+	standings := make([]models.StandingWithTeam, 0, len(current))
+	for _, standing := range current {
+		standings = append(standings, models.StandingWithTeam{
+			Standing: *computed[standing.TeamID],
+			TeamName: standing.TeamName,
+		})
+	}
+	sortStandings(league, standings)
+
+	resp := models.WhatIfStandingsResponse{
+		League:    models.NewLeagueResponse(league),
+		Overrides: req.Overrides,
+		Standings: assignZones(standings, league.Zones),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// finalTiebreakKey returns the key that orders, ascending, teams level on points, goal difference
+// and goals for under the league's final tiebreak. It matches the standings query's ordering.
+func finalTiebreakKey(league *models.League, teamID int, teamName string) string {
+	switch league.FinalTiebreak {
+	case models.FinalTiebreakTeamID:
+		return fmt.Sprintf("%020d", teamID)
+	case models.FinalTiebreakSeeded:
+		sum := md5.Sum([]byte(fmt.Sprintf("%d:%d", league.TiebreakSeed, teamID)))
+		return hex.EncodeToString(sum[:])
+	default:
+		return teamName
+	}
+}
+
+// CalendarHandler handles GET /api/leagues/:leagueID/calendar.ics
+// It exports the league's fixtures as an iCalendar file that calendar apps can subscribe to.
+func (lh *LeagueHandler) CalendarHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "calendar.ics" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get all matches of the league
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Get team names for the event summaries
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		return
+	}
+
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	writeCalendar(w, league, matches, teamNames)
+}
+
+// ScheduleHandler handles GET /api/leagues/:leagueID/schedule?from=&to=
+// from and to are RFC 3339 timestamps or YYYY-MM-DD dates. from is inclusive and to is exclusive,
+// except that a date-only to covers that whole day.
+func (lh *LeagueHandler) ScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "schedule" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseScheduleTime(r.URL.Query().Get("from"), false)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid from: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseScheduleTime(r.URL.Query().Get("to"), true)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid to: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the matches kicking off in the range
+	matches, err := lh.db.GetMatchesByKickoffRange(ctx, leagueID, from, to)
+	if err != nil {
+		log.Printf("Failed to get scheduled matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league schedule", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Get team names for response
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		return
+	}
+
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	schedule := []models.MatchResult{}
+	for _, match := range matches {
+		result := "Not played yet"
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+
+		schedule = append(schedule, models.MatchResult{
+			Match:    *match,
+			HomeTeam: teamNames[match.HomeTeamID],
+			AwayTeam: teamNames[match.AwayTeamID],
+			Result:   result,
+			Edited:   match.Edited,
+		})
+	}
+
+	// 4. Create response
+	resp := models.ScheduleResponse{
+		League:  models.NewLeagueResponse(league),
+		From:    from,
+		To:      to,
+		Matches: schedule,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// parseScheduleTime parses a schedule range bound given as an RFC 3339 timestamp or a
+// YYYY-MM-DD date (midnight UTC). An end bound given as a date moves to the following
+// midnight so the whole day is included.
+func parseScheduleTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("a date is required")
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp or YYYY-MM-DD date", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// RoundFixturesHandler handles GET /api/leagues/:leagueID/round/:round
+// It returns every fixture scheduled in the round, whatever its status, and the teams with a bye.
+func (lh *LeagueHandler) RoundFixturesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and round from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "round" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	round, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid round: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the round's fixtures
+	matches, err := lh.db.GetMatchesByWeekAndLeague(ctx, leagueID, round)
+	if err != nil {
+		log.Printf("Failed to get matches for league %d round %d: %v", leagueID, round, err)
+		http.Error(w, "Failed to get matches for the round", http.StatusInternalServerError)
+		return
+	}
+
+	if len(matches) == 0 {
+		http.Error(w, fmt.Sprintf("No fixtures scheduled for round %d", round), http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the league's teams; any team without a fixture in the round has a bye
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams in league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get teams in league", http.StatusInternalServerError)
+		return
+	}
+
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	playing := make(map[int]bool)
+	fixtures := []models.MatchResult{}
+	for _, match := range matches {
+		playing[match.HomeTeamID] = true
+		playing[match.AwayTeamID] = true
+
+		result := "Not played yet"
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+
+		fixtures = append(fixtures, models.MatchResult{
+			Match:    *match,
+			HomeTeam: teamNames[match.HomeTeamID],
+			AwayTeam: teamNames[match.AwayTeamID],
+			Result:   result,
+			Edited:   match.Edited,
+		})
+	}
+
+	byes := []models.TeamResponse{}
+	for _, team := range teams {
+		if !playing[team.ID] {
+			byes = append(byes, models.NewTeamResponse(team))
+		}
+	}
+
+	// 4. Create response
+	resp := models.RoundFixturesResponse{
+		League:   models.NewLeagueResponse(league),
+		Round:    round,
+		Fixtures: fixtures,
+		Byes:     byes,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// TeamTrendHandler handles GET /api/leagues/:leagueID/teams/:teamID/trend
+// It replays the team's played matches in week order and returns its cumulative totals after each week.
+func (lh *LeagueHandler) TeamTrendHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "trend" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	for _, t := range teams {
+		if t.ID == teamID {
+			team = t
+			break
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the league's played matches
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Replay the team's matches in week order, recording totals at the end of each week
+	var teamMatches []*models.Match
+	for _, match := range matches {
+		if (match.HomeTeamID == teamID || match.AwayTeamID == teamID) && match.HomeGoals != nil && match.AwayGoals != nil {
+			teamMatches = append(teamMatches, match)
+		}
+	}
+	sort.SliceStable(teamMatches, func(i, j int) bool {
+		if teamMatches[i].Week != teamMatches[j].Week {
+			return teamMatches[i].Week < teamMatches[j].Week
+		}
+		return teamMatches[i].ID < teamMatches[j].ID
+	})
+
+	standings := map[int]*models.Standing{teamID: {LeagueID: leagueID, TeamID: teamID}}
+	trend := []models.TeamTrendWeek{}
+	for i, match := range teamMatches {
+		opponentID := match.AwayTeamID
+		if opponentID == teamID {
+			opponentID = match.HomeTeamID
+		}
+		standings[opponentID] = &models.Standing{} // Only the team's own totals are reported
+
+		lh.updateStandingsInMemory(standings, match.HomeTeamID, match.AwayTeamID, *match.HomeGoals, *match.AwayGoals, league.Bonuses())
+
+		// Record the totals once the team's last match of the week has been replayed
+		if i == len(teamMatches)-1 || teamMatches[i+1].Week != match.Week {
+			totals := standings[teamID]
+			trend = append(trend, models.TeamTrendWeek{
+				Week:           match.Week,
+				Played:         totals.Played,
+				Points:         totals.Points,
+				GoalsFor:       totals.GoalsFor,
+				GoalsAgainst:   totals.GoalsAgainst,
+				GoalDifference: totals.GoalDifference,
+			})
+		}
+	}
+
+	// 5. Create response
+	resp := models.TeamTrendResponse{
+		League: models.NewLeagueResponse(league),
+		Team:   models.NewTeamResponse(team),
+		Weeks:  trend,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// VenueBalanceHandler handles GET /api/leagues/:leagueID/teams/:teamID/venue-balance
+// It counts the team's scheduled and played fixtures at home and away and flags an uneven split.
+func (lh *LeagueHandler) VenueBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "venue-balance" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	for _, t := range teams {
+		if t.ID == teamID {
+			team = t
+			break
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Count the team's fixtures by venue
+	home, away, err := lh.db.GetVenueCounts(ctx, leagueID, teamID)
+	if err != nil {
+		log.Printf("Failed to count fixtures by venue for team %d in league %d: %v", teamID, leagueID, err)
+		http.Error(w, "Failed to get venue balance", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.VenueBalanceResponse{
+		League:       models.NewLeagueResponse(league),
+		Team:         models.NewTeamResponse(team),
+		HomeFixtures: home,
+		AwayFixtures: away,
+		Uneven:       home-away > 1 || away-home > 1,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// RemainingFixturesHandler handles GET /api/leagues/:leagueID/teams/:teamID/remaining
+// It lists the team's scheduled matches in week order with the opponent and venue of each.
+func (lh *LeagueHandler) RemainingFixturesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "remaining" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	teamNames := make(map[int]string, len(teams))
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+		if t.ID == teamID {
+			team = t
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the league's scheduled matches, which come in week order
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "scheduled")
+	if err != nil {
+		log.Printf("Failed to get scheduled matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Keep the team's matches
+	fixtures := teamFixtures(matches, teamID, teamNames)
+
+	resp := models.RemainingFixturesResponse{
+		League:   models.NewLeagueResponse(league),
+		Team:     models.NewTeamResponse(team),
+		Fixtures: fixtures,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// teamFixtures picks out a team's matches, in the order given, with the opponent and venue of each
+func teamFixtures(matches []*models.Match, teamID int, teamNames map[int]string) []models.RemainingFixture {
+	fixtures := []models.RemainingFixture{}
+	for _, match := range matches {
+		fixture := models.RemainingFixture{
+			MatchID:     match.ID,
+			Week:        match.Week,
+			KickoffTime: match.KickoffTime,
+		}
+		switch teamID {
+		case match.HomeTeamID:
+			fixture.Venue = "home"
+			fixture.OpponentID = match.AwayTeamID
+		case match.AwayTeamID:
+			fixture.Venue = "away"
+			fixture.OpponentID = match.HomeTeamID
+		default:
+			continue
+		}
+		fixture.OpponentName = teamNames[fixture.OpponentID]
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures
+}
+
+// NextFixtureHandler handles GET /api/leagues/:leagueID/teams/:teamID/next
+// It returns the team's first scheduled match after the league's current week, with the opponent and venue.
+func (lh *LeagueHandler) NextFixtureHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "next" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	teamNames := make(map[int]string, len(teams))
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+		if t.ID == teamID {
+			team = t
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the league's scheduled matches, which come in week order
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "scheduled")
+	if err != nil {
+		log.Printf("Failed to get scheduled matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Take the team's first match after the current week
+	for _, fixture := range teamFixtures(matches, teamID, teamNames) {
+		if fixture.Week <= league.CurrentWeek {
+			continue
+		}
+		resp := models.NextFixtureResponse{
+			League:  models.NewLeagueResponse(league),
+			Team:    models.NewTeamResponse(team),
+			Fixture: fixture,
+		}
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	http.Error(w, "Team has no remaining fixtures", http.StatusNotFound)
+}
+
+// TeamMatchesByVenueHandler handles GET /api/leagues/:leagueID/teams/:teamID/by-venue
+// It returns every match of the team in the league, played or not, split into home and away lists.
+func (lh *LeagueHandler) TeamMatchesByVenueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "by-venue" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	teamNames := make(map[int]string, len(teams))
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+		if t.ID == teamID {
+			team = t
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get all the league's matches, which come in week order
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Split the team's matches by venue
+	resp := models.TeamMatchesByVenueResponse{
+		League: models.NewLeagueResponse(league),
+		Team:   models.NewTeamResponse(team),
+		Home:   []models.MatchResult{},
+		Away:   []models.MatchResult{},
+	}
+	for _, match := range matches {
+		if match.HomeTeamID != teamID && match.AwayTeamID != teamID {
+			continue
+		}
+
+		result := "Not played yet"
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			result = fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals)
+		}
+
+		matchResult := models.MatchResult{
+			Match:    *match,
+			HomeTeam: teamNames[match.HomeTeamID],
+			AwayTeam: teamNames[match.AwayTeamID],
+			Result:   result,
+			Edited:   match.Edited,
+		}
+		if match.HomeTeamID == teamID {
+			resp.Home = append(resp.Home, matchResult)
+		} else {
+			resp.Away = append(resp.Away, matchResult)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// TeamPositionHandler handles GET /api/leagues/:leagueID/teams/:teamID/position
+// It returns the team's place in the standings with its gap to the leader and to the relegation zone.
+func (lh *LeagueHandler) TeamPositionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "position" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	for _, t := range teams {
+		if t.ID == teamID {
+			team = t
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the standings, already ordered by the league's tiebreak rules
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Locate the team and measure it against the leader and the relegation line
+	rows := assignZones(standings, league.Zones)
+	for _, row := range rows {
+		if row.TeamID != teamID {
+			continue
+		}
+
+		resp := models.TeamPositionResponse{
+			League:             models.NewLeagueResponse(league),
+			Team:               models.NewTeamResponse(team),
+			Position:           row.Position,
+			Zone:               row.Zone,
+			Points:             row.Points,
+			PointsBehindLeader: rows[0].Points - row.Points,
+		}
+		if spots := league.Zones.RelegationSpots; spots > 0 && spots <= len(rows) {
+			gap := row.Points - rows[len(rows)-spots].Points
+			resp.PointsAboveRelegation = &gap
+		}
+
+		writeJSON(w, r, http.StatusOK, resp)
+		return
+	}
+
+	log.Printf("Team %d is in league %d but has no standing", teamID, leagueID)
+	http.Error(w, "Team has no standing in this league", http.StatusInternalServerError)
+}
+
+// HomeAwaySplitHandler handles GET /api/leagues/:leagueID/home-away-split
+// It totals results and goals by venue over the league's played matches to show how strong home advantage is.
+func (lh *LeagueHandler) HomeAwaySplitHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "home-away-split" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Total the played matches by venue
+	split, err := lh.db.GetHomeAwaySplit(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get home and away split for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get home and away split", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Home win percentage, rounded to one decimal place
+	homeWinPercent := 0.0
+	if split.MatchesPlayed > 0 {
+		homeWinPercent = math.Round(float64(split.HomeWins)*1000/float64(split.MatchesPlayed)) / 10
+	}
+
+	resp := models.HomeAwaySplitResponse{
+		League:         models.NewLeagueResponse(league),
+		HomeAwaySplit:  *split,
+		HomeWinPercent: homeWinPercent,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// StrengthOfScheduleHandler handles GET /api/leagues/:leagueID/strength-of-schedule
+// It averages the strength of each team's opponents over its scheduled matches, hardest run-in first.
+func (lh *LeagueHandler) StrengthOfScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "strength-of-schedule" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the league's teams and their strengths
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	strengths := make(map[int]int, len(teams))
+	for _, team := range teams {
+		strengths[team.ID] = team.Strength
+	}
+
+	// 3. Get the league's scheduled matches
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "scheduled")
+	if err != nil {
+		log.Printf("Failed to get scheduled matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Sum each team's opponent strengths; opponents no longer in the league are skipped
+	opponentStrength := make(map[int]int, len(teams))
+	remaining := make(map[int]int, len(teams))
+	for _, match := range matches {
+		homeStrength, homeOK := strengths[match.HomeTeamID]
+		awayStrength, awayOK := strengths[match.AwayTeamID]
+		if homeOK && awayOK {
+			opponentStrength[match.HomeTeamID] += awayStrength
+			remaining[match.HomeTeamID]++
+			opponentStrength[match.AwayTeamID] += homeStrength
+			remaining[match.AwayTeamID]++
+		}
+	}
+
+	// 5. Average per team, rounded to two decimal places, hardest run-in first
+	schedules := make([]models.TeamStrengthOfSchedule, 0, len(teams))
+	for _, team := range teams {
+		schedule := models.TeamStrengthOfSchedule{
+			TeamID:           team.ID,
+			TeamName:         team.Name,
+			RemainingMatches: remaining[team.ID],
+		}
+		if schedule.RemainingMatches > 0 {
+			schedule.AverageOpponentStrength = math.Round(float64(opponentStrength[team.ID])*100/float64(schedule.RemainingMatches)) / 100
+		}
+		schedules = append(schedules, schedule)
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		if schedules[i].AverageOpponentStrength != schedules[j].AverageOpponentStrength {
+			return schedules[i].AverageOpponentStrength > schedules[j].AverageOpponentStrength
+		}
+		return schedules[i].TeamName < schedules[j].TeamName
+	})
+
+	resp := models.StrengthOfScheduleResponse{
+		League: models.NewLeagueResponse(league),
+		Teams:  schedules,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// SetTeamGroupHandler handles PUT /api/leagues/:leagueID/teams/:teamID/group
+// It assigns a team to a group before the league starts; each group plays its own round-robin.
+func (lh *LeagueHandler) SetTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID and teamID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "teams" || pathParts[5] != "group" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetTeamGroupRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	group := strings.TrimSpace(req.Group)
+	if len(group) > models.MaxGroupNameLength {
+		var errs validationErrors
+		errs.add("group", fmt.Sprintf("Group must be at most %d characters", models.MaxGroupNameLength))
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Groups decide the schedule, so they are fixed once the league has started
+	if league.Status != "created" {
+		http.Error(w, fmt.Sprintf("Groups can only be changed before the league starts. Current status: %s", league.Status), http.StatusConflict)
+		return
+	}
+
+	// 3. Validate the team is part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team *models.Team
+	for _, t := range teams {
+		if t.ID == teamID {
+			team = t
+			break
+		}
+	}
+	if team == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 4. Save the group
+	if err := lh.db.SetTeamGroup(ctx, leagueID, teamID, group); err != nil {
+		log.Printf("Failed to set group of team %d in league %d: %v", teamID, leagueID, err)
+		http.Error(w, "Failed to set team group", http.StatusInternalServerError)
+		return
+	}
+
+	message := fmt.Sprintf("Team '%s' assigned to group '%s' in league '%s'", team.Name, group, league.Name)
+	if group == "" {
+		message = fmt.Sprintf("Team '%s' removed from its group in league '%s'", team.Name, league.Name)
+	}
+
+	resp := models.TeamGroupResponse{
+		League:  models.NewLeagueResponse(league),
+		Team:    models.NewTeamResponse(team),
+		Group:   group,
+		Message: message,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// GroupStandingsHandler handles GET /api/leagues/:leagueID/groups
+// It returns a separate standings table for each group, ordered by group name.
+func (lh *LeagueHandler) GroupStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "groups" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Get the league's groups and its full standings table
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := lh.leagueGroups(ctx, leagueID, teams)
+	if err != nil {
+		log.Printf("Failed to get groups for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league groups", http.StatusInternalServerError)
+		return
+	}
+
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Split the table by group, keeping the standings order, and label zones within each group
+	groupOf := make(map[int]string, len(teams))
+	for _, group := range groups {
+		for _, team := range group.teams {
+			groupOf[team.ID] = group.name
+		}
+	}
+
+	byGroup := make(map[string][]models.StandingWithTeam, len(groups))
+	for _, standing := range standings {
+		name := groupOf[standing.TeamID]
+		byGroup[name] = append(byGroup[name], standing)
+	}
+
+	resp := models.GroupStandingsResponse{
+		League: models.NewLeagueResponse(league),
+		Groups: make([]models.GroupStandings, 0, len(groups)),
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, models.GroupStandings{
+			Group:     group.name,
+			Standings: assignZones(byGroup[group.name], league.Zones),
+		})
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// assignZones numbers ordered standings and labels each position with its zone
+func assignZones(standings []models.StandingWithTeam, zones models.LeagueZones) []models.StandingRow {
+	rows := make([]models.StandingRow, 0, len(standings))
+	for i, standing := range standings {
+		position := i + 1
+
+		zone := "mid-table"
+		switch {
+		case position <= zones.ChampionSpots:
+			zone = "champion"
+		case position <= zones.ChampionSpots+zones.PromotionSpots:
+			zone = "promotion"
+		case position > len(standings)-zones.RelegationSpots:
+			zone = "relegation"
+		}
+
+		rows = append(rows, models.StandingRow{
+			Position:         position,
+			Zone:             zone,
+			StandingWithTeam: standing,
+		})
+	}
+	return rows
+}
+
+// VerifyStandingsHandler handles GET /api/leagues/:leagueID/verify
+func (lh *LeagueHandler) VerifyStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "verify" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Load the stored standings and the played matches
+	stored, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Recompute the expected standings and compare field by field
+	teamIDs := make([]int, 0, len(stored))
+	storedByTeam := make(map[int]models.StandingWithTeam, len(stored))
+	for _, standing := range stored {
+		teamIDs = append(teamIDs, standing.TeamID)
+		storedByTeam[standing.TeamID] = standing
+	}
+	expected := lh.computeStandingsFromMatches(leagueID, teamIDs, matches, league.Bonuses())
+
+	// Byes in the weeks played so far earn points without a match
+	byePoints, err := lh.byePointsEarned(ctx, league, league.CurrentWeek)
+	if err != nil {
+		log.Printf("Failed to count bye points for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to count bye points", http.StatusInternalServerError)
+		return
+	}
+	for teamID, points := range byePoints {
+		if standing, ok := expected[teamID]; ok {
+			standing.Points += points
+		}
+	}
+
+	discrepancies := []models.StandingDiscrepancy{}
+	for _, standing := range stored {
+		discrepancies = append(discrepancies, compareStandings(standing.TeamName, standing.Standing, *expected[standing.TeamID])...)
+	}
+
+	// Teams with played matches but no stored standings row
+	for teamID, standing := range expected {
+		if _, ok := storedByTeam[teamID]; ok || standing.Played == 0 {
+			continue
+		}
+		teamName := ""
+		if team, err := lh.db.GetTeamByID(ctx, teamID); err == nil {
+			teamName = team.Name
+		}
+		discrepancies = append(discrepancies, compareStandings(teamName, models.Standing{LeagueID: leagueID, TeamID: teamID}, *standing)...)
+	}
+
+	message := fmt.Sprintf("Standings for league '%s' match %d played matches", league.Name, len(matches))
+	if len(discrepancies) > 0 {
+		message = fmt.Sprintf("Found %d discrepancies in standings for league '%s'", len(discrepancies), league.Name)
+	}
+
+	resp := models.VerifyStandingsResponse{
+		League:         models.NewLeagueResponse(league),
+		Consistent:     len(discrepancies) == 0,
+		MatchesChecked: len(matches),
+		Discrepancies:  discrepancies,
+		Message:        message,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// compareStandings lists the fields where a stored standing differs from the expected one
+func compareStandings(teamName string, stored, expected models.Standing) []models.StandingDiscrepancy {
+	fields := []struct {
+		name             string
+		stored, expected int
+	}{
+		{"points", stored.Points, expected.Points},
+		{"played", stored.Played, expected.Played},
+		{"wins", stored.Wins, expected.Wins},
+		{"draws", stored.Draws, expected.Draws},
+		{"losses", stored.Losses, expected.Losses},
+		{"goals_for", stored.GoalsFor, expected.GoalsFor},
+		{"goals_against", stored.GoalsAgainst, expected.GoalsAgainst},
+		{"goal_difference", stored.GoalDifference, expected.GoalDifference},
+	}
+
+	var discrepancies []models.StandingDiscrepancy
+	for _, field := range fields {
+		if field.stored != field.expected {
+			discrepancies = append(discrepancies, models.StandingDiscrepancy{
+				TeamID:   stored.TeamID,
+				TeamName: teamName,
+				Field:    field.name,
+				Stored:   field.stored,
+				Expected: field.expected,
+			})
+		}
+	}
+	return discrepancies
+}
+
+// BottomTeamHandler handles GET /api/leagues/bottom/:leagueID
+func (lh *LeagueHandler) BottomTeamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "bottom" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[3])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists and get its current state
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if league.Status != "started" && league.Status != "paused" && league.Status != "finished" {
+		http.Error(w, fmt.Sprintf("League must be 'started', 'paused' or 'finished' to have a bottom team. Current status: %s", league.Status), http.StatusBadRequest)
+		return
+	}
+
+	// 2. Get current standings; the last row is the bottom team under the standings tiebreak order
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	if len(standings) == 0 {
+		http.Error(w, "League has no standings", http.StatusBadRequest)
+		return
+	}
+
+	bottom := standings[len(standings)-1]
+
+	// 3. Count the bottom team's remaining matches
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	remaining := 0
+	scheduled := 0
+	for _, match := range matches {
+		if match.Status != "scheduled" {
+			continue
+		}
+		scheduled++
+		if match.HomeTeamID == bottom.TeamID || match.AwayTeamID == bottom.TeamID {
+			remaining++
+		}
+	}
+
+	// 4. The most points the team could still reach: every remaining match won with every bonus,
+	// plus the bye points of the weeks it still sits out
+	totalWeeks, err := lh.leagueTotalWeeks(ctx, league)
+	if err != nil {
+		log.Printf("Failed to get total weeks for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league schedule", http.StatusInternalServerError)
+		return
+	}
+	byes, err := lh.pointScoringByes(ctx, league, matches, totalWeeks)
+	if err != nil {
+		log.Printf("Failed to get byes for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league byes", http.StatusInternalServerError)
+		return
+	}
+
+	maxPoints := bottom.Points + maxMatchPoints(league)*remaining
+	for week := league.CurrentWeek + 1; week <= totalWeeks; week++ {
+		for _, teamID := range byes[week] {
+			if teamID == bottom.TeamID {
+				maxPoints += league.ByePoints
+			}
+		}
+	}
+
+	// 5. Relegation is confirmed once the league is finished or no match is left to play, or when
+	// the team can't even draw level on points with the team directly above. Level on points isn't
+	// enough while others still play, since their results can change the tiebreak.
+	confirmed := league.Status == "finished" || scheduled == 0
+	if !confirmed && len(standings) > 1 {
+		confirmed = maxPoints < standings[len(standings)-2].Points
+	}
+
+	resp := models.BottomTeamResponse{
+		League:              models.NewLeagueResponse(league),
+		Team:                bottom,
+		Position:            len(standings),
+		RemainingMatches:    remaining,
+		MaxPossiblePoints:   maxPoints,
+		RelegationConfirmed: confirmed,
+		Message:             fmt.Sprintf("%s is bottom of league '%s' after week %d.", bottom.TeamName, league.Name, league.CurrentWeek),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// TitleDecidedHandler handles GET /api/leagues/:leagueID/title-decided
+// For a finished league it replays the table week by week to find when the champion became uncatchable.
+func (lh *LeagueHandler) TitleDecidedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "title-decided" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists and is finished
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if league.Status != "finished" {
+		http.Error(w, fmt.Sprintf("League must be 'finished' to tell when its title was decided. Current status: %s", league.Status), http.StatusBadRequest)
+		return
+	}
+
+	// 2. The champion tops the final standings
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+	if len(standings) == 0 {
+		http.Error(w, "League has no standings", http.StatusBadRequest)
+		return
+	}
+	champion := standings[0]
+
+	// 3. Get every match, including those cancelled, which were still to play when scheduled
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Work out which teams sat out each week, when byes earn points
+	byes, err := lh.pointScoringByes(ctx, league, matches, league.CurrentWeek)
+	if err != nil {
+		log.Printf("Failed to get byes for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league byes", http.StatusInternalServerError)
+		return
+	}
+
+	// 5. Replay the season to find the first week the champion could no longer be caught
+	teamIDs := make([]int, 0, len(standings))
+	for _, standing := range standings {
+		teamIDs = append(teamIDs, standing.TeamID)
+	}
+	decidedWeek, lead := lh.titleDecidedWeek(league, champion.TeamID, teamIDs, matches, byes)
+
+	resp := models.TitleDecidedResponse{
+		League:       models.NewLeagueResponse(league),
+		Champion:     champion,
+		DecidedWeek:  decidedWeek,
+		FinalWeek:    league.CurrentWeek,
+		WeeksToSpare: league.CurrentWeek - decidedWeek,
+		Lead:         lead,
+		Message:      fmt.Sprintf("%s won league '%s' with %d weeks to spare, after week %d.", champion.TeamName, league.Name, league.CurrentWeek-decidedWeek, decidedWeek),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// maxMatchPoints returns the most points a team can earn from one match: a win by a big margin
+// with a clean sheet
+func maxMatchPoints(league *models.League) int {
+	bonus, _ := league.Bonuses().Points(max(league.BigWinMargin, 1), 0)
+	return 3 + bonus
+}
+
+// titleDecidedWeek returns the first week after which every other team, even winning all its
+// remaining matches by a big margin with a clean sheet and collecting its remaining bye points,
+// would finish below the champion on points, and the champion's lead at that point. A title
+// settled only by tiebreaks is decided in the final week. byes lists the teams on a bye each week.
+func (lh *LeagueHandler) titleDecidedWeek(league *models.League, championID int, teamIDs []int, matches []*models.Match, byes map[int][]int) (int, int) {
+	finalWeek := league.CurrentWeek
+	matchPoints := maxMatchPoints(league)
+
+	for week := 1; week <= finalWeek; week++ {
+		// The table after this week, with the bye points earned so far
+		var played []*models.Match
+		for _, match := range matches {
+			if match.Week <= week {
+				played = append(played, match)
+			}
+		}
+		table := lh.computeStandingsFromMatches(league.ID, teamIDs, played, league.Bonuses())
+		for byeWeek := 1; byeWeek <= week; byeWeek++ {
+			for _, teamID := range byes[byeWeek] {
+				if standing, ok := table[teamID]; ok {
+					standing.Points += league.ByePoints
+				}
+			}
+		}
+
+		// The most points each team could still add
+		potential := make(map[int]int, len(teamIDs))
+		for _, match := range matches {
+			if match.Week > week && match.Week <= finalWeek {
+				potential[match.HomeTeamID] += matchPoints
+				potential[match.AwayTeamID] += matchPoints
+			}
+		}
+		for byeWeek := week + 1; byeWeek <= finalWeek; byeWeek++ {
+			for _, teamID := range byes[byeWeek] {
+				potential[teamID] += league.ByePoints
+			}
+		}
+
+		championPoints := table[championID].Points
+		decided := true
+		lead := -1
+		for _, teamID := range teamIDs {
+			if teamID == championID {
+				continue
+			}
+			if table[teamID].Points+potential[teamID] >= championPoints {
+				decided = false
+				break
+			}
+			if gap := championPoints - table[teamID].Points; lead < 0 || gap < lead {
+				lead = gap
+			}
+		}
+		if decided {
+			return week, max(lead, 0)
+		}
+	}
+
+	// Level on points with a challenger at the end, so the tiebreaks settled it on the final day
+	return finalWeek, 0
+}
+
+// replayMaxWeeks caps the number of weeks in a season replay
+const replayMaxWeeks = 100
+
+// LeagueReplayHandler handles GET /api/leagues/:leagueID/replay
+// It returns the league's season week by week: each played week's results and the standings after
+// them, up to replayMaxWeeks weeks. The table is built up one week at a time from the played matches.
+func (lh *LeagueHandler) LeagueReplayHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "replay" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. The current standings give the teams in the table and their names
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league standings", http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "")
+	if err != nil {
+		log.Printf("Failed to get matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		return
+	}
+
+	// Future fixtures settled early, such as forfeits, are played in weeks past the current one,
+	// so the replay runs to the later of the current week and the last week with a played match.
+	// Byes are only awarded for weeks the league has advanced through.
+	lastWeek := league.CurrentWeek
+	for _, match := range matches {
+		if match.Status == "played" {
+			lastWeek = max(lastWeek, match.Week)
+		}
+	}
+	weeks := min(lastWeek, replayMaxWeeks)
+	byes, err := lh.pointScoringByes(ctx, league, matches, min(league.CurrentWeek, weeks))
+	if err != nil {
+		log.Printf("Failed to get byes for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league byes", http.StatusInternalServerError)
+		return
+	}
+
+	teamIDs := make([]int, 0, len(standings))
+	teamNames := make(map[int]string, len(standings))
+	for _, standing := range standings {
+		teamIDs = append(teamIDs, standing.TeamID)
+		teamNames[standing.TeamID] = standing.TeamName
+	}
+
+	playedByWeek := make(map[int][]*models.Match)
+	for _, match := range matches {
+		if match.Status == "played" && match.HomeGoals != nil && match.AwayGoals != nil {
+			playedByWeek[match.Week] = append(playedByWeek[match.Week], match)
+		}
+	}
+
+	// 3. Play the season back a week at a time, adding each week's results to the running table
+	table := lh.computeStandingsFromMatches(leagueID, teamIDs, nil, league.Bonuses())
+	resp := models.LeagueReplayResponse{
+		League:    models.NewLeagueResponse(league),
+		Weeks:     make([]models.ReplayWeek, 0, weeks),
+		Truncated: lastWeek > replayMaxWeeks,
+	}
+	for week := 1; week <= weeks; week++ {
+		weekMatches := playedByWeek[week]
+		sort.Slice(weekMatches, func(i, j int) bool {
+			return weekMatches[i].ID < weekMatches[j].ID
+		})
+
+		replayWeek := models.ReplayWeek{Week: week, Results: make([]models.MatchResult, 0, len(weekMatches))}
+		for _, match := range weekMatches {
+			// Matches against teams no longer in the league still count for the remaining side
+			for _, teamID := range []int{match.HomeTeamID, match.AwayTeamID} {
+				if _, ok := table[teamID]; !ok {
+					table[teamID] = &models.Standing{LeagueID: leagueID, TeamID: teamID}
+				}
+			}
+			lh.updateStandingsInMemory(table, match.HomeTeamID, match.AwayTeamID, *match.HomeGoals, *match.AwayGoals, league.Bonuses())
+
+			replayWeek.Results = append(replayWeek.Results, models.MatchResult{
+				Match:    *match,
+				HomeTeam: teamNames[match.HomeTeamID],
+				AwayTeam: teamNames[match.AwayTeamID],
+				Result:   fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals),
+				Edited:   match.Edited,
+			})
+		}
+		for _, teamID := range byes[week] {
+			if standing, ok := table[teamID]; ok {
+				standing.Points += league.ByePoints
+			}
+		}
+
+		replayWeek.Standings = make([]models.StandingWithTeam, 0, len(teamIDs))
+		for _, teamID := range teamIDs {
+			replayWeek.Standings = append(replayWeek.Standings, models.StandingWithTeam{
+				Standing: *table[teamID],
+				TeamName: teamNames[teamID],
+			})
+		}
+		sortStandings(league, replayWeek.Standings)
+		resp.Weeks = append(resp.Weeks, replayWeek)
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// getActualChampion returns 100% probability for the actual champion when league is finished.
+// Co-champions share the title, so the probability is split evenly between them.
+func (lh *LeagueHandler) getActualChampion(standings []models.StandingWithTeam, champions []models.StandingWithTeam) []models.ChampionProbability {
+	var championProbabilities []models.ChampionProbability
+
+	for _, standing := range standings {
+		probability := 0.0
+		for _, champion := range champions {
+			if champion.TeamID == standing.TeamID {
+				probability = 100.0 / float64(len(champions))
+			}
+		}
+
+		championProbabilities = append(championProbabilities, models.ChampionProbability{
+			TeamID:      standing.TeamID,
+			TeamName:    standing.TeamName,
+			Probability: probability,
+		})
+	}
+
+	return championProbabilities
+}
+
+// titleWinners returns the champion of a finished league, or every co-champion when the top teams
+// are level on points, goal difference and goals for, and their meetings with each other don't
+// separate them either (head-to-head points, then head-to-head goal difference).
+func (lh *LeagueHandler) titleWinners(standings []models.StandingWithTeam, matches []*models.Match) []models.StandingWithTeam {
+	if len(standings) == 0 {
+		return nil
+	}
+
+	// Teams level with the leader on every table tiebreak
+	leader := standings[0]
+	tied := make(map[int]bool)
+	for _, standing := range standings {
+		if standing.Points == leader.Points && standing.GoalDifference == leader.GoalDifference && standing.GoalsFor == leader.GoalsFor {
+			tied[standing.TeamID] = true
+		}
+	}
+	if len(tied) == 1 {
+		return []models.StandingWithTeam{leader}
+	}
+
+	// Head-to-head mini table between the tied teams
+	headToHead := make(map[int]*models.Standing, len(tied))
+	for teamID := range tied {
+		headToHead[teamID] = &models.Standing{TeamID: teamID}
+	}
+	for _, match := range matches {
+		if match.Status != "played" || match.HomeGoals == nil || match.AwayGoals == nil || !tied[match.HomeTeamID] || !tied[match.AwayTeamID] {
+			continue
+		}
+		lh.updateStandingsInMemory(headToHead, match.HomeTeamID, match.AwayTeamID, *match.HomeGoals, *match.AwayGoals, models.MatchBonuses{})
+	}
+
+	var best *models.Standing
+	for _, standing := range headToHead {
+		if best == nil || standing.Points > best.Points || (standing.Points == best.Points && standing.GoalDifference > best.GoalDifference) {
+			best = standing
+		}
+	}
+
+	// Keep the table order among the winners
+	var champions []models.StandingWithTeam
+	for _, standing := range standings {
+		record, ok := headToHead[standing.TeamID]
+		if ok && record.Points == best.Points && record.GoalDifference == best.GoalDifference {
+			champions = append(champions, standing)
+		}
+	}
+	return champions
+}
+
+// simulateRestOfSeason simulates all remaining matches and returns the champion team ID
+func (lh *LeagueHandler) simulateRestOfSeason(currentStandings []models.StandingWithTeam, remainingMatches []*models.Match, teams []*models.Team, settings simulationSettings) int {
+	// Create a copy of current standings for simulation
+	standings := make(map[int]*models.Standing)
+	for _, s := range currentStandings {
+		standings[s.TeamID] = &models.Standing{
+			TeamID:         s.TeamID,
+			Points:         s.Points,
+			Played:         s.Played,
+			Wins:           s.Wins,
+			Draws:          s.Draws,
+			Losses:         s.Losses,
+			GoalsFor:       s.GoalsFor,
+			GoalsAgainst:   s.GoalsAgainst,
+			GoalDifference: s.GoalDifference,
+		}
+	}
+
+	// Get team strengths for simulation
+	teamStrengths := make(map[int]int)
+	for _, team := range teams {
+		teamStrengths[team.ID] = team.Strength
+	}
+
+	// Simulate all remaining matches
+	for _, match := range remainingMatches {
+		homeStrength := teamStrengths[match.HomeTeamID]
+		awayStrength := teamStrengths[match.AwayTeamID]
+
+		homeGoals, awayGoals := lh.simulateMatch(homeStrength, awayStrength, settings)
+
+		// Update standings based on match result
+		lh.updateStandingsInMemory(standings, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals, settings.bonuses)
+	}
+
+	// Find champion (team with most points, then best goal difference)
+	var championID int
 	var bestPoints int = -1
 	var bestGoalDiff int = -999
 
-	for teamID, standing := range standings {
-		if standing.Points > bestPoints ||
-			(standing.Points == bestPoints && standing.GoalDifference > bestGoalDiff) {
-			bestPoints = standing.Points
-			bestGoalDiff = standing.GoalDifference
-			championID = teamID
+	for teamID, standing := range standings {
+		if standing.Points > bestPoints ||
+			(standing.Points == bestPoints && standing.GoalDifference > bestGoalDiff) {
+			bestPoints = standing.Points
+			bestGoalDiff = standing.GoalDifference
+			championID = teamID
+		}
+	}
+
+	return championID
+}
+
+// computeStandingsFromMatches rebuilds a league's standings from scratch using its played matches
+func (lh *LeagueHandler) computeStandingsFromMatches(leagueID int, teamIDs []int, matches []*models.Match, bonuses models.MatchBonuses) map[int]*models.Standing {
+	standings := make(map[int]*models.Standing, len(teamIDs))
+	for _, teamID := range teamIDs {
+		standings[teamID] = &models.Standing{LeagueID: leagueID, TeamID: teamID}
+	}
+
+	for _, match := range matches {
+		if match.Status != "played" || match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		// Matches against teams no longer in the league still count for the remaining side
+		for _, teamID := range []int{match.HomeTeamID, match.AwayTeamID} {
+			if _, ok := standings[teamID]; !ok {
+				standings[teamID] = &models.Standing{LeagueID: leagueID, TeamID: teamID}
+			}
+		}
+		lh.updateStandingsInMemory(standings, match.HomeTeamID, match.AwayTeamID, *match.HomeGoals, *match.AwayGoals, bonuses)
+	}
+
+	return standings
+}
+
+// updateStandingsInMemory updates standings in memory for simulation
+func (lh *LeagueHandler) updateStandingsInMemory(standings map[int]*models.Standing, homeTeamID, awayTeamID, homeGoals, awayGoals int, bonuses models.MatchBonuses) {
+	homeStanding := standings[homeTeamID]
+	awayStanding := standings[awayTeamID]
+
+	// Update matches played
+	homeStanding.Played++
+	awayStanding.Played++
+
+	// Update goals
+	homeStanding.GoalsFor += homeGoals
+	homeStanding.GoalsAgainst += awayGoals
+	awayStanding.GoalsFor += awayGoals
+	awayStanding.GoalsAgainst += homeGoals
+
+	// Update goal difference
+	homeStanding.GoalDifference = homeStanding.GoalsFor - homeStanding.GoalsAgainst
+	awayStanding.GoalDifference = awayStanding.GoalsFor - awayStanding.GoalsAgainst
+
+	// Update wins/draws/losses and points
+	if homeGoals > awayGoals {
+		// Home team wins
+		homeStanding.Wins++
+		homeStanding.Points += 3
+		awayStanding.Losses++
+	} else if homeGoals < awayGoals {
+		// Away team wins
+		awayStanding.Wins++
+		awayStanding.Points += 3
+		homeStanding.Losses++
+	} else {
+		// Draw
+		homeStanding.Draws++
+		homeStanding.Points += 1
+		awayStanding.Draws++
+		awayStanding.Points += 1
+	}
+
+	// Clean sheet and big win bonuses
+	homeBonus, awayBonus := bonuses.Points(homeGoals, awayGoals)
+	homeStanding.Points += homeBonus
+	awayStanding.Points += awayBonus
+}
+
+// EditMatchHandler handles POST /api/leagues/edit-match/:matchID
+func (lh *LeagueHandler) EditMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract matchID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "edit-match" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	matchID, err := parsePathID(pathParts[3])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid match ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var req models.EditMatchRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	// Validate input
+	if req.HomeGoals < 0 || req.AwayGoals < 0 {
+		http.Error(w, "Goals cannot be negative", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// Get the original match to show previous result
+	originalMatch, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get match by ID %d: %v", matchID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Check if match has been played and has results
+	if originalMatch.Status != "played" {
+		http.Error(w, fmt.Sprintf("Can only edit played matches. Current status: %s", originalMatch.Status), http.StatusBadRequest)
+		return
+	}
+
+	if originalMatch.HomeGoals == nil || originalMatch.AwayGoals == nil {
+		http.Error(w, "Match has no existing result to edit", http.StatusBadRequest)
+		return
+	}
+
+	// Store previous result for response
+	previousResult := fmt.Sprintf("%d-%d", *originalMatch.HomeGoals, *originalMatch.AwayGoals)
+	newResult := fmt.Sprintf("%d-%d", req.HomeGoals, req.AwayGoals)
+
+	// Check if the new result is actually different
+	if *originalMatch.HomeGoals == req.HomeGoals && *originalMatch.AwayGoals == req.AwayGoals {
+		http.Error(w, "New result is the same as the current result", http.StatusBadRequest)
+		return
+	}
+
+	// Update the match result and standings
+	err = lh.db.EditMatch(ctx, matchID, req.HomeGoals, req.AwayGoals)
+	if err != nil {
+		log.Printf("Failed to edit match %d: %v", matchID, err)
+		http.Error(w, "Failed to edit match result", http.StatusInternalServerError)
+		return
+	}
+
+	lh.recordEvent(ctx, originalMatch.LeagueID, models.LeagueEventMatchEdited, fmt.Sprintf("Match %d (week %d) edited from %s to %s", matchID, originalMatch.Week, previousResult, newResult))
+
+	// Get the updated match for response
+	updatedMatch, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		log.Printf("Failed to get updated match %d: %v", matchID, err)
+		http.Error(w, "Failed to retrieve updated match", http.StatusInternalServerError)
+		return
+	}
+
+	// Get team names for the response
+	homeTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.HomeTeamID)
+	if err != nil {
+		log.Printf("Failed to get home team %d: %v", updatedMatch.HomeTeamID, err)
+		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		return
+	}
+
+	awayTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.AwayTeamID)
+	if err != nil {
+		log.Printf("Failed to get away team %d: %v", updatedMatch.AwayTeamID, err)
+		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		return
+	}
+
+	// Create match result for response
+	matchResult := models.MatchResult{
+		Match:    *updatedMatch,
+		HomeTeam: homeTeam.Name,
+		AwayTeam: awayTeam.Name,
+		Result:   newResult,
+		Edited:   updatedMatch.Edited,
+	}
+
+	// Create response
+	response := models.EditMatchResponse{
+		Match:          matchResult,
+		PreviousResult: previousResult,
+		NewResult:      newResult,
+		Message:        fmt.Sprintf("Match result edited successfully. Changed from %s to %s (%s vs %s)", previousResult, newResult, homeTeam.Name, awayTeam.Name),
+	}
+
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// SwapVenueHandler handles POST /api/matches/:matchID/swap-venue
+func (lh *LeagueHandler) SwapVenueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract matchID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "matches" || pathParts[3] != "swap-venue" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	matchID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid match ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate match exists and is still scheduled
+	match, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get match by ID %d: %v", matchID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if match.Status != "scheduled" {
+		http.Error(w, fmt.Sprintf("Can only swap venue for scheduled matches. Current status: %s", match.Status), http.StatusConflict)
+		return
+	}
+
+	// 2. Swap home and away teams; standings are untouched since the match hasn't been played
+	if err := lh.db.SwapMatchVenue(ctx, matchID); err != nil {
+		log.Printf("Failed to swap venue for match %d: %v", matchID, err)
+		if strings.Contains(err.Error(), "no scheduled match") {
+			http.Error(w, "Match is no longer scheduled", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to swap venue", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 3. Get the updated match for response
+	lh.writeMatchUpdate(w, r, matchID, "Venue swapped")
+}
+
+// CancelMatchHandler handles POST /api/matches/:matchID/cancel
+func (lh *LeagueHandler) CancelMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract matchID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "matches" || pathParts[3] != "cancel" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	matchID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid match ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate match exists and is still scheduled
+	match, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get match by ID %d: %v", matchID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if match.Status != "scheduled" {
+		http.Error(w, fmt.Sprintf("Can only cancel scheduled matches. Current status: %s", match.Status), http.StatusConflict)
+		return
+	}
+
+	// 2. Cancel the match
+	if err := lh.db.UpdateMatchStatus(ctx, matchID, "scheduled", "cancelled"); err != nil {
+		log.Printf("Failed to cancel match %d: %v", matchID, err)
+		if strings.Contains(err.Error(), "no scheduled match") {
+			http.Error(w, "Match is no longer scheduled", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to cancel match", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 3. Get the updated match for response
+	lh.writeMatchUpdate(w, r, matchID, "Match cancelled")
+}
+
+// ReinstateMatchHandler handles POST /api/matches/:matchID/reinstate
+func (lh *LeagueHandler) ReinstateMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract matchID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "matches" || pathParts[3] != "reinstate" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	matchID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid match ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate match exists and is cancelled
+	match, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get match by ID %d: %v", matchID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if match.Status != "cancelled" {
+		http.Error(w, fmt.Sprintf("Can only reinstate cancelled matches. Current status: %s", match.Status), http.StatusConflict)
+		return
+	}
+
+	// 2. The match's week must not have been played yet
+	league, err := lh.db.GetLeagueByID(ctx, match.LeagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", match.LeagueID, err)
+		http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		return
+	}
+
+	if match.Week <= league.CurrentWeek {
+		http.Error(w, fmt.Sprintf("Cannot reinstate a match into week %d, which has already been played. Current week: %d", match.Week, league.CurrentWeek), http.StatusBadRequest)
+		return
+	}
+
+	// 3. Return the match to the schedule
+	if err := lh.db.UpdateMatchStatus(ctx, matchID, "cancelled", "scheduled"); err != nil {
+		log.Printf("Failed to reinstate match %d: %v", matchID, err)
+		if strings.Contains(err.Error(), "no cancelled match") {
+			http.Error(w, "Match is no longer cancelled", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to reinstate match", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 4. Get the updated match for response
+	lh.writeMatchUpdate(w, r, matchID, "Match reinstated")
+}
+
+// defaultForfeitGoals is the score awarded to the opponent of a forfeiting team, which scores none
+const defaultForfeitGoals = 3
+
+// ForfeitMatchHandler handles POST /api/matches/:matchID/forfeit
+// The forfeiting side loses by the default forfeit scoreline (3-0) unless the request sets "goals".
+func (lh *LeagueHandler) ForfeitMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract matchID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "matches" || pathParts[3] != "forfeit" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	matchID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid match ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var req models.ForfeitMatchRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	if req.Side != "home" && req.Side != "away" {
+		http.Error(w, "Side must be 'home' or 'away'", http.StatusBadRequest)
+		return
+	}
+
+	winnerGoals := defaultForfeitGoals
+	if req.Goals != nil {
+		if *req.Goals < 1 {
+			http.Error(w, "Forfeit goals must be at least 1", http.StatusBadRequest)
+			return
+		}
+		winnerGoals = *req.Goals
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate match exists and is still scheduled
+	match, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Failed to get match by ID %d: %v", matchID, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if match.Status != "scheduled" {
+		http.Error(w, fmt.Sprintf("Can only forfeit scheduled matches. Current status: %s", match.Status), http.StatusConflict)
+		return
+	}
+
+	// 2. Award the match to the side that did not forfeit
+	homeGoals, awayGoals := 0, winnerGoals
+	if req.Side == "away" {
+		homeGoals, awayGoals = winnerGoals, 0
+	}
+
+	if err := lh.db.PlayMatch(ctx, matchID, homeGoals, awayGoals); err != nil {
+		log.Printf("Failed to record forfeit for match %d: %v", matchID, err)
+		http.Error(w, "Failed to record forfeit", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Update standings
+	if err := lh.db.UpdateStandings(ctx, match.LeagueID, match.HomeTeamID, match.AwayTeamID, homeGoals, awayGoals); err != nil {
+		log.Printf("Failed to update standings for forfeited match %d: %v", matchID, err)
+		http.Error(w, "Failed to update standings", http.StatusInternalServerError)
+		return
+	}
+
+	// 4. Get the updated match for response
+	lh.writeMatchUpdate(w, r, matchID, fmt.Sprintf("Match forfeited by the %s side", req.Side))
+}
+
+// writeMatchUpdate writes the current state of a match after a change, with team names
+func (lh *LeagueHandler) writeMatchUpdate(w http.ResponseWriter, r *http.Request, matchID int, action string) {
+	ctx := r.Context()
+
+	updatedMatch, err := lh.db.GetMatchByID(ctx, matchID)
+	if err != nil {
+		log.Printf("Failed to get updated match %d: %v", matchID, err)
+		http.Error(w, "Failed to retrieve updated match", http.StatusInternalServerError)
+		return
+	}
+
+	homeTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.HomeTeamID)
+	if err != nil {
+		log.Printf("Failed to get home team %d: %v", updatedMatch.HomeTeamID, err)
+		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		return
+	}
+
+	awayTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.AwayTeamID)
+	if err != nil {
+		log.Printf("Failed to get away team %d: %v", updatedMatch.AwayTeamID, err)
+		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		return
+	}
+
+	result := "Not played yet"
+	if updatedMatch.Status == "played" && updatedMatch.HomeGoals != nil && updatedMatch.AwayGoals != nil {
+		result = fmt.Sprintf("%d-%d", *updatedMatch.HomeGoals, *updatedMatch.AwayGoals)
+	}
+
+	response := models.MatchUpdateResponse{
+		Match: models.MatchResult{
+			Match:    *updatedMatch,
+			HomeTeam: homeTeam.Name,
+			AwayTeam: awayTeam.Name,
+			Result:   result,
+			Edited:   updatedMatch.Edited,
+		},
+		Message: fmt.Sprintf("%s: %s vs %s (week %d)", action, homeTeam.Name, awayTeam.Name, updatedMatch.Week),
+	}
+
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// basicRandomGoals generates basic random goals as fallback
+func (lh *LeagueHandler) basicRandomGoals() int {
+	rand.Seed(time.Now().UnixNano())
+	randInt := rand.Intn(100)
+
+	if randInt < 30 {
+		return 0
+	} else if randInt < 55 {
+		return 1
+	} else if randInt < 75 {
+		return 2
+	} else if randInt < 90 {
+		return 3
+	} else if randInt < 97 {
+		return 4
+	} else {
+		return 5
+	}
+}
+
+// LeagueProgressHandler handles GET /api/leagues/:leagueID/progress
+// It reports how far through its season a league is.
+func (lh *LeagueHandler) LeagueProgressHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "progress" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Work out the length of the season
+	totalWeeks, err := lh.leagueTotalWeeks(ctx, league)
+	if err != nil {
+		log.Printf("Failed to get total weeks for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league schedule", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Calculate progress, rounded to one decimal place
+	weeksRemaining := max(totalWeeks-league.CurrentWeek, 0)
+	percentComplete := 0.0
+	if totalWeeks > 0 {
+		percentComplete = math.Round(float64(min(league.CurrentWeek, totalWeeks))*1000/float64(totalWeeks)) / 10
+	}
+
+	resp := models.LeagueProgressResponse{
+		LeagueID:        league.ID,
+		Status:          league.Status,
+		CurrentWeek:     league.ReportedWeek(),
+		TotalWeeks:      totalWeeks,
+		WeeksRemaining:  weeksRemaining,
+		PercentComplete: percentComplete,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// HeadToHeadResultHandler handles GET /api/leagues/:leagueID/head-to-head-result?team1=&team2=&away_goals=
+// It treats the two teams' league meetings as a two-legged tie and reports the aggregate winner.
+// A level aggregate is decided by away goals unless away_goals=false.
+func (lh *LeagueHandler) HeadToHeadResultHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "head-to-head-result" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	team1ID, err := parsePathID(query.Get("team1"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team1: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	team2ID, err := parsePathID(query.Get("team2"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team2: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if team1ID == team2ID {
+		http.Error(w, "team1 and team2 must be different teams", http.StatusBadRequest)
+		return
+	}
+
+	awayGoalsRule := true
+	if value := query.Get("away_goals"); value != "" {
+		if awayGoalsRule, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid away_goals value '%s'. Must be true or false", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	if _, err := lh.db.GetLeagueByID(ctx, leagueID); err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate both teams are part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team1, team2 *models.Team
+	for _, t := range teams {
+		switch t.ID {
+		case team1ID:
+			team1 = t
+		case team2ID:
+			team2 = t
+		}
+	}
+	if team1 == nil || team2 == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Find the first played leg hosted by each team
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+
+	var team1Home, team2Home *models.Match
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		if match.HomeTeamID == team1ID && match.AwayTeamID == team2ID && (team1Home == nil || match.Week < team1Home.Week) {
+			team1Home = match
+		}
+		if match.HomeTeamID == team2ID && match.AwayTeamID == team1ID && (team2Home == nil || match.Week < team2Home.Week) {
+			team2Home = match
+		}
+	}
+	if team1Home == nil || team2Home == nil {
+		http.Error(w, "Both legs between the teams must be played to decide the tie", http.StatusConflict)
+		return
+	}
+
+	// 4. Decide the tie over both legs
+	legs := []models.Match{*team1Home, *team2Home}
+	if team2Home.Week < team1Home.Week {
+		legs[0], legs[1] = legs[1], legs[0]
+	}
+
+	resp := models.HeadToHeadResultResponse{
+		LeagueID:      leagueID,
+		Team1:         models.NewTeamResponse(team1),
+		Team2:         models.NewTeamResponse(team2),
+		AwayGoalsRule: awayGoalsRule,
+		Legs:          legs,
+		Result:        twoLegResult(team1ID, team2ID, team1Home, team2Home, awayGoalsRule),
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// twoLegResult works out the aggregate winner of a tie from the leg hosted by each team.
+// A level aggregate goes to the side with more away goals when the away goals rule applies.
+func twoLegResult(team1ID, team2ID int, team1Home, team2Home *models.Match, awayGoalsRule bool) models.TwoLegResult {
+	var result models.TwoLegResult
+
+	result.Team1Aggregate = *team1Home.HomeGoals + *team2Home.AwayGoals
+	result.Team2Aggregate = *team1Home.AwayGoals + *team2Home.HomeGoals
+	result.Team1AwayGoals = *team2Home.AwayGoals
+	result.Team2AwayGoals = *team1Home.AwayGoals
+
+	switch {
+	case result.Team1Aggregate > result.Team2Aggregate:
+		result.WinnerID, result.DecidedBy = &team1ID, "aggregate"
+	case result.Team1Aggregate < result.Team2Aggregate:
+		result.WinnerID, result.DecidedBy = &team2ID, "aggregate"
+	case awayGoalsRule && result.Team1AwayGoals > result.Team2AwayGoals:
+		result.WinnerID, result.DecidedBy = &team1ID, "away_goals"
+	case awayGoalsRule && result.Team1AwayGoals < result.Team2AwayGoals:
+		result.WinnerID, result.DecidedBy = &team2ID, "away_goals"
+	default:
+		result.DecidedBy = "level"
+	}
+
+	return result
+}
+
+// compareFormLength is how many recent results make up a team's form in a comparison
+const compareFormLength = 5
+
+// CompareTeamsHandler handles GET /api/leagues/:leagueID/compare?team1=&team2=
+// It puts two teams' standings, form and home/away records side by side with their head-to-head record.
+func (lh *LeagueHandler) CompareTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "compare" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	team1ID, err := parsePathID(query.Get("team1"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team1: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	team2ID, err := parsePathID(query.Get("team2"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team2: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if team1ID == team2ID {
+		http.Error(w, "team1 and team2 must be different teams", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 2. Validate both teams are part of the league
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	var team1, team2 *models.Team
+	for _, t := range teams {
+		switch t.ID {
+		case team1ID:
+			team1 = t
+		case team2ID:
+			team2 = t
+		}
+	}
+	if team1 == nil || team2 == nil {
+		http.Error(w, "Team is not in this league", http.StatusNotFound)
+		return
+	}
+
+	// 3. Get the current standings and played matches
+	standings, err := lh.db.GetStandings(ctx, leagueID)
+	if err != nil {
+		log.Printf("Failed to get standings for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get standings", http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Week != matches[j].Week {
+			return matches[i].Week < matches[j].Week
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	// 4. Build each side's stats and the head-to-head record from team1's perspective
+	resp := models.TeamComparisonResponse{
+		League: models.NewLeagueResponse(league),
+		Team1:  comparisonStats(team1, standings, matches),
+		Team2:  comparisonStats(team2, standings, matches),
+	}
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		switch {
+		case match.HomeTeamID == team1ID && match.AwayTeamID == team2ID:
+			addRecordResult(&resp.HeadToHead, *match.HomeGoals, *match.AwayGoals)
+		case match.HomeTeamID == team2ID && match.AwayTeamID == team1ID:
+			addRecordResult(&resp.HeadToHead, *match.AwayGoals, *match.HomeGoals)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// comparisonStats gathers a team's standing, recent form and home/away records.
+// Matches must be in the order they were played.
+func comparisonStats(team *models.Team, standings []models.StandingWithTeam, matches []*models.Match) models.TeamComparisonStats {
+	stats := models.TeamComparisonStats{
+		Team: models.NewTeamResponse(team),
+		Form: []string{},
+	}
+
+	for i, standing := range standings {
+		if standing.TeamID == team.ID {
+			stats.Position = i + 1
+			stats.Played = standing.Played
+			stats.Points = standing.Points
+			stats.GoalsFor = standing.GoalsFor
+			stats.GoalsAgainst = standing.GoalsAgainst
+			stats.GoalDifference = standing.GoalDifference
+			break
 		}
 	}
 
-	return championID
-}
-
-// updateStandingsInMemory updates standings in memory for simulation
-func (lh *LeagueHandler) updateStandingsInMemory(standings map[int]*models.Standing, homeTeamID, awayTeamID, homeGoals, awayGoals int) {
-	homeStanding := standings[homeTeamID]
-	awayStanding := standings[awayTeamID]
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
 
-	// Update matches played
-	homeStanding.Played++
-	awayStanding.Played++
+		var goalsFor, goalsAgainst int
+		switch team.ID {
+		case match.HomeTeamID:
+			goalsFor, goalsAgainst = *match.HomeGoals, *match.AwayGoals
+			addRecordResult(&stats.Home, goalsFor, goalsAgainst)
+		case match.AwayTeamID:
+			goalsFor, goalsAgainst = *match.AwayGoals, *match.HomeGoals
+			addRecordResult(&stats.Away, goalsFor, goalsAgainst)
+		default:
+			continue
+		}
 
-	// Update goals
-	homeStanding.GoalsFor += homeGoals
-	homeStanding.GoalsAgainst += awayGoals
-	awayStanding.GoalsFor += awayGoals
-	awayStanding.GoalsAgainst += homeGoals
+		switch {
+		case goalsFor > goalsAgainst:
+			stats.Form = append(stats.Form, "W")
+		case goalsFor < goalsAgainst:
+			stats.Form = append(stats.Form, "L")
+		default:
+			stats.Form = append(stats.Form, "D")
+		}
+	}
+	if len(stats.Form) > compareFormLength {
+		stats.Form = stats.Form[len(stats.Form)-compareFormLength:]
+	}
 
-	// Update goal difference
-	homeStanding.GoalDifference = homeStanding.GoalsFor - homeStanding.GoalsAgainst
-	awayStanding.GoalDifference = awayStanding.GoalsFor - awayStanding.GoalsAgainst
+	return stats
+}
 
-	// Update wins/draws/losses and points
-	if homeGoals > awayGoals {
-		// Home team wins
-		homeStanding.Wins++
-		homeStanding.Points += 3
-		awayStanding.Losses++
-	} else if homeGoals < awayGoals {
-		// Away team wins
-		awayStanding.Wins++
-		awayStanding.Points += 3
-		homeStanding.Losses++
-	} else {
-		// Draw
-		homeStanding.Draws++
-		homeStanding.Points += 1
-		awayStanding.Draws++
-		awayStanding.Points += 1
+// addRecordResult adds one result to a win/draw/loss record from the perspective of the team scoring goalsFor
+func addRecordResult(record *models.HeadToHeadRecord, goalsFor, goalsAgainst int) {
+	record.Played++
+	record.GoalsFor += goalsFor
+	record.GoalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		record.Wins++
+	case goalsFor < goalsAgainst:
+		record.Losses++
+	default:
+		record.Draws++
 	}
 }
 
-// EditMatchHandler handles POST /api/leagues/edit-match/:matchID
-func (lh *LeagueHandler) EditMatchHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+// LeagueRecordsHandler handles GET /api/leagues/:leagueID/records
+// It finds the league's notable results among its played matches; a record is null until
+// a match qualifies for it. Ties go to the earliest match.
+func (lh *LeagueHandler) LeagueRecordsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Extract matchID from URL path
+	// Extract leagueID from URL path
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[2] != "edit-match" {
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "records" {
 		http.Error(w, "Invalid URL path", http.StatusBadRequest)
 		return
 	}
 
-	matchID, err := strconv.Atoi(pathParts[3])
+	leagueID, err := parsePathID(pathParts[2])
 	if err != nil {
-		http.Error(w, "Invalid match ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	// Parse request body
-	var req models.EditMatchRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	if _, err := lh.db.GetLeagueByID(ctx, leagueID); err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Validate input
-	if req.HomeGoals < 0 || req.AwayGoals < 0 {
-		http.Error(w, "Goals cannot be negative", http.StatusBadRequest)
+	// 2. Get the played matches in the order they were played
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
 		return
 	}
 
-	ctx := r.Context()
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Week != matches[j].Week {
+			return matches[i].Week < matches[j].Week
+		}
+		return matches[i].ID < matches[j].ID
+	})
 
-	// Get the original match to show previous result
-	originalMatch, err := lh.db.GetMatchByID(ctx, matchID)
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
 	if err != nil {
-		if strings.Contains(err.Error(), "no rows in result set") {
-			http.Error(w, "Match not found", http.StatusNotFound)
-			return
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
+		return
+	}
+
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	// 3. Keep the best match seen for each record
+	resp := models.LeagueRecordsResponse{LeagueID: leagueID}
+	record := func(match *models.Match, value int) *models.LeagueRecord {
+		return &models.LeagueRecord{
+			Match: models.MatchResult{
+				Match:    *match,
+				HomeTeam: teamNames[match.HomeTeamID],
+				AwayTeam: teamNames[match.AwayTeamID],
+				Result:   fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals),
+				Edited:   match.Edited,
+			},
+			Value: value,
 		}
-		log.Printf("Failed to get match by ID %d: %v", matchID, err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+	withTeam := func(rec *models.LeagueRecord, teamID int) *models.LeagueRecord {
+		rec.TeamID = &teamID
+		rec.TeamName = teamNames[teamID]
+		return rec
+	}
+
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		homeGoals, awayGoals := *match.HomeGoals, *match.AwayGoals
+		resp.MatchesPlayed++
+
+		margin := homeGoals - awayGoals
+		winnerID := match.HomeTeamID
+		if margin < 0 {
+			margin, winnerID = -margin, match.AwayTeamID
+		}
+		if margin > 0 && (resp.BiggestWin == nil || margin > resp.BiggestWin.Value) {
+			resp.BiggestWin = withTeam(record(match, margin), winnerID)
+		}
+
+		if total := homeGoals + awayGoals; resp.HighestScoring == nil || total > resp.HighestScoring.Value {
+			resp.HighestScoring = record(match, total)
+		}
+
+		scorerID, goals := match.HomeTeamID, homeGoals
+		if awayGoals > homeGoals {
+			scorerID, goals = match.AwayTeamID, awayGoals
+		}
+		if goals > 0 && (resp.MostGoalsByTeam == nil || goals > resp.MostGoalsByTeam.Value) {
+			resp.MostGoalsByTeam = withTeam(record(match, goals), scorerID)
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// WeekAnalysisHandler handles GET /api/leagues/:leagueID/week-analysis
+// It totals the goals and averages the winning margin of each week's played matches, and picks
+// out the highest-scoring, most one-sided and tightest weeks. Ties go to the earliest week.
+func (lh *LeagueHandler) WeekAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Check if match has been played and has results
-	if originalMatch.Status != "played" {
-		http.Error(w, fmt.Sprintf("Can only edit played matches. Current status: %s", originalMatch.Status), http.StatusBadRequest)
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "week-analysis" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
 		return
 	}
 
-	if originalMatch.HomeGoals == nil || originalMatch.AwayGoals == nil {
-		http.Error(w, "Match has no existing result to edit", http.StatusBadRequest)
+	leagueID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	// Store previous result for response
-	previousResult := fmt.Sprintf("%d-%d", *originalMatch.HomeGoals, *originalMatch.AwayGoals)
-	newResult := fmt.Sprintf("%d-%d", req.HomeGoals, req.AwayGoals)
+	ctx := r.Context()
 
-	// Check if the new result is actually different
-	if *originalMatch.HomeGoals == req.HomeGoals && *originalMatch.AwayGoals == req.AwayGoals {
-		http.Error(w, "New result is the same as the current result", http.StatusBadRequest)
+	// 1. Validate league exists
+	if _, err := lh.db.GetLeagueByID(ctx, leagueID); err != nil {
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Update the match result and standings
-	err = lh.db.EditMatch(ctx, matchID, req.HomeGoals, req.AwayGoals)
+	// 2. Get the played matches
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
 	if err != nil {
-		log.Printf("Failed to edit match %d: %v", matchID, err)
-		http.Error(w, "Failed to edit match result", http.StatusInternalServerError)
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
 		return
 	}
 
-	// Get the updated match for response
-	updatedMatch, err := lh.db.GetMatchByID(ctx, matchID)
+	// 3. Total the goals and margins of each week
+	totalMargins := make(map[int]int)
+	byWeek := make(map[int]*models.WeekAnalysis)
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		week, ok := byWeek[match.Week]
+		if !ok {
+			week = &models.WeekAnalysis{Week: match.Week}
+			byWeek[match.Week] = week
+		}
+		week.MatchesPlayed++
+		week.TotalGoals += *match.HomeGoals + *match.AwayGoals
+		margin := *match.HomeGoals - *match.AwayGoals
+		if margin < 0 {
+			margin = -margin
+		}
+		totalMargins[match.Week] += margin
+	}
+
+	resp := models.WeekAnalysisResponse{LeagueID: leagueID, Weeks: make([]models.WeekAnalysis, 0, len(byWeek))}
+	for _, week := range byWeek {
+		week.AverageMargin = math.Round(float64(totalMargins[week.Week])*100/float64(week.MatchesPlayed)) / 100
+		resp.Weeks = append(resp.Weeks, *week)
+	}
+	sort.Slice(resp.Weeks, func(i, j int) bool {
+		return resp.Weeks[i].Week < resp.Weeks[j].Week
+	})
+
+	// 4. Pick out the standout weeks
+	for i := range resp.Weeks {
+		week := &resp.Weeks[i]
+		if resp.HighestScoringWeek == nil || week.TotalGoals > resp.HighestScoringWeek.TotalGoals {
+			resp.HighestScoringWeek = week
+		}
+		if resp.MostOneSidedWeek == nil || week.AverageMargin > resp.MostOneSidedWeek.AverageMargin {
+			resp.MostOneSidedWeek = week
+		}
+		if resp.TightestWeek == nil || week.AverageMargin < resp.TightestWeek.AverageMargin {
+			resp.TightestWeek = week
+		}
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// ExpectedPointsHandler handles GET /api/leagues/:leagueID/expected-points
+// It compares the points each team took from its played matches with its expected points (xPts):
+// 3 times its pre-match chance of winning plus its chance of drawing, from the simulator's goal
+// distributions for each match's sides and venue. Both count 3 points for a win and 1 for a draw,
+// without bonus or bye points. Rivalry swings are random and left out. Biggest over-performer first.
+func (lh *LeagueHandler) ExpectedPointsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract leagueID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "leagues" || pathParts[3] != "expected-points" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	leagueID, err := parsePathID(pathParts[2])
 	if err != nil {
-		log.Printf("Failed to get updated match %d: %v", matchID, err)
-		http.Error(w, "Failed to retrieve updated match", http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Invalid league ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
-	// Get team names for the response
-	homeTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.HomeTeamID)
+	ctx := r.Context()
+
+	// 1. Validate league exists
+	league, err := lh.db.GetLeagueByID(ctx, leagueID)
 	if err != nil {
-		log.Printf("Failed to get home team %d: %v", updatedMatch.HomeTeamID, err)
-		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		log.Printf("Failed to get league by ID %d: %v", leagueID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "League not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get league", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	awayTeam, err := lh.db.GetTeamByID(ctx, updatedMatch.AwayTeamID)
+	// 2. Get the teams with their strengths, and the played matches
+	teams, err := lh.db.GetTeamsInLeague(ctx, leagueID)
 	if err != nil {
-		log.Printf("Failed to get away team %d: %v", updatedMatch.AwayTeamID, err)
-		http.Error(w, "Failed to get team information", http.StatusInternalServerError)
+		log.Printf("Failed to get teams for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league teams", http.StatusInternalServerError)
 		return
 	}
 
-	// Create match result for response
-	matchResult := models.MatchResult{
-		Match:    *updatedMatch,
-		HomeTeam: homeTeam.Name,
-		AwayTeam: awayTeam.Name,
-		Result:   newResult,
+	matches, err := lh.db.GetMatchesByLeague(ctx, leagueID, "played")
+	if err != nil {
+		log.Printf("Failed to get played matches for league %d: %v", leagueID, err)
+		http.Error(w, "Failed to get league matches", http.StatusInternalServerError)
+		return
 	}
 
-	// Create response
-	response := models.EditMatchResponse{
-		Match:          matchResult,
-		PreviousResult: previousResult,
-		NewResult:      newResult,
-		Message:        fmt.Sprintf("Match result edited successfully. Changed from %s to %s (%s vs %s)", previousResult, newResult, homeTeam.Name, awayTeam.Name),
+	strengths := make(map[int]int, len(teams))
+	rows := make(map[int]*models.TeamExpectedPoints, len(teams))
+	for _, team := range teams {
+		strengths[team.ID] = team.Strength
+		rows[team.ID] = &models.TeamExpectedPoints{TeamID: team.ID, TeamName: team.Name}
+	}
+	strength := func(teamID int) int {
+		if value, ok := strengths[teamID]; ok {
+			return value
+		}
+		return neutralStrength // an opponent no longer in the league
+	}
+
+	// 3. Add up the actual and expected points of every played match
+	for _, match := range matches {
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+		homeExpectancy, awayExpectancy := lh.simulatedExpectancy(strength(match.HomeTeamID), strength(match.AwayTeamID), defaultHomeAdvantage, lh.matchSimulation(league, match))
+		homeWin, draw, awayWin := resultProbabilities(homeExpectancy, awayExpectancy)
+
+		homePoints, awayPoints := 1, 1
+		switch {
+		case *match.HomeGoals > *match.AwayGoals:
+			homePoints, awayPoints = 3, 0
+		case *match.HomeGoals < *match.AwayGoals:
+			homePoints, awayPoints = 0, 3
+		}
+
+		if row, ok := rows[match.HomeTeamID]; ok {
+			row.Played++
+			row.Points += homePoints
+			row.ExpectedPoints += 3*homeWin + draw
+		}
+		if row, ok := rows[match.AwayTeamID]; ok {
+			row.Played++
+			row.Points += awayPoints
+			row.ExpectedPoints += 3*awayWin + draw
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("Failed to encode response: %v", err)
+	resp := models.ExpectedPointsResponse{
+		League: models.NewLeagueResponse(league),
+		Teams:  make([]models.TeamExpectedPoints, 0, len(rows)),
+	}
+	for _, team := range teams {
+		row := rows[team.ID]
+		row.ExpectedPoints = math.Round(row.ExpectedPoints*100) / 100
+		row.Difference = math.Round((float64(row.Points)-row.ExpectedPoints)*100) / 100
+		resp.Teams = append(resp.Teams, *row)
 	}
+	sort.SliceStable(resp.Teams, func(i, j int) bool {
+		return resp.Teams[i].Difference > resp.Teams[j].Difference
+	})
+
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
-// basicRandomGoals generates basic random goals as fallback
-func (lh *LeagueHandler) basicRandomGoals() int {
-	rand.Seed(time.Now().UnixNano())
-	randInt := rand.Intn(100)
+// leaderboardDefaultLimit and leaderboardMaxLimit bound the number of teams on the all-time leaderboard
+const (
+	leaderboardDefaultLimit = 10
+	leaderboardMaxLimit     = 100
+)
 
-	if randInt < 30 {
-		return 0
-	} else if randInt < 55 {
-		return 1
-	} else if randInt < 75 {
-		return 2
-	} else if randInt < 90 {
-		return 3
-	} else if randInt < 97 {
-		return 4
-	} else {
-		return 5
+// LeaderboardHandler handles GET /api/leaderboard?limit=
+// It ranks teams by their total points and wins across all finished leagues.
+func (lh *LeagueHandler) LeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Optional limit, capped to keep result sets bounded
+	limit := leaderboardDefaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > leaderboardMaxLimit {
+			limit = leaderboardMaxLimit
+		}
+	}
+
+	entries, err := lh.db.GetLeaderboard(r.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to get leaderboard: %v", err)
+		http.Error(w, "Failed to get leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.LeaderboardResponse{
+		Limit:   limit,
+		Entries: entries,
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// LeagueSummaryHandler handles GET /api/leagues/summary
+// It returns league counts by status and the total number of teams for dashboards.
+func (lh *LeagueHandler) LeagueSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	summary, err := lh.db.GetLeagueSummary(r.Context())
+	if err != nil {
+		log.Printf("Failed to get league summary: %v", err)
+		http.Error(w, "Failed to get league summary", http.StatusInternalServerError)
+		return
+	}
+
+	// Report every lifecycle status, even when no league is in it
+	for _, status := range []string{"created", "started", "paused", "finished"} {
+		if _, ok := summary.LeaguesByStatus[status]; !ok {
+			summary.LeaguesByStatus[status] = 0
+		}
+	}
+
+	summary.TotalLeagues = 0
+	for _, count := range summary.LeaguesByStatus {
+		summary.TotalLeagues += count
 	}
+
+	writeJSON(w, r, http.StatusOK, summary)
 }
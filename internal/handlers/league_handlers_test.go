@@ -5,8 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -107,7 +111,7 @@ func (m *mockLeagueDBService) GetTeamByID(ctx context.Context, teamID int) (*mod
 }
 
 func (m *mockLeagueDBService) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
-	if leagueID == 1 {
+	if leagueID == 1 || leagueID == 3 {
 		return []*models.Team{
 			{ID: 1, Name: "Team A", Strength: 85},
 			{ID: 2, Name: "Team B", Strength: 90},
@@ -158,6 +162,28 @@ func (m *mockLeagueDBService) GetMatchesByWeekAndLeague(ctx context.Context, lea
 	return nil, fmt.Errorf("no matches found for league %d week %d", leagueID, week)
 }
 
+func (m *mockLeagueDBService) GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error) {
+	if leagueID != 3 {
+		return []*models.Match{}, nil
+	}
+
+	homeGoals, awayGoals := 2, 0
+	all := []*models.Match{
+		{ID: 1, LeagueID: 3, HomeTeamID: 1, AwayTeamID: 2, Week: 1, HomeGoals: &homeGoals, AwayGoals: &awayGoals, Status: "played"},
+		{ID: 2, LeagueID: 3, HomeTeamID: 2, AwayTeamID: 1, Week: 2, Status: "scheduled"},
+		{ID: 3, LeagueID: 3, HomeTeamID: 1, AwayTeamID: 2, Week: 3, Status: "cancelled"},
+	}
+
+	// Filter by status the same way the query does
+	var matches []*models.Match
+	for _, match := range all {
+		if status == "" || match.Status == status {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
 func (m *mockLeagueDBService) PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals int) error {
 	if matchID == 1 {
 		return nil // Successful update
@@ -403,6 +429,81 @@ func TestInitializeLeagueHandler(t *testing.T) {
 	}
 }
 
+// corruptDefaultTeamsDB serves default teams where one has lost its strength
+type corruptDefaultTeamsDB struct {
+	*mockLeagueDBService
+}
+
+func (c *corruptDefaultTeamsDB) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
+	teams, err := c.mockLeagueDBService.GetDefaultTeams(ctx)
+	teams[2].Strength = 0
+	return teams, err
+}
+
+func TestInitializeLeagueHandler_ImplausibleDefaultStrength(t *testing.T) {
+	handler := NewLeagueHandler(&corruptDefaultTeamsDB{&mockLeagueDBService{}})
+
+	w := httptest.NewRecorder()
+	handler.InitializeLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/initialize", strings.NewReader(`{"name": "Corrupted"}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp models.InitializeLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !strings.Contains(resp.Warning, "Chelsea FC (0)") {
+		t.Errorf("Expected a warning naming Chelsea FC's strength of 0, got %q", resp.Warning)
+	}
+	for _, team := range []string{"Manchester City", "Liverpool FC", "Arsenal FC"} {
+		if strings.Contains(resp.Warning, team) {
+			t.Errorf("Expected %s's plausible strength not to be flagged, got %q", team, resp.Warning)
+		}
+	}
+}
+
+func TestImplausibleDefaultTeams(t *testing.T) {
+	teams, _ := (&mockDBService{}).GetDefaultTeams(context.Background())
+	if warning := implausibleDefaultTeams(teams); warning != "" {
+		t.Errorf("Expected no warning for the seeded strengths, got %q", warning)
+	}
+
+	teams[0].Strength = 0
+	teams[3].Strength = 101
+	warning := implausibleDefaultTeams(teams)
+	if !strings.Contains(warning, "Manchester City (0)") || !strings.Contains(warning, "Arsenal FC (101)") {
+		t.Errorf("Expected both implausible teams in the warning, got %q", warning)
+	}
+}
+
+func TestInitializeLeagueHandler_StandingFailureLeavesNoLeague(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.failStandingTeamID = 3 // the third default team
+	handler := NewLeagueHandler(db)
+	leaguesBefore := len(db.leagues)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/initialize", strings.NewReader(`{"name": "Half Initialized"}`))
+	w := httptest.NewRecorder()
+	handler.InitializeLeagueHandler(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+
+	if len(db.leagues) != leaguesBefore {
+		t.Errorf("Expected no league to remain, got %d leagues (was %d)", len(db.leagues), leaguesBefore)
+	}
+	for id, league := range db.leagues {
+		if league.Name == "Half Initialized" {
+			t.Errorf("Expected the failed league to be rolled back, found league %d", id)
+		}
+	}
+	if len(db.members[leaguesBefore+1]) != 0 || len(db.standings[leaguesBefore+1]) != 0 {
+		t.Error("Expected no memberships or standings for the failed league")
+	}
+}
+
 func TestInitializeLeagueHandler_EmptyName(t *testing.T) {
 	handler := NewLeagueHandler(&mockLeagueDBService{})
 
@@ -435,6 +536,151 @@ func TestInitializeLeagueHandler_InvalidMethod(t *testing.T) {
 	}
 }
 
+func TestImportLeagueHandler(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	homeGoals, awayGoals := 2, 1
+	importReq := models.ImportLeagueRequest{
+		Name: "Imported League",
+		Teams: []models.ImportTeam{
+			{ID: 7, Name: "Seven FC", Strength: 70},
+			{ID: 9, Name: "Nine FC", Strength: 60},
+		},
+		Matches: []models.ImportMatch{
+			{HomeTeamID: 7, AwayTeamID: 9, Week: 1, HomeGoals: &homeGoals, AwayGoals: &awayGoals},
+			{HomeTeamID: 9, AwayTeamID: 7, Week: 2},
+		},
+	}
+
+	reqBody, err := json.Marshal(importReq)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/import", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ImportLeagueHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var resp models.ImportLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.League.Name != importReq.Name {
+		t.Errorf("Expected league name %s, got %s", importReq.Name, resp.League.Name)
+	}
+	if len(resp.Teams) != 2 {
+		t.Errorf("Expected 2 teams, got %d", len(resp.Teams))
+	}
+	if resp.MatchesCount != 2 {
+		t.Errorf("Expected 2 matches, got %d", resp.MatchesCount)
+	}
+}
+
+func TestImportLeagueHandler_DanglingTeamReference(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	importReq := models.ImportLeagueRequest{
+		Name: "Imported League",
+		Teams: []models.ImportTeam{
+			{ID: 7, Name: "Seven FC", Strength: 70},
+			{ID: 9, Name: "Nine FC", Strength: 60},
+		},
+		Matches: []models.ImportMatch{
+			{HomeTeamID: 7, AwayTeamID: 9, Week: 1},
+			{HomeTeamID: 7, AwayTeamID: 42, Week: 2}, // team 42 is not part of the document
+		},
+	}
+
+	reqBody, _ := json.Marshal(importReq)
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/import", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ImportLeagueHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "unknown away team 42") {
+		t.Errorf("Expected error to name the dangling team, got %q", w.Body.String())
+	}
+}
+
+func TestImportLeagueHandler_NormalizeStrengths(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	importReq := models.ImportLeagueRequest{
+		Name: "Imported League",
+		Teams: []models.ImportTeam{
+			{ID: 1, Name: "Low FC", Strength: 120},
+			{ID: 2, Name: "Top FC", Strength: 1000},
+			{ID: 3, Name: "Mid FC", Strength: 540},
+			{ID: 4, Name: "Upper FC", Strength: 810},
+		},
+	}
+
+	reqBody, _ := json.Marshal(importReq)
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/import?normalize=true", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ImportLeagueHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp models.ImportLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	strengths := make(map[string]int)
+	for _, team := range resp.Teams {
+		if team.Strength < 0 || team.Strength > 100 {
+			t.Errorf("Expected %s's strength in [0,100], got %d", team.Name, team.Strength)
+		}
+		strengths[team.Name] = team.Strength
+	}
+
+	order := []string{"Low FC", "Mid FC", "Upper FC", "Top FC"}
+	for i := 1; i < len(order); i++ {
+		if strengths[order[i-1]] >= strengths[order[i]] {
+			t.Errorf("Expected %s (%d) below %s (%d)", order[i-1], strengths[order[i-1]], order[i], strengths[order[i]])
+		}
+	}
+	if strengths["Low FC"] != 0 || strengths["Top FC"] != 100 {
+		t.Errorf("Expected the scale to span 0-100, got %d-%d", strengths["Low FC"], strengths["Top FC"])
+	}
+}
+
+func TestNormalizeImportStrengths(t *testing.T) {
+	teams := []models.ImportTeam{{Strength: -40}, {Strength: 10}, {Strength: 60}, {Strength: 10}}
+	normalizeImportStrengths(teams)
+
+	expected := []int{0, 50, 100, 50}
+	for i, team := range teams {
+		if team.Strength != expected[i] {
+			t.Errorf("Team %d: expected strength %d, got %d", i, expected[i], team.Strength)
+		}
+	}
+
+	same := []models.ImportTeam{{Strength: 700}, {Strength: 700}}
+	normalizeImportStrengths(same)
+	for i, team := range same {
+		if team.Strength != neutralStrength {
+			t.Errorf("Team %d: expected neutral strength for a flat scale, got %d", i, team.Strength)
+		}
+	}
+}
+
 func TestAddTeamToLeagueHandler(t *testing.T) {
 	handler := NewLeagueHandler(&mockLeagueDBService{})
 
@@ -465,6 +711,33 @@ func TestAddTeamToLeagueHandler(t *testing.T) {
 	}
 }
 
+func TestAddTeamToLeagueHandler_AlreadyInLeague(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 50}
+	handler := NewLeagueHandler(db)
+
+	addTeam := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.AddTeamToLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/add-team/1/5", nil))
+		return w
+	}
+
+	if w := addTeam(); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	w := addTeam()
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "already in this league") {
+		t.Errorf("Expected an already-in-league message, got %q", w.Body.String())
+	}
+	if len(db.members[1]) != 5 {
+		t.Errorf("Expected 5 teams in the league, got %d", len(db.members[1]))
+	}
+}
+
 func TestAddTeamToLeagueHandler_LeagueNotFound(t *testing.T) {
 	handler := NewLeagueHandler(&mockLeagueDBService{})
 
@@ -573,6 +846,265 @@ func TestRemoveTeamFromLeagueHandler(t *testing.T) {
 	}
 }
 
+func TestAdvanceWeekHandler_BlocksOnUnplayedEarlierWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	ctx := context.Background()
+
+	// Week 1 was only partly played before the league moved on to it
+	var weekOne []*models.Match
+	for _, match := range db.matches {
+		if match.Week == 1 {
+			weekOne = append(weekOne, match)
+		}
+	}
+	if len(weekOne) != 2 {
+		t.Fatalf("Expected 2 week 1 matches, got %d", len(weekOne))
+	}
+	if err := db.PlayMatch(ctx, weekOne[0].ID, 1, 0); err != nil {
+		t.Fatalf("Failed to play match: %v", err)
+	}
+	db.leagues[1].CurrentWeek = 1
+
+	advance := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		return w
+	}
+
+	w := advance()
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d with week 1 unfinished, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	var conflict models.UnplayedMatchesErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&conflict); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(conflict.MatchIDs) != 1 || conflict.MatchIDs[0] != weekOne[1].ID {
+		t.Errorf("Expected unplayed match %d to be listed, got %v", weekOne[1].ID, conflict.MatchIDs)
+	}
+	if db.leagues[1].CurrentWeek != 1 {
+		t.Errorf("Expected the league to stay in week 1, got week %d", db.leagues[1].CurrentWeek)
+	}
+
+	// Once week 1 is complete the league advances
+	if err := db.PlayMatch(ctx, weekOne[1].ID, 2, 2); err != nil {
+		t.Fatalf("Failed to play match: %v", err)
+	}
+	if w := advance(); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d once week 1 is played, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+func TestGroupedLeague(t *testing.T) {
+	teams := append(fakeTeams(),
+		&models.Team{ID: 5, Name: "Echo", Strength: 80},
+		&models.Team{ID: 6, Name: "Foxtrot", Strength: 65},
+		&models.Team{ID: 7, Name: "Golf", Strength: 55},
+		&models.Team{ID: 8, Name: "Hotel", Strength: 40},
+	)
+	db := newFakeLeagueDB(teams)
+	handler := NewLeagueHandler(db)
+
+	setGroup := func(teamID int, group string) *httptest.ResponseRecorder {
+		body := strings.NewReader(fmt.Sprintf(`{"group": %q}`, group))
+		w := httptest.NewRecorder()
+		handler.SetTeamGroupHandler(w, httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/leagues/1/teams/%d/group", teamID), body))
+		return w
+	}
+
+	// Starting with only some teams grouped is rejected
+	if w := setGroup(1, "A"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d with ungrouped teams, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	expected := map[int]string{1: "A", 3: "A", 5: "A", 7: "A", 2: "B", 4: "B", 6: "B", 8: "B"}
+	for teamID, group := range expected {
+		if w := setGroup(teamID, " "+group+" "); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+	if w := setGroup(99, "A"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a team outside the league, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", w.Code, w.Body.String())
+	}
+	var started models.StartLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&started); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	// Two groups of 4 each play a 6 week double round-robin side by side
+	if started.MatchesCount != 24 || started.TotalWeeks != 6 {
+		t.Errorf("Expected 24 matches over 6 weeks, got %d over %d", started.MatchesCount, started.TotalWeeks)
+	}
+
+	// Every fixture stays within its group
+	for _, match := range db.matches {
+		if expected[match.HomeTeamID] != expected[match.AwayTeamID] {
+			t.Errorf("Match %d pairs team %d (group %s) with team %d (group %s)", match.ID,
+				match.HomeTeamID, expected[match.HomeTeamID], match.AwayTeamID, expected[match.AwayTeamID])
+		}
+	}
+
+	// Groups are fixed once the league has started
+	if w := setGroup(1, "B"); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d after start, got %d", http.StatusConflict, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to play all matches: status %d, body %s", w.Code, w.Body.String())
+	}
+	if db.leagues[1].Status != "finished" {
+		t.Errorf("Expected league to finish after 6 weeks, got status %q in week %d", db.leagues[1].Status, db.leagues[1].CurrentWeek)
+	}
+
+	// Standings are reported per group
+	w = httptest.NewRecorder()
+	handler.GroupStandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/groups", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.GroupStandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Groups) != 2 || resp.Groups[0].Group != "A" || resp.Groups[1].Group != "B" {
+		t.Fatalf("Expected groups A and B, got %+v", resp.Groups)
+	}
+	for _, group := range resp.Groups {
+		if len(group.Standings) != 4 {
+			t.Errorf("Expected 4 teams in group %s, got %d", group.Group, len(group.Standings))
+		}
+		for i, row := range group.Standings {
+			if expected[row.TeamID] != group.Group {
+				t.Errorf("Team %d listed in group %s, expected group %s", row.TeamID, group.Group, expected[row.TeamID])
+			}
+			if row.Position != i+1 {
+				t.Errorf("Expected position %d in group %s, got %d", i+1, group.Group, row.Position)
+			}
+			if row.Played != 6 {
+				t.Errorf("Expected team %d to play 6 matches, got %d", row.TeamID, row.Played)
+			}
+		}
+	}
+}
+
+func TestLeagueEventsHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	startFakeLeague(t, handler)
+	for week := 1; week <= 2; week++ {
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week %d: status %d", week, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.LeagueEventsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/events", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.LeagueEventsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	expected := []struct{ eventType, details string }{
+		{models.LeagueEventStarted, "League started with 4 teams, 12 matches over 6 weeks"},
+		{models.LeagueEventWeekAdvanced, "Week 1 played: 2 matches"},
+		{models.LeagueEventWeekAdvanced, "Week 2 played: 2 matches"},
+	}
+	if len(resp.Events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), resp.Events)
+	}
+	for i, event := range resp.Events {
+		if event.Type != expected[i].eventType || event.Details != expected[i].details {
+			t.Errorf("Event %d: expected %s %q, got %s %q", i, expected[i].eventType, expected[i].details, event.Type, event.Details)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handler.LeagueEventsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/99/events", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for an unknown league, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestAvailableTeamsHandler(t *testing.T) {
+	// Alpha and Bravo are in the league; Charlie, Delta and Echo are not
+	db := newFakeLeagueDB(fakeTeams())
+	db.members[1] = []int{1, 2}
+	db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 50}
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.AvailableTeamsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/available-teams", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.AvailableTeamsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	var names []string
+	for _, team := range resp.Teams {
+		names = append(names, team.Name)
+	}
+	if got := strings.Join(names, ","); got != "Charlie,Delta,Echo" {
+		t.Errorf("Expected Charlie,Delta,Echo to be available, got %s", got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.AvailableTeamsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/99/available-teams", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for an unknown league, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestRemoveTeamFromLeagueHandler_StartedLeagueMinimum(t *testing.T) {
+	remove := func(db *fakeLeagueDB, teamID int) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		NewLeagueHandler(db).RemoveTeamFromLeagueHandler(w, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/leagues/remove-team/1/%d", teamID), nil))
+		return w
+	}
+
+	// A started 2-team league can't lose a team
+	db := newFakeLeagueDB(fakeTeams()[:2])
+	db.leagues[1].Status = "started"
+	if w := remove(db, 1); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d removing from a 2-team started league, got %d", http.StatusBadRequest, w.Code)
+	}
+	if len(db.members[1]) != 2 {
+		t.Errorf("Expected both teams to remain, got %v", db.members[1])
+	}
+
+	// A started 4-team league can drop to 3
+	db = newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Status = "started"
+	if w := remove(db, 1); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d removing from a 4-team started league, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(db.members[1]) != 3 {
+		t.Errorf("Expected 3 teams to remain, got %v", db.members[1])
+	}
+}
+
 func TestRemoveTeamFromLeagueHandler_TeamNotInLeague(t *testing.T) {
 	handler := NewLeagueHandler(&mockLeagueDBService{})
 
@@ -850,3 +1382,5367 @@ func TestAdvanceWeekHandler_InvalidPath(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
 	}
 }
+
+func TestListMatchesHandler(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/3/matches", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListMatchesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp models.LeagueMatchesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Matches) != 3 {
+		t.Errorf("Expected 3 matches, got %d", len(resp.Matches))
+	}
+	if resp.Matches[0].HomeTeam != "Team A" || resp.Matches[0].Result != "2-0" {
+		t.Errorf("Expected first match 'Team A' 2-0, got '%s' %s", resp.Matches[0].HomeTeam, resp.Matches[0].Result)
+	}
+}
+
+func TestListMatchesHandler_StatusFilter(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	for _, status := range []string{"scheduled", "played", "cancelled"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/3/matches?status="+status, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMatchesHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Status %s: expected status %d, got %d", status, http.StatusOK, w.Code)
+		}
+
+		var resp models.LeagueMatchesResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		if len(resp.Matches) != 1 {
+			t.Errorf("Status %s: expected 1 match, got %d", status, len(resp.Matches))
+		}
+		for _, match := range resp.Matches {
+			if match.Match.Status != status {
+				t.Errorf("Status %s: got match with status %s", status, match.Match.Status)
+			}
+		}
+	}
+}
+
+func TestListMatchesHandler_InvalidStatus(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/3/matches?status=postponed", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListMatchesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestListMatchesHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/99/matches", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListMatchesHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+// fakeLeagueDB is an in-memory database that keeps state across calls,
+// for tests that exercise a full league flow
+type fakeLeagueDB struct {
+	*mockDBService
+	leagues     map[int]*models.League
+	teams       map[int]*models.Team
+	members     map[int][]int // leagueID -> team IDs
+	matches     []*models.Match
+	standings   map[int]map[int]*models.Standing // leagueID -> teamID -> standing
+	nextMatchID int
+	idempotency map[string]int // idempotency key -> league ID
+	finishedAt  map[int][]int  // leagueID -> current week each time the league was marked finished
+	events      []models.LeagueEvent
+	runs        []models.SimulationRun
+	groups      map[int]map[int]string // leagueID -> teamID -> group
+
+	failStandingTeamID int // InitializeLeagueWithTeams fails when initializing this team's standing
+}
+
+// newFakeLeagueDB creates a fake database holding league 1 in "created" status with the given teams
+func newFakeLeagueDB(teams []*models.Team) *fakeLeagueDB {
+	f := &fakeLeagueDB{
+		leagues:     map[int]*models.League{1: {ID: 1, Name: "Fake League", Status: "created", CreatedAt: time.Now()}},
+		teams:       make(map[int]*models.Team),
+		members:     make(map[int][]int),
+		standings:   map[int]map[int]*models.Standing{1: {}},
+		nextMatchID: 1,
+		idempotency: make(map[string]int),
+		finishedAt:  make(map[int][]int),
+		groups:      make(map[int]map[int]string),
+	}
+	for _, team := range teams {
+		f.teams[team.ID] = team
+		f.members[1] = append(f.members[1], team.ID)
+		f.standings[1][team.ID] = &models.Standing{LeagueID: 1, TeamID: team.ID}
+	}
+	return f
+}
+
+func (f *fakeLeagueDB) GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error) {
+	league, ok := f.leagues[leagueID]
+	if !ok {
+		return nil, fmt.Errorf("no rows in result set")
+	}
+	leagueCopy := *league
+	return &leagueCopy, nil
+}
+
+func (f *fakeLeagueDB) GetAllLeagues(ctx context.Context, status string) ([]*models.League, error) {
+	leagues := []*models.League{}
+	for id := 1; id <= len(f.leagues); id++ {
+		league, ok := f.leagues[id]
+		if !ok || (status != "" && league.Status != status) {
+			continue
+		}
+		leagueCopy := *league
+		leagues = append(leagues, &leagueCopy)
+	}
+	return leagues, nil
+}
+
+func (f *fakeLeagueDB) UpdateLeagueConfig(ctx context.Context, leagueID int, config models.LeagueConfig) error {
+	league, ok := f.leagues[leagueID]
+	if !ok || league.Status != "created" {
+		return fmt.Errorf("no created league found with ID %d", leagueID)
+	}
+	league.Zones = config.Zones
+	league.ScoreCorrelation = config.ScoreCorrelation
+	league.UpsetFactor = config.UpsetFactor
+	league.Scoring = config.Scoring
+	league.CleanSheetBonus = config.CleanSheetBonus
+	league.FinalTiebreak = config.FinalTiebreak
+	league.TiebreakSeed = config.TiebreakSeed
+	league.FatiguePenalty = config.FatiguePenalty
+	league.MatchesPerWeek = config.MatchesPerWeek
+	league.ByePoints = config.ByePoints
+	league.BigWinMargin = config.BigWinMargin
+	league.BigWinBonus = config.BigWinBonus
+	league.WeekNumbering = config.WeekNumbering
+	return nil
+}
+
+func (f *fakeLeagueDB) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
+	team, ok := f.teams[teamID]
+	if !ok {
+		return nil, fmt.Errorf("no rows in result set")
+	}
+	teamCopy := *team
+	return &teamCopy, nil
+}
+
+func (f *fakeLeagueDB) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	var teams []*models.Team
+	for _, teamID := range f.members[leagueID] {
+		teamCopy := *f.teams[teamID]
+		teams = append(teams, &teamCopy)
+	}
+	return teams, nil
+}
+
+func (f *fakeLeagueDB) RecordLeagueEvent(ctx context.Context, leagueID int, eventType, details string) error {
+	f.events = append(f.events, models.LeagueEvent{
+		ID:        len(f.events) + 1,
+		LeagueID:  leagueID,
+		Type:      eventType,
+		Details:   details,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+func (f *fakeLeagueDB) DeleteFinishedLeagues(ctx context.Context, finishedBefore time.Time) ([]int, error) {
+	deleted := []int{}
+	for id := 1; id <= f.maxLeagueID(); id++ {
+		league, ok := f.leagues[id]
+		if !ok || league.Status != "finished" {
+			continue
+		}
+		finishedAt := league.CreatedAt
+		for _, event := range f.events {
+			if event.LeagueID == id && event.Type == models.LeagueEventFinished && event.CreatedAt.After(finishedAt) {
+				finishedAt = event.CreatedAt
+			}
+		}
+		if finishedAt.Before(finishedBefore) {
+			delete(f.leagues, id)
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+// maxLeagueID returns the highest league ID the fake holds
+func (f *fakeLeagueDB) maxLeagueID() int {
+	maxID := 0
+	for id := range f.leagues {
+		maxID = max(maxID, id)
+	}
+	return maxID
+}
+
+func (f *fakeLeagueDB) GetLeagueEvents(ctx context.Context, leagueID int) ([]models.LeagueEvent, error) {
+	events := []models.LeagueEvent{}
+	for _, event := range f.events {
+		if event.LeagueID == leagueID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (f *fakeLeagueDB) RecordSimulationRun(ctx context.Context, run models.SimulationRun) (*models.SimulationRun, error) {
+	run.ID = len(f.runs) + 1
+	run.CreatedAt = time.Now()
+	f.runs = append(f.runs, run)
+	return &run, nil
+}
+
+func (f *fakeLeagueDB) GetSimulationRuns(ctx context.Context, leagueID int) ([]models.SimulationRun, error) {
+	runs := []models.SimulationRun{}
+	for _, run := range f.runs {
+		if run.LeagueID == leagueID {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func (f *fakeLeagueDB) GetTeamsNotInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	inLeague := make(map[int]bool)
+	for _, teamID := range f.members[leagueID] {
+		inLeague[teamID] = true
+	}
+
+	var teams []*models.Team
+	for _, team := range f.teams {
+		if !inLeague[team.ID] {
+			teamCopy := *team
+			teams = append(teams, &teamCopy)
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return teams, nil
+}
+
+func (f *fakeLeagueDB) SetTeamGroup(ctx context.Context, leagueID, teamID int, group string) error {
+	if f.groups[leagueID] == nil {
+		f.groups[leagueID] = make(map[int]string)
+	}
+	if group == "" {
+		delete(f.groups[leagueID], teamID)
+		return nil
+	}
+	f.groups[leagueID][teamID] = group
+	return nil
+}
+
+func (f *fakeLeagueDB) GetTeamGroups(ctx context.Context, leagueID int) (map[int]string, error) {
+	groups := make(map[int]string)
+	for teamID, group := range f.groups[leagueID] {
+		groups[teamID] = group
+	}
+	return groups, nil
+}
+
+func (f *fakeLeagueDB) CreateLeague(ctx context.Context, req *models.CreateLeagueRequest) (*models.League, error) {
+	id := len(f.leagues) + 1
+	f.leagues[id] = &models.League{ID: id, Name: req.Name, Status: "created", CreatedAt: time.Now()}
+	if req.ScoreCorrelation != nil {
+		f.leagues[id].ScoreCorrelation = *req.ScoreCorrelation
+	}
+	if req.UpsetFactor != nil {
+		f.leagues[id].UpsetFactor = *req.UpsetFactor
+	}
+	scoring, ok := models.ResolveScoringPreset(req.ScoringPreset)
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring preset %q", req.ScoringPreset)
+	}
+	f.leagues[id].Scoring = scoring
+	if req.CleanSheetBonus != nil {
+		f.leagues[id].CleanSheetBonus = *req.CleanSheetBonus
+	}
+	if req.FatiguePenalty != nil {
+		f.leagues[id].FatiguePenalty = *req.FatiguePenalty
+	}
+	if req.MatchesPerWeek != nil {
+		f.leagues[id].MatchesPerWeek = *req.MatchesPerWeek
+	}
+	if req.ByePoints != nil {
+		f.leagues[id].ByePoints = *req.ByePoints
+	}
+	if req.BigWinMargin != nil {
+		f.leagues[id].BigWinMargin = *req.BigWinMargin
+	}
+	if req.BigWinBonus != nil {
+		f.leagues[id].BigWinBonus = *req.BigWinBonus
+	}
+	f.leagues[id].WeekNumbering = req.WeekNumbering
+	f.standings[id] = make(map[int]*models.Standing)
+
+	leagueCopy := *f.leagues[id]
+	return &leagueCopy, nil
+}
+
+func (f *fakeLeagueDB) CreateLeagueWithIdempotencyKey(ctx context.Context, key string, req *models.CreateLeagueRequest) (*models.League, bool, error) {
+	if leagueID, ok := f.idempotency[key]; ok {
+		league, err := f.GetLeagueByID(ctx, leagueID)
+		return league, false, err
+	}
+
+	league, err := f.CreateLeague(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	f.idempotency[key] = league.ID
+	return league, true, nil
+}
+
+// InitializeLeagueWithTeams only stores the league once every team has been added,
+// mirroring the rollback of the real transaction
+func (f *fakeLeagueDB) InitializeLeagueWithTeams(ctx context.Context, req *models.CreateLeagueRequest, teams []*models.Team) (*models.League, error) {
+	id := len(f.leagues) + 1
+	members := []int{}
+	standings := make(map[int]*models.Standing)
+	for _, team := range teams {
+		members = append(members, team.ID)
+		if team.ID == f.failStandingTeamID {
+			return nil, fmt.Errorf("failed to initialize standing for team %d in league %d", team.ID, id)
+		}
+		standings[team.ID] = &models.Standing{LeagueID: id, TeamID: team.ID}
+	}
+
+	f.leagues[id] = &models.League{ID: id, Name: req.Name, Status: "created", CreatedAt: time.Now()}
+	f.members[id] = members
+	f.standings[id] = standings
+
+	leagueCopy := *f.leagues[id]
+	return &leagueCopy, nil
+}
+
+func (f *fakeLeagueDB) CloneLeague(ctx context.Context, sourceLeagueID int, name string) (*models.League, error) {
+	if _, ok := f.leagues[sourceLeagueID]; !ok {
+		return nil, fmt.Errorf("no rows in result set")
+	}
+
+	id := len(f.leagues) + 1
+	f.leagues[id] = &models.League{ID: id, Name: name, Status: "created", CreatedAt: time.Now()}
+	f.standings[id] = make(map[int]*models.Standing)
+	for _, teamID := range f.members[sourceLeagueID] {
+		f.members[id] = append(f.members[id], teamID)
+		f.standings[id][teamID] = &models.Standing{LeagueID: id, TeamID: teamID}
+	}
+
+	leagueCopy := *f.leagues[id]
+	return &leagueCopy, nil
+}
+
+func (f *fakeLeagueDB) GetMatchesByKickoffRange(ctx context.Context, leagueID int, from, to time.Time) ([]*models.Match, error) {
+	matches := []*models.Match{}
+	for _, match := range f.matches {
+		if match.LeagueID != leagueID || match.KickoffTime == nil {
+			continue
+		}
+		if match.KickoffTime.Before(from) || !match.KickoffTime.Before(to) {
+			continue
+		}
+		matchCopy := *match
+		matches = append(matches, &matchCopy)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].KickoffTime.Before(*matches[j].KickoffTime)
+	})
+	return matches, nil
+}
+
+func (f *fakeLeagueDB) CreateMatch(ctx context.Context, match *models.Match) (*models.Match, error) {
+	created := *match
+	created.ID = f.nextMatchID
+	created.CreatedAt = time.Now()
+	f.nextMatchID++
+	f.matches = append(f.matches, &created)
+
+	matchCopy := created
+	return &matchCopy, nil
+}
+
+func (f *fakeLeagueDB) ReplaceScheduledMatches(ctx context.Context, leagueID int, matches []models.Match) ([]*models.Match, error) {
+	kept := f.matches[:0]
+	for _, match := range f.matches {
+		if match.LeagueID != leagueID || match.Status != "scheduled" {
+			kept = append(kept, match)
+		}
+	}
+	f.matches = kept
+
+	created := make([]*models.Match, 0, len(matches))
+	for _, match := range matches {
+		match.LeagueID = leagueID
+		createdMatch, _ := f.CreateMatch(ctx, &match)
+		created = append(created, createdMatch)
+	}
+	return created, nil
+}
+
+func (f *fakeLeagueDB) GetVenueCounts(ctx context.Context, leagueID, teamID int) (int, int, error) {
+	var home, away int
+	for _, match := range f.matches {
+		if match.LeagueID != leagueID || (match.Status != "scheduled" && match.Status != "played") {
+			continue
+		}
+		if match.HomeTeamID == teamID {
+			home++
+		}
+		if match.AwayTeamID == teamID {
+			away++
+		}
+	}
+	return home, away, nil
+}
+
+func (f *fakeLeagueDB) GetHomeAwaySplit(ctx context.Context, leagueID int) (*models.HomeAwaySplit, error) {
+	split := &models.HomeAwaySplit{}
+	for _, match := range f.matches {
+		if match.LeagueID != leagueID || match.Status != "played" {
+			continue
+		}
+		split.MatchesPlayed++
+		split.HomeGoals += *match.HomeGoals
+		split.AwayGoals += *match.AwayGoals
+		switch {
+		case *match.HomeGoals > *match.AwayGoals:
+			split.HomeWins++
+		case *match.HomeGoals < *match.AwayGoals:
+			split.AwayWins++
+		default:
+			split.Draws++
+		}
+	}
+	return split, nil
+}
+
+func (f *fakeLeagueDB) TransitionLeagueStatus(ctx context.Context, leagueID int, fromStatus, toStatus string) error {
+	league, ok := f.leagues[leagueID]
+	if !ok || league.Status != fromStatus {
+		return fmt.Errorf("no %s league found with ID %d", fromStatus, leagueID)
+	}
+	league.Status = toStatus
+	return nil
+}
+
+func (f *fakeLeagueDB) UpdateLeagueStatus(ctx context.Context, leagueID int, status string) error {
+	league, ok := f.leagues[leagueID]
+	if !ok {
+		return fmt.Errorf("no league found with ID %d", leagueID)
+	}
+	league.Status = status
+	if status == "finished" {
+		f.finishedAt[leagueID] = append(f.finishedAt[leagueID], league.CurrentWeek)
+	}
+	return nil
+}
+
+func (f *fakeLeagueDB) GetMatchesByWeekAndLeague(ctx context.Context, leagueID, week int) ([]*models.Match, error) {
+	var matches []*models.Match
+	for _, match := range f.matches {
+		if match.LeagueID == leagueID && match.Week == week {
+			matchCopy := *match
+			matches = append(matches, &matchCopy)
+		}
+	}
+	return matches, nil
+}
+
+func (f *fakeLeagueDB) GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error) {
+	var matches []*models.Match
+	for _, match := range f.matches {
+		if match.LeagueID == leagueID && (status == "" || match.Status == status) {
+			matchCopy := *match
+			matches = append(matches, &matchCopy)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Week < matches[j].Week })
+	return matches, nil
+}
+
+func (f *fakeLeagueDB) GetMatchByID(ctx context.Context, matchID int) (*models.Match, error) {
+	for _, match := range f.matches {
+		if match.ID == matchID {
+			matchCopy := *match
+			return &matchCopy, nil
+		}
+	}
+	return nil, fmt.Errorf("no rows in result set")
+}
+
+func (f *fakeLeagueDB) PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals int) error {
+	for _, match := range f.matches {
+		if match.ID == matchID {
+			now := time.Now()
+			match.HomeGoals = &homeGoals
+			match.AwayGoals = &awayGoals
+			match.Status = "played"
+			match.PlayedAt = &now
+			return nil
+		}
+	}
+	return fmt.Errorf("no match found with ID %d", matchID)
+}
+
+func (f *fakeLeagueDB) AddTeamToLeague(ctx context.Context, leagueID, teamID int) error {
+	for _, memberID := range f.members[leagueID] {
+		if memberID == teamID {
+			return fmt.Errorf("team %d is already in league %d", teamID, leagueID)
+		}
+	}
+	f.members[leagueID] = append(f.members[leagueID], teamID)
+	return nil
+}
+
+func (f *fakeLeagueDB) InitializeStanding(ctx context.Context, leagueID, teamID int) error {
+	if f.standings[leagueID] == nil {
+		f.standings[leagueID] = make(map[int]*models.Standing)
+	}
+	if f.standings[leagueID][teamID] == nil {
+		f.standings[leagueID][teamID] = &models.Standing{LeagueID: leagueID, TeamID: teamID}
+	}
+	return nil
+}
+
+func (f *fakeLeagueDB) RemoveTeamFromLeague(ctx context.Context, leagueID, teamID int) error {
+	for i, memberID := range f.members[leagueID] {
+		if memberID == teamID {
+			f.members[leagueID] = append(f.members[leagueID][:i:i], f.members[leagueID][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("team %d is not in league %d", teamID, leagueID)
+}
+
+// EditMatch only rewrites the score; the tests using it don't read standings afterwards
+func (f *fakeLeagueDB) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayGoals int) error {
+	for _, match := range f.matches {
+		if match.ID == matchID && match.Status == "played" {
+			now := time.Now()
+			match.HomeGoals = &newHomeGoals
+			match.AwayGoals = &newAwayGoals
+			match.UpdatedAt = &now
+			match.Edited = true
+			return nil
+		}
+	}
+	return fmt.Errorf("match not found or cannot be edited")
+}
+
+func (f *fakeLeagueDB) UpdateMatchStatus(ctx context.Context, matchID int, fromStatus, toStatus string) error {
+	for _, match := range f.matches {
+		if match.ID == matchID && match.Status == fromStatus {
+			match.Status = toStatus
+			return nil
+		}
+	}
+	return fmt.Errorf("no %s match found with ID %d", fromStatus, matchID)
+}
+
+func (f *fakeLeagueDB) SwapMatchVenue(ctx context.Context, matchID int) error {
+	for _, match := range f.matches {
+		if match.ID == matchID && match.Status == "scheduled" {
+			match.HomeTeamID, match.AwayTeamID = match.AwayTeamID, match.HomeTeamID
+			return nil
+		}
+	}
+	return fmt.Errorf("no scheduled match found with ID %d", matchID)
+}
+
+func (f *fakeLeagueDB) AddStandingPoints(ctx context.Context, leagueID, teamID, points int) error {
+	standing := f.standings[leagueID][teamID]
+	if standing == nil {
+		return fmt.Errorf("no standing found for team %d in league %d", teamID, leagueID)
+	}
+	standing.Points += points
+	return nil
+}
+
+func (f *fakeLeagueDB) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
+	// Mirrors the upsert: a member missing its standing gets one, a non-member is left alone, and
+	// each side is updated on its own
+	for _, memberID := range f.members[leagueID] {
+		if (memberID == homeTeamID || memberID == awayTeamID) && f.standings[leagueID][memberID] == nil {
+			f.standings[leagueID][memberID] = &models.Standing{LeagueID: leagueID, TeamID: memberID}
+		}
+	}
+	league := f.leagues[leagueID]
+	if home := f.standings[leagueID][homeTeamID]; home != nil {
+		addFakeResult(league, home, homeGoals, awayGoals)
+	}
+	if away := f.standings[leagueID][awayTeamID]; away != nil {
+		addFakeResult(league, away, awayGoals, homeGoals)
+	}
+	return nil
+}
+
+// addFakeResult adds one side's result to its standing, with the league's bonus points
+func addFakeResult(league *models.League, standing *models.Standing, scored, conceded int) {
+	standing.Played++
+	standing.GoalsFor += scored
+	standing.GoalsAgainst += conceded
+	standing.GoalDifference = standing.GoalsFor - standing.GoalsAgainst
+
+	switch {
+	case scored > conceded:
+		standing.Wins++
+		standing.Points += 3
+	case scored < conceded:
+		standing.Losses++
+	default:
+		standing.Draws++
+		standing.Points++
+	}
+
+	bonus, _ := league.Bonuses().Points(scored, conceded)
+	standing.Points += bonus
+}
+
+func (f *fakeLeagueDB) AdvanceLeagueWeek(ctx context.Context, leagueID int) error {
+	league, ok := f.leagues[leagueID]
+	if !ok {
+		return fmt.Errorf("no league found with ID %d", leagueID)
+	}
+	league.CurrentWeek++
+	return nil
+}
+
+func (f *fakeLeagueDB) GetStandings(ctx context.Context, leagueID int) ([]models.StandingWithTeam, error) {
+	var standings []models.StandingWithTeam
+	for teamID, standing := range f.standings[leagueID] {
+		standings = append(standings, models.StandingWithTeam{
+			Standing: *standing,
+			TeamName: f.teams[teamID].Name,
+		})
+	}
+
+	// Same ordering as the standings query
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalDifference != b.GoalDifference {
+			return a.GoalDifference > b.GoalDifference
+		}
+		if a.GoalsFor != b.GoalsFor {
+			return a.GoalsFor > b.GoalsFor
+		}
+		return finalTiebreakKey(f.leagues[leagueID], a.TeamID, a.TeamName) < finalTiebreakKey(f.leagues[leagueID], b.TeamID, b.TeamName)
+	})
+	return standings, nil
+}
+
+// fakeTeams returns four teams of differing strengths
+func fakeTeams() []*models.Team {
+	return []*models.Team{
+		{ID: 1, Name: "Alpha", Strength: 90},
+		{ID: 2, Name: "Bravo", Strength: 75},
+		{ID: 3, Name: "Charlie", Strength: 60},
+		{ID: 4, Name: "Delta", Strength: 45},
+	}
+}
+
+// startFakeLeague starts league 1 of the fake database through the handler
+func startFakeLeague(t *testing.T, handler *LeagueHandler) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil)
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", w.Code, w.Body.String())
+	}
+}
+
+func TestModeScore(t *testing.T) {
+	tests := []struct {
+		homeExpectancy, awayExpectancy float64
+		homeGoals, awayGoals           int
+	}{
+		{1.69, 1.31, 1, 1},
+		{2.4, 0.6, 2, 0},
+		{0.99, 3.01, 0, 3},
+		{2.0, 1.0, 2, 1},
+		{0, 0, 0, 0},
+		{-0.5, 4.75, 0, 4},
+	}
+
+	for _, tt := range tests {
+		home, away := modeScore(tt.homeExpectancy, tt.awayExpectancy)
+		if home != tt.homeGoals || away != tt.awayGoals {
+			t.Errorf("modeScore(%.2f, %.2f) = %d-%d, expected %d-%d", tt.homeExpectancy, tt.awayExpectancy, home, away, tt.homeGoals, tt.awayGoals)
+		}
+	}
+}
+
+func TestPlayAllMatchesHandler_ExpectedModeIsDeterministic(t *testing.T) {
+	var tables [][]models.StandingWithTeam
+
+	for run := 0; run < 2; run++ {
+		db := newFakeLeagueDB(fakeTeams())
+		handler := NewLeagueHandler(db)
+		startFakeLeague(t, handler)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=expected", nil)
+		w := httptest.NewRecorder()
+		handler.PlayAllMatchesHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var resp models.PlayAllMatchesResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Mode != "expected" {
+			t.Errorf("Expected mode 'expected', got %s", resp.Mode)
+		}
+		if resp.TotalMatchesPlayed != 12 {
+			t.Errorf("Expected 12 matches played, got %d", resp.TotalMatchesPlayed)
+		}
+
+		standings, _ := db.GetStandings(context.Background(), 1)
+		tables = append(tables, standings)
+	}
+
+	for i := range tables[0] {
+		if tables[0][i] != tables[1][i] {
+			t.Errorf("Expected identical standings at position %d, got %+v and %+v", i+1, tables[0][i], tables[1][i])
+		}
+	}
+}
+
+// playAllWithTemperature plays a fresh fake league to the end at a temperature and returns every match's score in ID order
+func playAllWithTemperature(t *testing.T, temperature string) []string {
+	t.Helper()
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?temperature="+temperature, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.PlayAllMatchesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Mode != "tempered" || resp.Temperature == nil {
+		t.Errorf("Expected tempered mode with a temperature, got %s and %v", resp.Mode, resp.Temperature)
+	}
+
+	scores := make([]string, 0, len(db.matches))
+	for _, match := range db.matches {
+		scores = append(scores, fmt.Sprintf("%d-%d", *match.HomeGoals, *match.AwayGoals))
+	}
+	return scores
+}
+
+func TestPlayAllMatchesHandler_ZeroTemperatureIsRepeatable(t *testing.T) {
+	first := playAllWithTemperature(t, "0")
+	second := playAllWithTemperature(t, "0")
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected identical scores at temperature 0, got %v and %v", first, second)
+	}
+}
+
+func TestPlayAllMatchesHandler_FullTemperatureVaries(t *testing.T) {
+	first := playAllWithTemperature(t, "1")
+
+	// Twelve sampled scorelines matching exactly, run after run, would be vanishingly unlikely
+	for run := 0; run < 10; run++ {
+		if !reflect.DeepEqual(first, playAllWithTemperature(t, "1")) {
+			return
+		}
+	}
+	t.Errorf("Expected scores to vary across runs at temperature 1, always got %v", first)
+}
+
+func TestTemperGoals(t *testing.T) {
+	tests := []struct {
+		expected, sampled int
+		temperature       float64
+		goals             int
+	}{
+		{1, 4, 0, 1},
+		{1, 4, 1, 4},
+		{1, 4, 0.5, 3},
+		{2, 0, 0.25, 2},
+		{2, 0, 0.75, 1},
+	}
+
+	for _, tt := range tests {
+		if got := temperGoals(tt.expected, tt.sampled, tt.temperature); got != tt.goals {
+			t.Errorf("temperGoals(%d, %d, %.2f) = %d, expected %d", tt.expected, tt.sampled, tt.temperature, got, tt.goals)
+		}
+	}
+}
+
+func TestPlayAllMatchesHandler_RecordsRun(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?temperature=0.5", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.PlayAllMatchesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(db.runs) != 1 {
+		t.Fatalf("Expected 1 recorded run, got %d", len(db.runs))
+	}
+	run := db.runs[0]
+	if resp.RunID == nil || *resp.RunID != run.ID {
+		t.Errorf("Expected run ID %d in response, got %v", run.ID, resp.RunID)
+	}
+	if run.LeagueID != 1 || run.Mode != "tempered" || run.Temperature == nil || *run.Temperature != 0.5 {
+		t.Errorf("Expected a tempered run at 0.5 for league 1, got %+v", run)
+	}
+	if run.StartingWeek != 0 || run.FinalWeek != resp.FinalWeek {
+		t.Errorf("Expected weeks 0 to %d, got %d to %d", resp.FinalWeek, run.StartingWeek, run.FinalWeek)
+	}
+	standings, _ := db.GetStandings(context.Background(), 1)
+	if run.TableHash != standingsHash(standings) {
+		t.Errorf("Expected the hash of the final table, got %s", run.TableHash)
+	}
+
+	w = httptest.NewRecorder()
+	handler.SimulationRunsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/runs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var runsResp models.SimulationRunsResponse
+	if err := json.NewDecoder(w.Body).Decode(&runsResp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(runsResp.Runs) != 1 || runsResp.Runs[0].ID != run.ID || runsResp.Runs[0].TableHash != run.TableHash {
+		t.Errorf("Expected the recorded run to be listed, got %+v", runsResp.Runs)
+	}
+}
+
+func TestPlayAllMatchesHandler_ExpectedRunsShareTableHash(t *testing.T) {
+	var hashes []string
+	for run := 0; run < 2; run++ {
+		db := newFakeLeagueDB(fakeTeams())
+		handler := NewLeagueHandler(db)
+		startFakeLeague(t, handler)
+
+		w := httptest.NewRecorder()
+		handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=expected", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if len(db.runs) != 1 || db.runs[0].Mode != "expected" || db.runs[0].Temperature != nil {
+			t.Fatalf("Expected 1 expected-mode run without a temperature, got %+v", db.runs)
+		}
+		hashes = append(hashes, db.runs[0].TableHash)
+	}
+
+	if hashes[0] != hashes[1] {
+		t.Errorf("Expected identical tables to share a hash, got %s and %s", hashes[0], hashes[1])
+	}
+}
+
+func TestSimulationRunsHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.SimulationRunsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/99/runs", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestPlayAllMatchesHandler_InvalidTemperature(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	for _, query := range []string{"temperature=1.5", "temperature=-0.1", "temperature=warm", "temperature=0.5&mode=expected"} {
+		w := httptest.NewRecorder()
+		handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestPlayAllMatchesHandler_InvalidMode(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=median", nil)
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPlayAllMatchesHandler_StrayMatchBeyondSchedule(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// A stray fixture in week 8 leaves week 7 empty, so the loop stops before reaching it
+	db.matches = append(db.matches, &models.Match{ID: db.nextMatchID, LeagueID: 1, HomeTeamID: 1, AwayTeamID: 2, Week: 8, Status: "scheduled"})
+	strayID := db.nextMatchID
+	db.nextMatchID++
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	var resp models.UnplayedMatchesErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.MatchIDs) != 1 || resp.MatchIDs[0] != strayID {
+		t.Errorf("Expected stray match %d to be reported, got %v", strayID, resp.MatchIDs)
+	}
+	if db.leagues[1].Status != "started" {
+		t.Errorf("Expected league to stay started, got %s", db.leagues[1].Status)
+	}
+}
+
+// dropFakeMatch removes the first match of a week from the fake's schedule, leaving a gap
+func dropFakeMatch(t *testing.T, db *fakeLeagueDB, week int) {
+	t.Helper()
+	for i, match := range db.matches {
+		if match.Week == week {
+			db.matches = append(db.matches[:i], db.matches[i+1:]...)
+			return
+		}
+	}
+	t.Fatalf("No match in week %d to drop", week)
+}
+
+func TestPlayAllMatchesHandler_MissingMatch(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	dropFakeMatch(t, db, 2)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "11 of 12") {
+		t.Errorf("Expected the missing match to be counted, got %q", w.Body.String())
+	}
+	if db.leagues[1].Status != "started" {
+		t.Errorf("Expected league to stay started, got %s", db.leagues[1].Status)
+	}
+}
+
+func TestAdvanceWeekHandler_MissingMatchKeepsLeagueStarted(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	dropFakeMatch(t, db, 2)
+
+	for week := 1; week <= 6; week++ {
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week %d: status %d: %s", week, w.Code, w.Body.String())
+		}
+	}
+
+	if db.leagues[1].CurrentWeek != 6 {
+		t.Errorf("Expected the league to reach week 6, got %d", db.leagues[1].CurrentWeek)
+	}
+	if db.leagues[1].Status != "started" {
+		t.Errorf("Expected league to stay started, got %s", db.leagues[1].Status)
+	}
+}
+
+// addLateFakeTeam adds a fifth team to a started fake league, after its schedule was generated
+func addLateFakeTeam(t *testing.T, db *fakeLeagueDB, handler *LeagueHandler) {
+	t.Helper()
+	db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 50}
+
+	w := httptest.NewRecorder()
+	handler.AddTeamToLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/add-team/1/5", nil))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to add team: status %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPlayAllMatchesHandler_TeamAddedAfterStart(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	addLateFakeTeam(t, db, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if db.leagues[1].Status != "finished" {
+		t.Errorf("Expected league to be finished, got %s", db.leagues[1].Status)
+	}
+}
+
+func TestAdvanceWeekHandler_TeamAddedAfterStart(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	addLateFakeTeam(t, db, handler)
+
+	for week := 1; db.leagues[1].Status == "started"; week++ {
+		if week > 10 {
+			t.Fatalf("Expected league to finish by week 10, still %s at week %d", db.leagues[1].Status, db.leagues[1].CurrentWeek)
+		}
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week %d: status %d: %s", week, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAdvanceWeeksHandler_BlocksOnUnplayedEarlierWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Week 1 was skipped without playing its matches
+	db.leagues[1].CurrentWeek = 1
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 2}`)))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d with week 1 unplayed, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	var conflict models.UnplayedMatchesErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&conflict); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(conflict.MatchIDs) != 2 {
+		t.Errorf("Expected both week 1 matches to be listed, got %v", conflict.MatchIDs)
+	}
+	if db.leagues[1].CurrentWeek != 1 {
+		t.Errorf("Expected the league to stay in week 1, got week %d", db.leagues[1].CurrentWeek)
+	}
+}
+
+func TestAdvanceWeeksHandler_MissingMatchKeepsLeagueStarted(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	dropFakeMatch(t, db, 5)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 6}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance weeks: status %d: %s", w.Code, w.Body.String())
+	}
+	if db.leagues[1].Status != "started" {
+		t.Errorf("Expected league to stay started, got %s", db.leagues[1].Status)
+	}
+}
+
+func TestPlayAllMatchesHandler_WeekCap(t *testing.T) {
+	defer func(saved int) { maxPlayAllWeeks = saved }(maxPlayAllWeeks)
+	maxPlayAllWeeks = 2
+
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+	if league := db.leagues[1]; league.CurrentWeek != 2 || league.Status != "started" {
+		t.Errorf("Expected league to stop started at week 2, got %s at week %d", league.Status, league.CurrentWeek)
+	}
+}
+
+func TestAdvanceWeeksHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 2}`))
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.AdvanceWeeksResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.League.CurrentWeek != 2 {
+		t.Errorf("Expected current week 2, got %d", resp.League.CurrentWeek)
+	}
+	if resp.League.Status != "started" {
+		t.Errorf("Expected league to still be started, got %s", resp.League.Status)
+	}
+	if resp.WeeksPlayed != 2 || len(resp.WeekResults) != 2 {
+		t.Fatalf("Expected 2 weeks played, got %d (%d results)", resp.WeeksPlayed, len(resp.WeekResults))
+	}
+	if resp.TotalMatchesPlayed != 4 {
+		t.Errorf("Expected 4 matches played, got %d", resp.TotalMatchesPlayed)
+	}
+	for i, weekResult := range resp.WeekResults {
+		if weekResult.Week != i+1 {
+			t.Errorf("Expected week %d, got %d", i+1, weekResult.Week)
+		}
+		for _, result := range weekResult.Matches {
+			if result.Match.Week != weekResult.Week || result.Match.Status != "played" {
+				t.Errorf("Expected played match in week %d, got %+v", weekResult.Week, result.Match)
+			}
+		}
+	}
+
+	league, _ := db.GetLeagueByID(context.Background(), 1)
+	if league.CurrentWeek != 2 {
+		t.Errorf("Expected stored current week 2, got %d", league.CurrentWeek)
+	}
+	remaining, _ := db.GetMatchesByLeague(context.Background(), 1, "scheduled")
+	if len(remaining) != 8 {
+		t.Errorf("Expected 8 scheduled matches remaining, got %d", len(remaining))
+	}
+}
+
+func TestAdvanceWeeksHandler_StopsAtSeasonEnd(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 10}`))
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.AdvanceWeeksResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.WeeksPlayed != 6 {
+		t.Errorf("Expected 6 weeks played, got %d", resp.WeeksPlayed)
+	}
+	if resp.League.Status != "finished" {
+		t.Errorf("Expected league to be finished, got %s", resp.League.Status)
+	}
+}
+
+func TestAdvanceWeeksHandler_InvalidCount(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 0}`))
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestBottomTeamHandler_MidSeason(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil)
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leagues/bottom/1", nil)
+	w = httptest.NewRecorder()
+	handler.BottomTeamHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.BottomTeamResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	standings, _ := db.GetStandings(context.Background(), 1)
+	if resp.Team.TeamID != standings[len(standings)-1].TeamID {
+		t.Errorf("Expected bottom team %d, got %d", standings[len(standings)-1].TeamID, resp.Team.TeamID)
+	}
+	if resp.Position != 4 {
+		t.Errorf("Expected position 4, got %d", resp.Position)
+	}
+	if resp.RemainingMatches != 5 {
+		t.Errorf("Expected 5 remaining matches, got %d", resp.RemainingMatches)
+	}
+	if resp.RelegationConfirmed {
+		t.Error("Expected relegation not to be confirmed after one week")
+	}
+}
+
+func TestBottomTeamHandler_FinishedLeague(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=expected", nil)
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to play all matches: status %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leagues/bottom/1", nil)
+	w = httptest.NewRecorder()
+	handler.BottomTeamHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.BottomTeamResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.RemainingMatches != 0 {
+		t.Errorf("Expected 0 remaining matches, got %d", resp.RemainingMatches)
+	}
+	if !resp.RelegationConfirmed {
+		t.Error("Expected relegation to be confirmed in a finished league")
+	}
+	if resp.Team.TeamName != "Delta" {
+		t.Errorf("Expected weakest team Delta at the bottom, got %s", resp.Team.TeamName)
+	}
+}
+
+// getBottomTeam fetches the fake league's bottom team
+func getBottomTeam(t *testing.T, handler *LeagueHandler) models.BottomTeamResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.BottomTeamHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/bottom/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.BottomTeamResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestBottomTeamHandler_LevelOnPointsNotConfirmed(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Delta has played all its matches and sits level on points with Charlie, who still plays
+	for _, match := range db.matches {
+		if match.HomeTeamID == 4 || match.AwayTeamID == 4 {
+			match.Status = "played"
+		}
+	}
+	db.standings[1][1].Points = 10
+	db.standings[1][2].Points = 8
+	db.standings[1][3].Points = 3
+	db.standings[1][4].Points = 3
+	db.standings[1][4].GoalDifference = -5
+
+	resp := getBottomTeam(t, handler)
+	if resp.Team.TeamName != "Delta" || resp.RemainingMatches != 0 {
+		t.Fatalf("Expected Delta at the bottom with no matches left, got %s with %d", resp.Team.TeamName, resp.RemainingMatches)
+	}
+	if resp.RelegationConfirmed {
+		t.Error("Expected relegation not to be confirmed while Charlie's results can still change the tiebreak")
+	}
+
+	// Once every match is played the table is final
+	for _, match := range db.matches {
+		match.Status = "played"
+	}
+	if resp := getBottomTeam(t, handler); !resp.RelegationConfirmed {
+		t.Error("Expected relegation to be confirmed with no matches left")
+	}
+}
+
+func TestBottomTeamHandler_MaxPointsCountBonuses(t *testing.T) {
+	tests := []struct {
+		name          string
+		configure     func(league *models.League)
+		extraTeam     bool
+		charliePoints int
+		maxPoints     int
+	}{
+		{"no bonuses", func(league *models.League) {}, false, 20, 18},
+		{"clean sheet bonus", func(league *models.League) { league.CleanSheetBonus = 1 }, false, 20, 24},
+		{"big win bonus", func(league *models.League) { league.BigWinMargin = 3; league.BigWinBonus = 1 }, false, 20, 24},
+		{"bye points", func(league *models.League) { league.ByePoints = 2 }, true, 25, 28},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeLeagueDB(fakeTeams())
+			if tt.extraTeam {
+				db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 50}
+				db.members[1] = append(db.members[1], 5)
+				db.standings[1][5] = &models.Standing{LeagueID: 1, TeamID: 5}
+			}
+			tt.configure(db.leagues[1])
+			handler := NewLeagueHandler(db)
+			startFakeLeague(t, handler)
+
+			// Delta has all its matches (and byes) to come, trailing Charlie by more than a plain
+			// three points a match could make up
+			for teamID, standing := range db.standings[1] {
+				standing.Points = 40 - 5*teamID
+			}
+			db.standings[1][3].Points = tt.charliePoints
+			db.standings[1][4].Points = 0
+			if tt.extraTeam {
+				db.standings[1][5].Points = 30
+			}
+
+			resp := getBottomTeam(t, handler)
+			if resp.Team.TeamName != "Delta" {
+				t.Fatalf("Expected Delta at the bottom, got %s", resp.Team.TeamName)
+			}
+			if resp.MaxPossiblePoints != tt.maxPoints {
+				t.Errorf("Expected max possible points %d, got %d", tt.maxPoints, resp.MaxPossiblePoints)
+			}
+			if confirmed := tt.maxPoints < tt.charliePoints; resp.RelegationConfirmed != confirmed {
+				t.Errorf("Expected relegation confirmed %v, got %v", confirmed, resp.RelegationConfirmed)
+			}
+		})
+	}
+}
+
+func TestMatchStrengths_OneTeamMissing(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	homeStrength, awayStrength, known := handler.matchStrengths(1, 99)
+	if !known {
+		t.Fatal("Expected strengths to be known when one team exists")
+	}
+	if homeStrength != 90 {
+		t.Errorf("Expected home strength 90, got %d", homeStrength)
+	}
+	if awayStrength != neutralStrength {
+		t.Errorf("Expected neutral away strength %d, got %d", neutralStrength, awayStrength)
+	}
+
+	homeStrength, awayStrength, known = handler.matchStrengths(99, 2)
+	if !known {
+		t.Fatal("Expected strengths to be known when one team exists")
+	}
+	if homeStrength != neutralStrength || awayStrength != 75 {
+		t.Errorf("Expected strengths %d and 75, got %d and %d", neutralStrength, homeStrength, awayStrength)
+	}
+}
+
+func TestMatchStrengths_BothTeamsMissing(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	homeStrength, awayStrength, known := handler.matchStrengths(98, 99)
+	if known {
+		t.Error("Expected strengths to be unknown when neither team exists")
+	}
+	if homeStrength != neutralStrength || awayStrength != neutralStrength {
+		t.Errorf("Expected neutral strengths, got %d and %d", homeStrength, awayStrength)
+	}
+
+	for i := 0; i < 20; i++ {
+		homeGoals, awayGoals := handler.generateMatchResult(98, 99, simulationSettings{})
+		if homeGoals < 0 || homeGoals > 5 || awayGoals < 0 || awayGoals > 5 {
+			t.Fatalf("Expected random fallback goals between 0 and 5, got %d-%d", homeGoals, awayGoals)
+		}
+	}
+}
+
+func TestRegenerateScheduleHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	regenerate := func() (*httptest.ResponseRecorder, models.RegenerateScheduleResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.RegenerateScheduleHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/1/regenerate-schedule", nil))
+		var resp models.RegenerateScheduleResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	w, resp := regenerate()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if resp.MatchesCount != 12 || len(db.matches) != 12 {
+		t.Fatalf("Expected 12 fixtures for 4 teams, got %d (%d stored)", resp.MatchesCount, len(db.matches))
+	}
+
+	// A fifth team joins before the start: the old fixtures are replaced, not added to
+	db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 30}
+	db.members[1] = append(db.members[1], 5)
+
+	w, resp = regenerate()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if resp.TeamsCount != 5 || resp.MatchesCount != 20 || resp.TotalWeeks != 10 {
+		t.Errorf("Expected 20 fixtures for 5 teams over 10 weeks, got %+v", resp)
+	}
+	if len(db.matches) != 20 {
+		t.Errorf("Expected 20 stored fixtures, got %d", len(db.matches))
+	}
+
+	// Starting the league builds its schedule afresh rather than duplicating it
+	startFakeLeague(t, handler)
+	if len(db.matches) != 20 {
+		t.Errorf("Expected 20 fixtures after the start, got %d", len(db.matches))
+	}
+
+	w, _ = regenerate()
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for a started league, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestVenueBalanceHandler(t *testing.T) {
+	getBalance := func(handler *LeagueHandler, teamID int) models.VenueBalanceResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.VenueBalanceHandler(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/leagues/1/teams/%d/venue-balance", teamID), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.VenueBalanceResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	// The generated double round-robin gives every team three home and three away fixtures
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	for teamID := 1; teamID <= 4; teamID++ {
+		resp := getBalance(handler, teamID)
+		if resp.HomeFixtures != 3 || resp.AwayFixtures != 3 || resp.Uneven {
+			t.Errorf("Expected team %d to have a balanced 3/3 split, got %+v", teamID, resp)
+		}
+	}
+
+	// Alpha hosting three of its four fixtures is uneven
+	db = newFakeLeagueDB(fakeTeams())
+	handler = NewLeagueHandler(db)
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	addPlayedFakeMatch(db, 2, 1, 3, 2, 2)
+	addPlayedFakeMatch(db, 3, 1, 4, 0, 1)
+	addPlayedFakeMatch(db, 4, 2, 1, 1, 1)
+	resp := getBalance(handler, 1)
+	if resp.HomeFixtures != 3 || resp.AwayFixtures != 1 || !resp.Uneven {
+		t.Errorf("Expected an uneven 3/1 split for Alpha, got %+v", resp)
+	}
+
+	w := httptest.NewRecorder()
+	handler.VenueBalanceHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/99/venue-balance", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a team outside the league, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestRemainingFixturesHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Mark the first week as played so only later fixtures remain
+	for _, match := range db.matches {
+		if match.Week == 1 {
+			match.Status = "played"
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.RemainingFixturesHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/1/remaining", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.RemainingFixturesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Fixtures) != 5 {
+		t.Fatalf("Expected 5 remaining fixtures for Alpha, got %d", len(resp.Fixtures))
+	}
+	for i, fixture := range resp.Fixtures {
+		if fixture.Week == 1 {
+			t.Errorf("Expected played week 1 to be excluded, got %+v", fixture)
+		}
+		if i > 0 && fixture.Week < resp.Fixtures[i-1].Week {
+			t.Errorf("Expected fixtures ordered by week, got week %d after %d", fixture.Week, resp.Fixtures[i-1].Week)
+		}
+		match, err := db.GetMatchByID(context.Background(), fixture.MatchID)
+		if err != nil {
+			t.Fatalf("Unknown match %d: %v", fixture.MatchID, err)
+		}
+		if match.Status != "scheduled" {
+			t.Errorf("Expected only scheduled matches, got %s match %d", match.Status, match.ID)
+		}
+		switch fixture.Venue {
+		case "home":
+			if match.HomeTeamID != 1 || match.AwayTeamID != fixture.OpponentID {
+				t.Errorf("Home fixture %+v does not match %+v", fixture, match)
+			}
+		case "away":
+			if match.AwayTeamID != 1 || match.HomeTeamID != fixture.OpponentID {
+				t.Errorf("Away fixture %+v does not match %+v", fixture, match)
+			}
+		default:
+			t.Errorf("Unexpected venue %q", fixture.Venue)
+		}
+		if fixture.OpponentName == "" {
+			t.Errorf("Expected opponent name for fixture %+v", fixture)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handler.RemainingFixturesHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/99/remaining", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a team outside the league, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestNextFixtureHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.NextFixtureHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/1/next", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.NextFixtureResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	fixture := resp.Fixture
+	if fixture.Week != 2 {
+		t.Errorf("Expected Alpha's next fixture in week 2, got week %d", fixture.Week)
+	}
+	match, err := db.GetMatchByID(context.Background(), fixture.MatchID)
+	if err != nil {
+		t.Fatalf("Unknown match %d: %v", fixture.MatchID, err)
+	}
+	switch {
+	case fixture.Venue == "home" && match.HomeTeamID == 1 && match.AwayTeamID == fixture.OpponentID:
+	case fixture.Venue == "away" && match.AwayTeamID == 1 && match.HomeTeamID == fixture.OpponentID:
+	default:
+		t.Errorf("Fixture %+v does not match %+v", fixture, match)
+	}
+	if fixture.OpponentName != db.teams[fixture.OpponentID].Name {
+		t.Errorf("Expected opponent name %s, got %s", db.teams[fixture.OpponentID].Name, fixture.OpponentName)
+	}
+}
+
+func TestNextFixtureHandler_NoRemainingFixtures(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	for _, match := range db.matches {
+		if match.HomeTeamID == 1 || match.AwayTeamID == 1 {
+			match.Status = "played"
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.NextFixtureHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/1/next", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestViewMatchesHandler_PartlyPlayedWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	db.leagues[1].CurrentWeek = 1
+
+	// Play one of week 1's two matches and leave the other scheduled
+	var playedID, scheduledID int
+	for _, match := range db.matches {
+		if match.Week != 1 {
+			continue
+		}
+		if playedID == 0 {
+			db.PlayMatch(context.Background(), match.ID, 2, 1)
+			playedID = match.ID
+		} else {
+			scheduledID = match.ID
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ViewMatchesHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/view-matches/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.ViewMatchesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Matches) != 2 {
+		t.Errorf("Expected 2 matches in the week, got %d", len(resp.Matches))
+	}
+	if len(resp.PlayedMatches) != 1 || resp.PlayedMatches[0].Match.ID != playedID || resp.PlayedMatches[0].Result != "2-1" {
+		t.Errorf("Expected match %d played 2-1, got %+v", playedID, resp.PlayedMatches)
+	}
+	if len(resp.ScheduledMatches) != 1 || resp.ScheduledMatches[0].Match.ID != scheduledID || resp.ScheduledMatches[0].Result != "Not played yet" {
+		t.Errorf("Expected match %d still scheduled, got %+v", scheduledID, resp.ScheduledMatches)
+	}
+}
+
+func TestTeamMatchesByVenueHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.TeamMatchesByVenueHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/2/by-venue", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.TeamMatchesByVenueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// A double round-robin of four teams hosts each opponent once
+	if len(resp.Home) != 3 || len(resp.Away) != 3 {
+		t.Fatalf("Expected 3 home and 3 away matches, got %d and %d", len(resp.Home), len(resp.Away))
+	}
+	for venue, matches := range map[string][]models.MatchResult{"home": resp.Home, "away": resp.Away} {
+		opponents := make(map[int]bool)
+		for i, match := range matches {
+			if venue == "home" && match.Match.HomeTeamID != 2 || venue == "away" && match.Match.AwayTeamID != 2 {
+				t.Errorf("Expected match %d in the %s list to have Bravo %s", match.Match.ID, venue, venue)
+			}
+			if i > 0 && match.Match.Week < matches[i-1].Match.Week {
+				t.Errorf("Expected %s matches in week order, got week %d after %d", venue, match.Match.Week, matches[i-1].Match.Week)
+			}
+			if match.Match.Week == 1 && match.Result == "Not played yet" {
+				t.Errorf("Expected week 1 match %d to have a result", match.Match.ID)
+			}
+			opponents[match.Match.HomeTeamID+match.Match.AwayTeamID-2] = true
+		}
+		if len(opponents) != 3 {
+			t.Errorf("Expected 3 different %s opponents, got %v", venue, opponents)
+		}
+	}
+}
+
+func TestTeamPositionHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Zones = models.LeagueZones{ChampionSpots: 1, RelegationSpots: 1}
+	handler := NewLeagueHandler(db)
+
+	// Alpha 9, Charlie 7, Bravo 4, Delta 1
+	for teamID, points := range map[int]int{1: 9, 2: 4, 3: 7, 4: 1} {
+		db.standings[1][teamID].Points = points
+	}
+
+	getPosition := func(teamID int) models.TeamPositionResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.TeamPositionHandler(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/leagues/1/teams/%d/position", teamID), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.TeamPositionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	resp := getPosition(2)
+	if resp.Team.ID != 2 || resp.Position != 3 || resp.Zone != "mid-table" {
+		t.Errorf("Expected Bravo third and mid-table, got %+v", resp)
+	}
+	if resp.PointsBehindLeader != 5 {
+		t.Errorf("Expected 5 points behind the leader, got %d", resp.PointsBehindLeader)
+	}
+	if resp.PointsAboveRelegation == nil || *resp.PointsAboveRelegation != 3 {
+		t.Errorf("Expected 3 points above relegation, got %v", resp.PointsAboveRelegation)
+	}
+
+	resp = getPosition(1)
+	if resp.Position != 1 || resp.PointsBehindLeader != 0 {
+		t.Errorf("Expected Alpha to lead, got %+v", resp)
+	}
+
+	resp = getPosition(4)
+	if resp.Zone != "relegation" || resp.PointsAboveRelegation == nil || *resp.PointsAboveRelegation != 0 {
+		t.Errorf("Expected Delta in the relegation zone, got %+v", resp)
+	}
+}
+
+func TestTeamPositionHandler_NoRelegation(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.TeamPositionHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/1/position", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp models.TeamPositionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.PointsAboveRelegation != nil {
+		t.Errorf("Expected no relegation gap, got %d", *resp.PointsAboveRelegation)
+	}
+}
+
+func TestTeamPositionHandler_TeamNotInLeague(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.TeamPositionHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/99/position", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHomeAwaySplitHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	addPlayedFakeMatch(db, 1, 1, 2, 2, 0)
+	addPlayedFakeMatch(db, 1, 3, 4, 1, 1)
+	addPlayedFakeMatch(db, 2, 2, 3, 0, 3)
+	addPlayedFakeMatch(db, 2, 4, 1, 2, 1)
+	// Neither a scheduled match nor another league's match counts
+	db.matches = append(db.matches,
+		&models.Match{ID: 100, LeagueID: 1, HomeTeamID: 1, AwayTeamID: 3, Week: 3, Status: "scheduled"},
+		&models.Match{ID: 101, LeagueID: 2, HomeTeamID: 1, AwayTeamID: 3, Week: 1, Status: "played", HomeGoals: new(int), AwayGoals: new(int)},
+	)
+
+	w := httptest.NewRecorder()
+	handler.HomeAwaySplitHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/home-away-split", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.HomeAwaySplitResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	expected := models.HomeAwaySplit{MatchesPlayed: 4, HomeWins: 2, AwayWins: 1, Draws: 1, HomeGoals: 5, AwayGoals: 5}
+	if resp.HomeAwaySplit != expected {
+		t.Errorf("Expected %+v, got %+v", expected, resp.HomeAwaySplit)
+	}
+	if resp.HomeWinPercent != 50 {
+		t.Errorf("Expected a 50%% home win rate, got %.1f", resp.HomeWinPercent)
+	}
+}
+
+func TestHomeAwaySplitHandler_NoPlayedMatches(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.HomeAwaySplitHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/home-away-split", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.HomeAwaySplitResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.MatchesPlayed != 0 || resp.HomeWinPercent != 0 {
+		t.Errorf("Expected an empty split, got %+v", resp)
+	}
+}
+
+func TestStrengthOfScheduleHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Leave one Alpha v Bravo and one Charlie v Delta fixture to play
+	left := make(map[[2]int]bool)
+	for _, match := range db.matches {
+		pair := [2]int{min(match.HomeTeamID, match.AwayTeamID), max(match.HomeTeamID, match.AwayTeamID)}
+		if (pair == [2]int{1, 2} || pair == [2]int{3, 4}) && !left[pair] {
+			left[pair] = true
+			continue
+		}
+		match.Status = "played"
+	}
+
+	w := httptest.NewRecorder()
+	handler.StrengthOfScheduleHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/strength-of-schedule", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.StrengthOfScheduleResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Bravo still faces Alpha (90), Alpha faces Bravo (75), Delta faces Charlie (60), Charlie faces Delta (45)
+	expected := []struct {
+		name    string
+		average float64
+	}{{"Bravo", 90}, {"Alpha", 75}, {"Delta", 60}, {"Charlie", 45}}
+	if len(resp.Teams) != len(expected) {
+		t.Fatalf("Expected %d teams, got %d", len(expected), len(resp.Teams))
+	}
+	for i, want := range expected {
+		got := resp.Teams[i]
+		if got.TeamName != want.name || got.AverageOpponentStrength != want.average || got.RemainingMatches != 1 {
+			t.Errorf("Position %d: expected %s averaging %.0f over 1 match, got %+v", i+1, want.name, want.average, got)
+		}
+	}
+}
+
+func TestStrengthOfScheduleHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.StrengthOfScheduleHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/99/strength-of-schedule", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestEditMatchHandler_EditedFlag(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// A freshly played match is not marked as edited
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var advanced models.AdvanceWeekResponse
+	if err := json.NewDecoder(w.Body).Decode(&advanced); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(advanced.MatchesPlayed) == 0 {
+		t.Fatal("Expected matches to be played")
+	}
+	played := advanced.MatchesPlayed[0]
+	if played.Edited || played.Match.Edited {
+		t.Errorf("Expected a freshly played match not to be edited, got %+v", played)
+	}
+
+	// Correcting the result marks it as edited
+	body := fmt.Sprintf(`{"home_goals": %d, "away_goals": %d}`, *played.Match.HomeGoals+1, *played.Match.AwayGoals)
+	w = httptest.NewRecorder()
+	handler.EditMatchHandler(w, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/leagues/edit-match/%d", played.Match.ID), strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var edited models.EditMatchResponse
+	if err := json.NewDecoder(w.Body).Decode(&edited); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !edited.Match.Edited || !edited.Match.Match.Edited || edited.Match.Match.UpdatedAt == nil {
+		t.Errorf("Expected the edited match to be flagged, got %+v", edited.Match)
+	}
+}
+
+func TestCompareTeamsHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	ctx := context.Background()
+
+	for _, m := range []struct{ week, home, away, homeGoals, awayGoals int }{
+		{1, 1, 2, 2, 0}, // Alpha 2-0 Bravo
+		{1, 3, 4, 1, 1}, // Charlie 1-1 Delta
+		{2, 3, 1, 0, 3}, // Charlie 0-3 Alpha
+		{2, 4, 2, 2, 1}, // Delta 2-1 Bravo
+		{3, 2, 1, 2, 1}, // Bravo 2-1 Alpha
+	} {
+		addPlayedFakeMatch(db, m.week, m.home, m.away, m.homeGoals, m.awayGoals)
+		if err := db.UpdateStandings(ctx, 1, m.home, m.away, m.homeGoals, m.awayGoals); err != nil {
+			t.Fatalf("Failed to update standings: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.CompareTeamsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/compare?team1=1&team2=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.TeamComparisonResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	alpha := resp.Team1
+	if alpha.Team.ID != 1 || alpha.Position != 1 || alpha.Points != 6 || alpha.GoalsFor != 6 || alpha.GoalsAgainst != 2 {
+		t.Errorf("Unexpected Alpha stats: %+v", alpha)
+	}
+	if got := strings.Join(alpha.Form, ""); got != "WWL" {
+		t.Errorf("Expected Alpha form WWL, got %s", got)
+	}
+	if expected := (models.HeadToHeadRecord{Played: 1, Wins: 1, GoalsFor: 2}); alpha.Home != expected {
+		t.Errorf("Expected Alpha home record %+v, got %+v", expected, alpha.Home)
+	}
+	if expected := (models.HeadToHeadRecord{Played: 2, Wins: 1, Losses: 1, GoalsFor: 4, GoalsAgainst: 2}); alpha.Away != expected {
+		t.Errorf("Expected Alpha away record %+v, got %+v", expected, alpha.Away)
+	}
+
+	bravo := resp.Team2
+	if bravo.Team.ID != 2 || bravo.Position != 3 || bravo.Points != 3 || bravo.GoalsFor != 3 || bravo.GoalsAgainst != 5 {
+		t.Errorf("Unexpected Bravo stats: %+v", bravo)
+	}
+	if got := strings.Join(bravo.Form, ""); got != "LLW" {
+		t.Errorf("Expected Bravo form LLW, got %s", got)
+	}
+	if expected := (models.HeadToHeadRecord{Played: 2, Losses: 2, GoalsFor: 1, GoalsAgainst: 4}); bravo.Away != expected {
+		t.Errorf("Expected Bravo away record %+v, got %+v", expected, bravo.Away)
+	}
+
+	if expected := (models.HeadToHeadRecord{Played: 2, Wins: 1, Losses: 1, GoalsFor: 3, GoalsAgainst: 2}); resp.HeadToHead != expected {
+		t.Errorf("Expected head-to-head %+v, got %+v", expected, resp.HeadToHead)
+	}
+
+	for path, status := range map[string]int{
+		"/api/leagues/1/compare?team1=1&team2=99": http.StatusNotFound,
+		"/api/leagues/1/compare?team1=1&team2=1":  http.StatusBadRequest,
+		"/api/leagues/1/compare?team1=1":          http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		handler.CompareTeamsHandler(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != status {
+			t.Errorf("Expected status %d for %s, got %d", status, path, w.Code)
+		}
+	}
+}
+
+func TestPauseAndResumeLeague(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	post := func(serve http.HandlerFunc, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serve(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	// Only a started league can be paused
+	if w := post(handler.PauseLeagueHandler, "/api/leagues/1/pause"); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d pausing a created league, got %d", http.StatusConflict, w.Code)
+	}
+
+	startFakeLeague(t, handler)
+
+	w := post(handler.PauseLeagueHandler, "/api/leagues/1/pause")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.LeagueStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.League.Status != "paused" {
+		t.Errorf("Expected status paused, got %s", resp.League.Status)
+	}
+
+	// A paused league can't advance or play its matches
+	if w := post(handler.AdvanceWeekHandler, "/api/leagues/advance-week/1"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d advancing a paused league, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := post(handler.PlayAllMatchesHandler, "/api/leagues/play-all-matches/1"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d playing a paused league, got %d", http.StatusBadRequest, w.Code)
+	}
+	if db.leagues[1].CurrentWeek != 0 {
+		t.Errorf("Expected no weeks played while paused, got current week %d", db.leagues[1].CurrentWeek)
+	}
+	if w := post(handler.PauseLeagueHandler, "/api/leagues/1/pause"); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d pausing a paused league, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Resuming restores advancing
+	if w := post(handler.ResumeLeagueHandler, "/api/leagues/1/resume"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d resuming, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := post(handler.AdvanceWeekHandler, "/api/leagues/advance-week/1"); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d advancing a resumed league, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := post(handler.ResumeLeagueHandler, "/api/leagues/1/resume"); w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d resuming a started league, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestCloneLeagueHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1", nil)
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to play all matches: status %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/leagues/1/clone", strings.NewReader(`{"name": "Season Two"}`))
+	w = httptest.NewRecorder()
+	handler.CloneLeagueHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp models.CloneLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.League.ID == 1 || resp.League.Name != "Season Two" {
+		t.Errorf("Expected a new league named Season Two, got %+v", resp.League)
+	}
+	if resp.League.Status != "created" || resp.League.CurrentWeek != 0 {
+		t.Errorf("Expected fresh league status, got %s at week %d", resp.League.Status, resp.League.CurrentWeek)
+	}
+	if resp.SourceLeagueID != 1 {
+		t.Errorf("Expected source league 1, got %d", resp.SourceLeagueID)
+	}
+
+	sourceTeams, _ := db.GetTeamsInLeague(context.Background(), 1)
+	if len(resp.Teams) != len(sourceTeams) {
+		t.Fatalf("Expected %d teams, got %d", len(sourceTeams), len(resp.Teams))
+	}
+	for i, team := range sourceTeams {
+		if resp.Teams[i].ID != team.ID {
+			t.Errorf("Expected team %d at index %d, got %d", team.ID, i, resp.Teams[i].ID)
+		}
+	}
+
+	standings, _ := db.GetStandings(context.Background(), resp.League.ID)
+	if len(standings) != len(sourceTeams) {
+		t.Fatalf("Expected %d standings, got %d", len(sourceTeams), len(standings))
+	}
+	for _, standing := range standings {
+		if standing.Points != 0 || standing.Played != 0 || standing.GoalsFor != 0 {
+			t.Errorf("Expected zeroed standing, got %+v", standing.Standing)
+		}
+	}
+
+	matches, _ := db.GetMatchesByLeague(context.Background(), resp.League.ID, "")
+	if len(matches) != 0 {
+		t.Errorf("Expected no matches in the clone, got %d", len(matches))
+	}
+}
+
+func TestCloneLeagueHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/999/clone", strings.NewReader(`{"name": "Season Two"}`))
+	w := httptest.NewRecorder()
+	handler.CloneLeagueHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestSwapVenueHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	original, _ := db.GetMatchByID(context.Background(), 1)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/matches/1/swap-venue", nil)
+	w := httptest.NewRecorder()
+	handler.SwapVenueHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.MatchUpdateResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Match.Match.HomeTeamID != original.AwayTeamID || resp.Match.Match.AwayTeamID != original.HomeTeamID {
+		t.Errorf("Expected teams swapped from %d v %d, got %d v %d",
+			original.HomeTeamID, original.AwayTeamID, resp.Match.Match.HomeTeamID, resp.Match.Match.AwayTeamID)
+	}
+
+	stored, _ := db.GetMatchByID(context.Background(), 1)
+	if stored.HomeTeamID != original.AwayTeamID || stored.Status != "scheduled" {
+		t.Errorf("Expected stored match swapped and still scheduled, got %+v", stored)
+	}
+}
+
+func TestSwapVenueHandler_PlayedMatch(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil)
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	original, _ := db.GetMatchByID(context.Background(), 1)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/matches/1/swap-venue", nil)
+	w = httptest.NewRecorder()
+	handler.SwapVenueHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	stored, _ := db.GetMatchByID(context.Background(), 1)
+	if stored.HomeTeamID != original.HomeTeamID {
+		t.Error("Expected played match to be left unchanged")
+	}
+}
+
+func TestAdvanceWeekHandler_WeekNumbering(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil)
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, req)
+
+	var startResp models.StartLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&startResp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if startResp.League.CurrentWeek != 0 || startResp.League.NextWeek != 1 {
+		t.Errorf("Expected a started league at week 0 with next week 1, got %d and %d",
+			startResp.League.CurrentWeek, startResp.League.NextWeek)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil)
+	w = httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, req)
+
+	var resp models.AdvanceWeekResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.WeekAdvanced != 1 {
+		t.Errorf("Expected the first advance to play week 1, got %d", resp.WeekAdvanced)
+	}
+	if resp.League.CurrentWeek != 1 || resp.League.NextWeek != 2 {
+		t.Errorf("Expected current week 1 and next week 2, got %d and %d", resp.League.CurrentWeek, resp.League.NextWeek)
+	}
+	for _, result := range resp.MatchesPlayed {
+		if result.Match.Week != 1 {
+			t.Errorf("Expected only week 1 matches, got week %d", result.Match.Week)
+		}
+	}
+
+	// The last advance finishes the league and leaves no next week
+	for week := 2; week <= 6; week++ {
+		w = httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	}
+
+	var lastResp models.AdvanceWeekResponse
+	if err := json.NewDecoder(w.Body).Decode(&lastResp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if lastResp.WeekAdvanced != 6 || lastResp.League.Status != "finished" {
+		t.Errorf("Expected week 6 to finish the league, got week %d with status %s", lastResp.WeekAdvanced, lastResp.League.Status)
+	}
+	if lastResp.League.NextWeek != 0 {
+		t.Errorf("Expected no next week for a finished league, got %d", lastResp.League.NextWeek)
+	}
+}
+
+func TestAdvanceWeekHandler_OneBasedWeekNumbering(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"week_numbering": "one_based"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil))
+
+	var startResp models.StartLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&startResp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if startResp.League.CurrentWeek != 1 || startResp.League.NextWeek != 1 {
+		t.Errorf("Expected a started league at week 1 with next week 1, got %d and %d",
+			startResp.League.CurrentWeek, startResp.League.NextWeek)
+	}
+
+	w = httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+
+	var resp models.AdvanceWeekResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// The numbering only changes what is reported, not which week is played
+	if resp.WeekAdvanced != 1 {
+		t.Errorf("Expected the first advance to play week 1, got %d", resp.WeekAdvanced)
+	}
+	if resp.League.CurrentWeek != 2 || resp.League.NextWeek != 2 {
+		t.Errorf("Expected current week 2 and next week 2, got %d and %d", resp.League.CurrentWeek, resp.League.NextWeek)
+	}
+
+	w = httptest.NewRecorder()
+	handler.LeagueProgressHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/progress", nil))
+
+	var progress models.LeagueProgressResponse
+	if err := json.NewDecoder(w.Body).Decode(&progress); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if progress.CurrentWeek != 2 || progress.WeeksRemaining != 5 {
+		t.Errorf("Expected progress at week 2 with 5 weeks remaining, got %d and %d", progress.CurrentWeek, progress.WeeksRemaining)
+	}
+
+	// A finished league reports its final week rather than one past it
+	for week := 2; week <= 6; week++ {
+		w = httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	}
+
+	var lastResp models.AdvanceWeekResponse
+	if err := json.NewDecoder(w.Body).Decode(&lastResp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if lastResp.WeekAdvanced != 6 || lastResp.League.Status != "finished" {
+		t.Errorf("Expected week 6 to finish the league, got week %d with status %s", lastResp.WeekAdvanced, lastResp.League.Status)
+	}
+	if lastResp.League.CurrentWeek != 6 || lastResp.League.NextWeek != 0 {
+		t.Errorf("Expected a finished league at week 6 with no next week, got %d and %d", lastResp.League.CurrentWeek, lastResp.League.NextWeek)
+	}
+
+	w = httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"week_numbering": "two_based"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown week numbering, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+// cancelFakeMatch cancels a match through the handler, failing the test on error
+func cancelFakeMatch(t *testing.T, handler *LeagueHandler, matchID int) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/cancel", matchID), nil)
+	w := httptest.NewRecorder()
+	handler.CancelMatchHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to cancel match %d: status %d, body %s", matchID, w.Code, w.Body.String())
+	}
+}
+
+func TestListCancelledMatches(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	cancelFakeMatch(t, handler, 3)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/cancelled", nil)
+	w := httptest.NewRecorder()
+	handler.ListMatchesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.LeagueMatchesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Status != "cancelled" {
+		t.Errorf("Expected status filter 'cancelled', got %s", resp.Status)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].Match.ID != 3 {
+		t.Fatalf("Expected only cancelled match 3, got %+v", resp.Matches)
+	}
+}
+
+func TestReinstateMatchHandler_FutureWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Cancel a week 2 match, then play week 1
+	weekTwo, _ := db.GetMatchesByWeekAndLeague(context.Background(), 1, 2)
+	matchID := weekTwo[0].ID
+	cancelFakeMatch(t, handler, matchID)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/reinstate", matchID), nil)
+	w = httptest.NewRecorder()
+	handler.ReinstateMatchHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.MatchUpdateResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Match.Match.Status != "scheduled" {
+		t.Errorf("Expected reinstated match to be scheduled, got %s", resp.Match.Match.Status)
+	}
+}
+
+func TestReinstateMatchHandler_PastWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Cancel a week 1 match, then play week 1 without it
+	weekOne, _ := db.GetMatchesByWeekAndLeague(context.Background(), 1, 1)
+	matchID := weekOne[0].ID
+	cancelFakeMatch(t, handler, matchID)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/reinstate", matchID), nil)
+	w = httptest.NewRecorder()
+	handler.ReinstateMatchHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	stored, _ := db.GetMatchByID(context.Background(), matchID)
+	if stored.Status != "cancelled" {
+		t.Errorf("Expected match to stay cancelled, got %s", stored.Status)
+	}
+}
+
+func TestVerifyStandingsHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 3}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance weeks: status %d", w.Code)
+	}
+
+	verify := func() models.VerifyStandingsResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/verify", nil)
+		w := httptest.NewRecorder()
+		handler.VerifyStandingsHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var resp models.VerifyStandingsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	resp := verify()
+	if !resp.Consistent || len(resp.Discrepancies) != 0 {
+		t.Fatalf("Expected consistent standings, got %+v", resp.Discrepancies)
+	}
+	if resp.MatchesChecked != 6 {
+		t.Errorf("Expected 6 matches checked, got %d", resp.MatchesChecked)
+	}
+
+	// Inject a deliberate mismatch
+	db.standings[1][2].Points += 2
+	want := db.standings[1][2].Points
+
+	resp = verify()
+	if resp.Consistent {
+		t.Fatal("Expected inconsistent standings after tampering")
+	}
+	if len(resp.Discrepancies) != 1 {
+		t.Fatalf("Expected 1 discrepancy, got %+v", resp.Discrepancies)
+	}
+	d := resp.Discrepancies[0]
+	if d.TeamID != 2 || d.TeamName != "Bravo" || d.Field != "points" || d.Stored != want || d.Expected != want-2 {
+		t.Errorf("Unexpected discrepancy %+v", d)
+	}
+
+	// Verification is read-only
+	if db.standings[1][2].Points != want {
+		t.Error("Expected stored standings to be left unchanged")
+	}
+}
+
+func TestAssignZones(t *testing.T) {
+	standings := make([]models.StandingWithTeam, 6)
+	for i := range standings {
+		standings[i] = models.StandingWithTeam{Standing: models.Standing{TeamID: i + 1}}
+	}
+
+	rows := assignZones(standings, models.LeagueZones{ChampionSpots: 1, PromotionSpots: 2, RelegationSpots: 2})
+
+	expected := []string{"champion", "promotion", "promotion", "mid-table", "relegation", "relegation"}
+	for i, row := range rows {
+		if row.Position != i+1 {
+			t.Errorf("Expected position %d, got %d", i+1, row.Position)
+		}
+		if row.Zone != expected[i] {
+			t.Errorf("Expected position %d in zone %s, got %s", i+1, expected[i], row.Zone)
+		}
+	}
+
+	// Upper zones take precedence when thresholds overlap in a small league
+	rows = assignZones(standings[:3], models.LeagueZones{ChampionSpots: 1, PromotionSpots: 1, RelegationSpots: 2})
+	expected = []string{"champion", "promotion", "relegation"}
+	for i, row := range rows {
+		if row.Zone != expected[i] {
+			t.Errorf("Expected position %d in zone %s, got %s", i+1, expected[i], row.Zone)
+		}
+	}
+}
+
+func TestStandingsHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Zones = models.LeagueZones{ChampionSpots: 1, PromotionSpots: 1, RelegationSpots: 1}
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=expected", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to play all matches: status %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings", nil)
+	w = httptest.NewRecorder()
+	handler.StandingsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.StandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.League.Zones.RelegationSpots != 1 {
+		t.Errorf("Expected league zones in response, got %+v", resp.League.Zones)
+	}
+	if len(resp.Standings) != 4 {
+		t.Fatalf("Expected 4 standings rows, got %d", len(resp.Standings))
+	}
+
+	expected := []struct {
+		team string
+		zone string
+	}{
+		{"Alpha", "champion"},
+		{"Bravo", "promotion"},
+		{"Charlie", "mid-table"},
+		{"Delta", "relegation"},
+	}
+	for i, row := range resp.Standings {
+		if row.TeamName != expected[i].team || row.Zone != expected[i].zone {
+			t.Errorf("Expected %s in %s at position %d, got %s in %s", expected[i].team, expected[i].zone, i+1, row.TeamName, row.Zone)
+		}
+	}
+}
+
+func TestStandingsHandler_AsOfCountsByePoints(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.teams[5] = &models.Team{ID: 5, Name: "Echo", Strength: 50}
+	db.members[1] = append(db.members[1], 5)
+	db.standings[1][5] = &models.Standing{LeagueID: 1, TeamID: 5}
+	db.leagues[1].ByePoints = 1
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?first_kickoff=2024-08-03T15:00:00Z", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", w.Code, w.Body.String())
+	}
+	for week := 1; week <= 2; week++ {
+		w = httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week %d: status %d", week, w.Code)
+		}
+	}
+
+	getPoints := func(asOf string) map[int]models.StandingRow {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings?as_of="+asOf, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.StandingsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		rows := make(map[int]models.StandingRow)
+		for _, row := range resp.Standings {
+			rows[row.TeamID] = row
+		}
+		return rows
+	}
+
+	// Just before now the rebuilt table matches the stored one, byes included
+	current, _ := db.GetStandings(context.Background(), 1)
+	rows := getPoints(time.Now().UTC().Add(-time.Second).Format(time.RFC3339))
+	for _, standing := range current {
+		if rows[standing.TeamID].Points != standing.Points {
+			t.Errorf("Expected %s on %d points, got %d", standing.TeamName, standing.Points, rows[standing.TeamID].Points)
+		}
+	}
+
+	// Only week 1's bye counts the day before week 2 kicks off
+	rows = getPoints("2024-08-09")
+	byes := 0
+	for _, row := range rows {
+		if row.Played == 0 {
+			byes++
+			if row.Points != 1 {
+				t.Errorf("Expected %s to have 1 bye point after week 1, got %d", row.TeamName, row.Points)
+			}
+		}
+	}
+	if byes != 1 {
+		t.Errorf("Expected 1 team on a bye in week 1, got %d", byes)
+	}
+}
+
+func TestStandingsHandler_TeamsFilter(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Zones = models.LeagueZones{ChampionSpots: 1, PromotionSpots: 1, RelegationSpots: 1}
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.PlayAllMatchesHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/play-all-matches/1?mode=expected", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to play all matches: status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings?teams=4,2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.StandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Only the requested teams, in league order with their league positions and zones
+	expected := []struct {
+		team     string
+		position int
+		zone     string
+	}{
+		{"Bravo", 2, "promotion"},
+		{"Delta", 4, "relegation"},
+	}
+	if len(resp.Standings) != len(expected) {
+		t.Fatalf("Expected %d standings rows, got %d", len(expected), len(resp.Standings))
+	}
+	for i, row := range resp.Standings {
+		if row.TeamName != expected[i].team || row.Position != expected[i].position || row.Zone != expected[i].zone {
+			t.Errorf("Expected %s at position %d in %s, got %s at position %d in %s",
+				expected[i].team, expected[i].position, expected[i].zone, row.TeamName, row.Position, row.Zone)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		teams  string
+		status int
+	}{
+		{"team not in league", "1,99", http.StatusNotFound},
+		{"invalid ID", "1,abc", http.StatusBadRequest},
+		{"duplicate ID", "2,2", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings?teams="+tt.teams, nil))
+			if w.Code != tt.status {
+				t.Errorf("Expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCalendarHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?first_kickoff=2024-08-03T15:00:00Z", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.CalendarHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/calendar.ics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/calendar") {
+		t.Errorf("Expected text/calendar content type, got %q", contentType)
+	}
+
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(body, "END:VCALENDAR\r\n") {
+		t.Fatalf("Expected a VCALENDAR, got %q", body)
+	}
+	if got := strings.Count(body, "BEGIN:VEVENT\r\n"); got != len(db.matches) {
+		t.Errorf("Expected %d events, got %d", len(db.matches), got)
+	}
+
+	names := make(map[int]string)
+	for _, team := range fakeTeams() {
+		names[team.ID] = team.Name
+	}
+	for _, match := range db.matches {
+		summary := fmt.Sprintf("SUMMARY:%s vs %s\r\n", names[match.HomeTeamID], names[match.AwayTeamID])
+		if !strings.Contains(body, summary) {
+			t.Errorf("Expected an event with %q", summary)
+		}
+		start := "DTSTART:" + match.KickoffTime.UTC().Format("20060102T150405Z") + "\r\n"
+		if !strings.Contains(body, start) {
+			t.Errorf("Expected an event with %q", start)
+		}
+	}
+}
+
+func TestCalendarHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	w := httptest.NewRecorder()
+	handler.CalendarHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/99/calendar.ics", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldICSLine(line)
+
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("Expected folded lines of at most 75 octets, got %d", len(part))
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("Expected unfolding to restore the line, got %q", unfolded)
+	}
+}
+
+func TestStandingsHandler_AsOf(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?first_kickoff=2024-08-03T15:00:00Z", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", w.Code, w.Body.String())
+	}
+	for week := 1; week <= 2; week++ {
+		w = httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week %d: status %d", week, w.Code)
+		}
+	}
+
+	getStandings := func(asOf string) models.StandingsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings?as_of="+asOf, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.StandingsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	// Week 2 kicks off on 2024-08-10, so only week 1 counts as of the day before
+	midSeason := getStandings("2024-08-09")
+	if midSeason.AsOf == nil {
+		t.Error("Expected as_of in the response")
+	}
+	totalPoints := 0
+	for _, row := range midSeason.Standings {
+		if row.Played != 1 {
+			t.Errorf("Expected %s to have played 1 match as of week 1, got %d", row.TeamName, row.Played)
+		}
+		totalPoints += row.Points
+	}
+	if len(midSeason.Standings) != 4 || totalPoints < 4 || totalPoints > 6 {
+		t.Errorf("Expected a 4-team table from two matches, got %d rows and %d points", len(midSeason.Standings), totalPoints)
+	}
+	for i := 1; i < len(midSeason.Standings); i++ {
+		if midSeason.Standings[i-1].Points < midSeason.Standings[i].Points {
+			t.Errorf("Expected the table sorted by points, got %+v", midSeason.Standings)
+		}
+	}
+
+	// A future date returns the full table so far
+	future := getStandings(time.Now().AddDate(1, 0, 0).Format(time.DateOnly))
+	for _, row := range future.Standings {
+		if row.Played != 2 {
+			t.Errorf("Expected %s to have played 2 matches, got %d", row.TeamName, row.Played)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings?as_of=yesterday", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid as_of, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCleanSheetBonus(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"clean_sheet_bonus": 1}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to set clean sheet bonus: status %d, body %s", w.Code, w.Body.String())
+	}
+	if db.leagues[1].CleanSheetBonus != 1 {
+		t.Fatalf("Expected clean sheet bonus 1, got %d", db.leagues[1].CleanSheetBonus)
+	}
+
+	// A 1-0 win is worth 3 points plus the bonus, and the stored and recomputed tables agree
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	if err := db.UpdateStandings(context.Background(), 1, 1, 2, 1, 0); err != nil {
+		t.Fatalf("Failed to update standings: %v", err)
+	}
+	if alpha, bravo := db.standings[1][1].Points, db.standings[1][2].Points; alpha != 4 || bravo != 0 {
+		t.Errorf("Expected 4 and 0 points after a 1-0 win, got %d and %d", alpha, bravo)
+	}
+
+	computed := handler.computeStandingsFromMatches(1, []int{1, 2}, db.matches, models.MatchBonuses{CleanSheet: 1})
+	if computed[1].Points != 4 || computed[2].Points != 0 {
+		t.Errorf("Expected recomputed points 4 and 0, got %d and %d", computed[1].Points, computed[2].Points)
+	}
+
+	w = httptest.NewRecorder()
+	handler.VerifyStandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/verify", nil))
+	var resp models.VerifyStandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.Consistent {
+		t.Errorf("Expected standings with the bonus to verify, got %+v", resp.Discrepancies)
+	}
+}
+
+func TestBigWinBonus(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"big_win_margin": 3, "big_win_bonus": 1}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to set big win bonus: status %d, body %s", w.Code, w.Body.String())
+	}
+	if db.leagues[1].BigWinMargin != 3 || db.leagues[1].BigWinBonus != 1 {
+		t.Fatalf("Expected big win margin 3 and bonus 1, got %d and %d", db.leagues[1].BigWinMargin, db.leagues[1].BigWinBonus)
+	}
+
+	// A 3-0 win meets the margin and earns the bonus, a 1-0 win doesn't
+	addPlayedFakeMatch(db, 1, 1, 2, 3, 0)
+	addPlayedFakeMatch(db, 1, 3, 4, 1, 0)
+	for _, result := range [][4]int{{1, 2, 3, 0}, {3, 4, 1, 0}} {
+		if err := db.UpdateStandings(context.Background(), 1, result[0], result[1], result[2], result[3]); err != nil {
+			t.Fatalf("Failed to update standings: %v", err)
+		}
+	}
+	if alpha, charlie := db.standings[1][1].Points, db.standings[1][3].Points; alpha != 4 || charlie != 3 {
+		t.Errorf("Expected 4 points for the 3-0 win and 3 for the 1-0 win, got %d and %d", alpha, charlie)
+	}
+
+	computed := handler.computeStandingsFromMatches(1, []int{1, 2, 3, 4}, db.matches, models.MatchBonuses{BigWinMargin: 3, BigWin: 1})
+	if computed[1].Points != 4 || computed[3].Points != 3 {
+		t.Errorf("Expected recomputed points 4 and 3, got %d and %d", computed[1].Points, computed[3].Points)
+	}
+
+	w = httptest.NewRecorder()
+	handler.VerifyStandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/verify", nil))
+	var resp models.VerifyStandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.Consistent {
+		t.Errorf("Expected standings with the bonus to verify, got %+v", resp.Discrepancies)
+	}
+}
+
+func TestCreateLeagueHandler_NegativeBigWinBonus(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	for _, body := range []string{`{"name": "Big Wins", "big_win_margin": -1}`, `{"name": "Big Wins", "big_win_bonus": -1}`} {
+		w := httptest.NewRecorder()
+		handler.CreateLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
+
+func TestCreateLeagueHandler_NegativeCleanSheetBonus(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Bonus", "clean_sheet_bonus": -1}`))
+	w := httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateLeagueHandler_FatiguePenaltyOutOfRange(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	for _, body := range []string{`{"name": "Tired", "fatigue_penalty": -1}`, `{"name": "Tired", "fatigue_penalty": 21}`} {
+		w := httptest.NewRecorder()
+		handler.CreateLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(body)))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Body %s: expected status %d, got %d", body, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestCreateLeagueHandler_NegativeMatchesPerWeek(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	w := httptest.NewRecorder()
+	body := `{"name": "Spread", "matches_per_week": -1}`
+	handler.CreateLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateLeagueHandler_ByePointsOutOfRange(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	for _, body := range []string{`{"name": "Byes", "bye_points": -1}`, `{"name": "Byes", "bye_points": 4}`} {
+		w := httptest.NewRecorder()
+		handler.CreateLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(body)))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", body, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestAdvanceWeekHandler_ByePoints(t *testing.T) {
+	for _, byePoints := range []int{0, 1} {
+		db := newFakeLeagueDB(fakeTeams()[:3])
+		db.leagues[1].ByePoints = byePoints
+		handler := NewLeagueHandler(db)
+		startFakeLeague(t, handler)
+
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week: status %d: %s", w.Code, w.Body.String())
+		}
+
+		// Three teams play one match a week, so exactly one team sits out
+		playing := make(map[int]bool)
+		for _, match := range db.matches {
+			if match.Week == 1 {
+				playing[match.HomeTeamID] = true
+				playing[match.AwayTeamID] = true
+			}
+		}
+		byes := 0
+		for teamID, standing := range db.standings[1] {
+			if playing[teamID] {
+				continue
+			}
+			byes++
+			if standing.Played != 0 {
+				t.Errorf("Expected bye team %d to have played 0, got %d", teamID, standing.Played)
+			}
+			if standing.Points != byePoints {
+				t.Errorf("Expected bye team %d to have %d points, got %d", teamID, byePoints, standing.Points)
+			}
+		}
+		if byes != 1 {
+			t.Fatalf("Expected 1 team on a bye, got %d", byes)
+		}
+
+		w = httptest.NewRecorder()
+		handler.VerifyStandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/verify", nil))
+		var resp models.VerifyStandingsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if !resp.Consistent {
+			t.Errorf("Expected standings with bye points %d to verify, got %+v", byePoints, resp.Discrepancies)
+		}
+	}
+}
+
+func TestAdvanceWeeksHandler_ByePoints(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams()[:3])
+	db.leagues[1].ByePoints = 2
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 3}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance weeks: status %d: %s", w.Code, w.Body.String())
+	}
+
+	// Each team sits out one of the first three weeks
+	for teamID, standing := range db.standings[1] {
+		if got, want := standing.Points-matchPoints(db, teamID), 2; got != want {
+			t.Errorf("Expected team %d to have %d bye points, got %d", teamID, want, got)
+		}
+	}
+}
+
+func TestAdvanceWeeksHandler_NoByePointsForTeamAddedAfterStart(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams()[:3])
+	db.leagues[1].ByePoints = 2
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	addLateFakeTeam(t, db, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 3}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance weeks: status %d: %s", w.Code, w.Body.String())
+	}
+
+	// The late team has no fixtures, so it isn't on a bye while the others play
+	if points := db.standings[1][5].Points; points != 0 {
+		t.Errorf("Expected the team added after the start to have no bye points, got %d", points)
+	}
+	for teamID := 1; teamID <= 3; teamID++ {
+		if got, want := db.standings[1][teamID].Points-matchPoints(db, teamID), 2; got != want {
+			t.Errorf("Expected team %d to have %d bye points, got %d", teamID, want, got)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handler.VerifyStandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/verify", nil))
+	var resp models.VerifyStandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.Consistent {
+		t.Errorf("Expected standings to verify, got %+v", resp.Discrepancies)
+	}
+}
+
+// matchPoints totals the points a team earned from its played matches in the fake's league 1
+func matchPoints(db *fakeLeagueDB, teamID int) int {
+	points := 0
+	for _, match := range db.matches {
+		if match.LeagueID != 1 || match.Status != "played" || (match.HomeTeamID != teamID && match.AwayTeamID != teamID) {
+			continue
+		}
+		scored, conceded := *match.HomeGoals, *match.AwayGoals
+		if match.AwayTeamID == teamID {
+			scored, conceded = conceded, scored
+		}
+		switch {
+		case scored > conceded:
+			points += 3
+		case scored == conceded:
+			points++
+		}
+	}
+	return points
+}
+
+func TestWhatIfStandingsHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Failed to advance week: status %d", w.Code)
+	}
+
+	stored, _ := db.GetStandings(context.Background(), 1)
+	storedFor := make(map[int]int)
+	for _, row := range stored {
+		storedFor[row.TeamID] = row.GoalsFor
+	}
+
+	// Give a week 1 home side nine goals, and the next week's first fixture to its away side
+	var played, scheduled *models.Match
+	for _, match := range db.matches {
+		if played == nil && match.Status == "played" {
+			played = match
+		}
+		if scheduled == nil && match.Status == "scheduled" {
+			scheduled = match
+		}
+	}
+	originalHomeGoals := *played.HomeGoals
+
+	body := fmt.Sprintf(`{"overrides": [{"match_id": %d, "home_goals": 9, "away_goals": 0}, {"match_id": %d, "home_goals": 0, "away_goals": 1}]}`, played.ID, scheduled.ID)
+	w = httptest.NewRecorder()
+	handler.WhatIfStandingsHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/1/what-if", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp models.WhatIfStandingsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	rows := make(map[int]models.StandingRow)
+	for _, row := range resp.Standings {
+		rows[row.TeamID] = row
+	}
+	if got, want := rows[played.HomeTeamID].GoalsFor, storedFor[played.HomeTeamID]-originalHomeGoals+9; got != want {
+		t.Errorf("Expected the overridden home side to have %d goals for, got %d", want, got)
+	}
+	if rows[scheduled.AwayTeamID].Played != 2 || rows[scheduled.HomeTeamID].Played != 2 {
+		t.Errorf("Expected the hypothetical scheduled result to count, got %+v and %+v", rows[scheduled.HomeTeamID], rows[scheduled.AwayTeamID])
+	}
+
+	// Nothing was saved
+	after, _ := db.GetStandings(context.Background(), 1)
+	if !reflect.DeepEqual(stored, after) {
+		t.Errorf("Expected stored standings to be unchanged, got %+v, expected %+v", after, stored)
+	}
+	if *played.HomeGoals != originalHomeGoals || scheduled.Status != "scheduled" || scheduled.HomeGoals != nil {
+		t.Errorf("Expected stored matches to be unchanged, got %+v and %+v", played, scheduled)
+	}
+}
+
+func TestWhatIfStandingsHandler_InvalidOverrides(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// A match from another league
+	db.matches = append(db.matches, &models.Match{ID: db.nextMatchID, LeagueID: 2, HomeTeamID: 1, AwayTeamID: 2, Week: 1, Status: "scheduled"})
+	otherLeagueMatch := db.nextMatchID
+	db.nextMatchID++
+
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"no overrides", `{"overrides": []}`, "overrides"},
+		{"other league's match", fmt.Sprintf(`{"overrides": [{"match_id": %d, "home_goals": 1, "away_goals": 0}]}`, otherLeagueMatch), "overrides[0].match_id"},
+		{"negative goals", fmt.Sprintf(`{"overrides": [{"match_id": %d, "home_goals": -1, "away_goals": 0}]}`, db.matches[0].ID), "overrides[0].home_goals"},
+		{"duplicate match", fmt.Sprintf(`{"overrides": [{"match_id": %d, "home_goals": 1, "away_goals": 0}, {"match_id": %d, "home_goals": 2, "away_goals": 0}]}`, db.matches[0].ID, db.matches[0].ID), "overrides[1].match_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.WhatIfStandingsHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/1/what-if", strings.NewReader(tt.body)))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			var resp models.ValidationErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Field != tt.field {
+				t.Errorf("Expected one error on %s, got %+v", tt.field, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestStandingsHandler_FinalTiebreak(t *testing.T) {
+	// Every team is level, and Alpha's rename puts ID order and name order apart
+	db := newFakeLeagueDB(fakeTeams())
+	db.teams[1].Name = "Zulu"
+	handler := NewLeagueHandler(db)
+
+	order := func() []int {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.StandingsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/standings", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.StandingsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		teamIDs := []int{}
+		for _, row := range resp.Standings {
+			teamIDs = append(teamIDs, row.TeamID)
+		}
+		return teamIDs
+	}
+
+	configure := func(body string) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to configure tiebreak: status %d, body %s", w.Code, w.Body.String())
+		}
+	}
+
+	if got := fmt.Sprint(order()); got != "[2 3 4 1]" {
+		t.Errorf("Expected alphabetical order by default, got %s", got)
+	}
+
+	configure(`{"final_tiebreak": "team_id"}`)
+	if got := fmt.Sprint(order()); got != "[1 2 3 4]" {
+		t.Errorf("Expected team ID order, got %s", got)
+	}
+
+	configure(`{"final_tiebreak": "seeded", "tiebreak_seed": 42}`)
+	seeded := fmt.Sprint(order())
+	for i := 0; i < 5; i++ {
+		if got := fmt.Sprint(order()); got != seeded {
+			t.Fatalf("Expected a stable seeded order %s, got %s", seeded, got)
+		}
+	}
+
+	keys := map[int]string{}
+	for teamID := 1; teamID <= 4; teamID++ {
+		keys[teamID] = finalTiebreakKey(db.leagues[1], teamID, "")
+	}
+	ids := []int{1, 2, 3, 4}
+	sort.Slice(ids, func(i, j int) bool { return keys[ids[i]] < keys[ids[j]] })
+	if seeded != fmt.Sprint(ids) {
+		t.Errorf("Expected the seeded order to follow the seed's keys %v, got %s", ids, seeded)
+	}
+
+	w := httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"final_tiebreak": "coin_toss"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown tiebreak, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateLeagueHandler_NegativeZones(t *testing.T) {
+	handler := NewLeagueHandler(&mockLeagueDBService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Zoned", "zones": {"relegation_spots": -1}}`))
+	w := httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCreateLeagueHandler_IdempotencyKey(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	create := func(key string) (*httptest.ResponseRecorder, models.LeagueResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Retried League"}`))
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		handler.CreateLeagueHandler(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+		}
+
+		var resp models.LeagueResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return w, resp
+	}
+
+	leaguesBefore := len(db.leagues)
+
+	first, firstLeague := create("retry-123")
+	second, secondLeague := create("retry-123")
+
+	if firstLeague.ID != secondLeague.ID {
+		t.Errorf("Expected the retry to return league %d, got %d", firstLeague.ID, secondLeague.ID)
+	}
+	if len(db.leagues) != leaguesBefore+1 {
+		t.Errorf("Expected exactly one league to be created, got %d", len(db.leagues)-leaguesBefore)
+	}
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("Expected the first request not to be marked as replayed")
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected the retry to be marked as replayed")
+	}
+
+	// A different key or no key creates a new league
+	_, otherLeague := create("retry-456")
+	_, plainLeague := create("")
+	if otherLeague.ID == firstLeague.ID || plainLeague.ID == firstLeague.ID || plainLeague.ID == otherLeague.ID {
+		t.Errorf("Expected distinct leagues, got %d, %d and %d", firstLeague.ID, otherLeague.ID, plainLeague.ID)
+	}
+}
+
+func TestCleanupFinishedLeaguesHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	old := time.Now().AddDate(0, 0, -60)
+	db.leagues[1].Status = "finished"
+	db.leagues[1].CreatedAt = old
+	db.leagues[2] = &models.League{ID: 2, Name: "Recently Finished", Status: "finished", CreatedAt: time.Now()}
+	db.leagues[3] = &models.League{ID: 3, Name: "Old But Running", Status: "started", CreatedAt: old}
+	db.leagues[4] = &models.League{ID: 4, Name: "Old But Paused", Status: "paused", CreatedAt: old}
+	// Created long ago but only finished today
+	db.leagues[5] = &models.League{ID: 5, Name: "Long Season", Status: "finished", CreatedAt: old}
+	db.RecordLeagueEvent(context.Background(), 5, models.LeagueEventFinished, "League finished after week 38")
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.CleanupFinishedLeaguesHandler(w, httptest.NewRequest(http.MethodPost, "/api/admin/cleanup-finished?older_than_days=30", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.CleanupFinishedLeaguesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.LeaguesDeleted != 1 || !reflect.DeepEqual(resp.LeagueIDs, []int{1}) {
+		t.Errorf("Expected only league 1 to be deleted, got %d: %v", resp.LeaguesDeleted, resp.LeagueIDs)
+	}
+	for _, id := range []int{2, 3, 4, 5} {
+		if _, ok := db.leagues[id]; !ok {
+			t.Errorf("Expected league %d to be kept", id)
+		}
+	}
+
+	// With no age limit every finished league goes, and still nothing else
+	w = httptest.NewRecorder()
+	handler.CleanupFinishedLeaguesHandler(w, httptest.NewRequest(http.MethodPost, "/api/admin/cleanup-finished?older_than_days=0", nil))
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !reflect.DeepEqual(resp.LeagueIDs, []int{2, 5}) {
+		t.Errorf("Expected leagues 2 and 5 to be deleted, got %v", resp.LeagueIDs)
+	}
+	if len(db.leagues) != 2 || db.leagues[3] == nil || db.leagues[4] == nil {
+		t.Errorf("Expected only the started and paused leagues to remain, got %d leagues", len(db.leagues))
+	}
+}
+
+func TestCleanupFinishedLeaguesHandler_InvalidAge(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	for _, value := range []string{"-1", "soon", "1.5"} {
+		w := httptest.NewRecorder()
+		handler.CleanupFinishedLeaguesHandler(w, httptest.NewRequest(http.MethodPost, "/api/admin/cleanup-finished?older_than_days="+value, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("older_than_days=%s: expected status %d, got %d", value, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestAdvanceAllLeaguesHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	ctx := context.Background()
+
+	// League 1 and its clone (league 2) are started with full schedules
+	startFakeLeague(t, handler)
+	if _, err := db.CloneLeague(ctx, 1, "Second League"); err != nil {
+		t.Fatalf("Failed to clone league: %v", err)
+	}
+	startReq := httptest.NewRequest(http.MethodPost, "/api/leagues/start/2", nil)
+	startW := httptest.NewRecorder()
+	handler.StartLeagueHandler(startW, startReq)
+	if startW.Code != http.StatusOK {
+		t.Fatalf("Failed to start league 2: status %d, body %s", startW.Code, startW.Body.String())
+	}
+
+	// League 3 is started but has no matches, so advancing it fails
+	broken, _ := db.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Broken League"})
+	db.leagues[broken.ID].Status = "started"
+
+	// League 4 is not started and must be left alone
+	idle, _ := db.CreateLeague(ctx, &models.CreateLeagueRequest{Name: "Idle League"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/advance-all", nil)
+	w := httptest.NewRecorder()
+	handler.AdvanceAllLeaguesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.AdvanceAllLeaguesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.LeaguesProcessed != 3 || resp.LeaguesAdvanced != 2 || resp.LeaguesFailed != 1 {
+		t.Fatalf("Expected 3 processed, 2 advanced, 1 failed, got %d, %d, %d", resp.LeaguesProcessed, resp.LeaguesAdvanced, resp.LeaguesFailed)
+	}
+
+	for _, result := range resp.Results {
+		switch result.LeagueID {
+		case 1, 2:
+			if result.Error != "" {
+				t.Errorf("League %d: unexpected error %q", result.LeagueID, result.Error)
+			}
+			if result.WeekAdvanced != 1 || result.MatchesPlayed != 2 {
+				t.Errorf("League %d: expected week 1 with 2 matches, got week %d with %d", result.LeagueID, result.WeekAdvanced, result.MatchesPlayed)
+			}
+			if db.leagues[result.LeagueID].CurrentWeek != 1 {
+				t.Errorf("League %d: expected stored current week 1, got %d", result.LeagueID, db.leagues[result.LeagueID].CurrentWeek)
+			}
+		case broken.ID:
+			if result.Error == "" {
+				t.Errorf("League %d: expected an error for a league without matches", result.LeagueID)
+			}
+		default:
+			t.Errorf("Unexpected league %d in results", result.LeagueID)
+		}
+	}
+
+	if db.leagues[idle.ID].Status != "created" || db.leagues[idle.ID].CurrentWeek != 0 {
+		t.Errorf("Expected the idle league to be untouched, got status %s week %d", db.leagues[idle.ID].Status, db.leagues[idle.ID].CurrentWeek)
+	}
+}
+
+func TestTeamTrendHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Play three weeks with fixed scores so the expected totals are known
+	for week := 1; week <= 3; week++ {
+		for _, match := range db.matches {
+			if match.Week != week {
+				continue
+			}
+			homeGoals, awayGoals := 2, 1
+			if week == 2 {
+				homeGoals, awayGoals = 1, 1
+			}
+			if err := db.PlayMatch(context.Background(), match.ID, homeGoals, awayGoals); err != nil {
+				t.Fatalf("Failed to play match %d: %v", match.ID, err)
+			}
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/1/trend", nil)
+	w := httptest.NewRecorder()
+	handler.TeamTrendHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.TeamTrendResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Weeks) != 3 {
+		t.Fatalf("Expected 3 weeks of trend, got %d", len(resp.Weeks))
+	}
+
+	var points, goalsFor, goalsAgainst int
+	for i, week := range resp.Weeks {
+		if week.Week != i+1 {
+			t.Errorf("Expected week %d at index %d, got %d", i+1, i, week.Week)
+		}
+		if week.Played != i+1 {
+			t.Errorf("Week %d: expected %d played, got %d", week.Week, i+1, week.Played)
+		}
+
+		// Recompute the team's totals from its match in this week
+		for _, match := range db.matches {
+			if match.Week != week.Week || (match.HomeTeamID != 1 && match.AwayTeamID != 1) {
+				continue
+			}
+			scored, conceded := *match.HomeGoals, *match.AwayGoals
+			if match.AwayTeamID == 1 {
+				scored, conceded = conceded, scored
+			}
+			goalsFor += scored
+			goalsAgainst += conceded
+			switch {
+			case scored > conceded:
+				points += 3
+			case scored == conceded:
+				points++
+			}
+		}
+
+		if week.Points != points || week.GoalsFor != goalsFor || week.GoalsAgainst != goalsAgainst {
+			t.Errorf("Week %d: expected %d pts %d-%d, got %d pts %d-%d", week.Week, points, goalsFor, goalsAgainst, week.Points, week.GoalsFor, week.GoalsAgainst)
+		}
+		if i > 0 {
+			prev := resp.Weeks[i-1]
+			if week.Points < prev.Points || week.GoalsFor <= prev.GoalsFor || week.GoalsAgainst < prev.GoalsAgainst {
+				t.Errorf("Week %d: expected cumulative totals not to decrease, got %+v after %+v", week.Week, week, prev)
+			}
+		}
+	}
+}
+
+func TestTeamTrendHandler_TeamNotInLeague(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.teams[9] = &models.Team{ID: 9, Name: "Outsider", Strength: 50}
+	handler := NewLeagueHandler(db)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/teams/9/trend", nil)
+	w := httptest.NewRecorder()
+	handler.TeamTrendHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestForfeitMatchHandler_HomeSide(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	match := db.matches[0]
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/forfeit", match.ID), strings.NewReader(`{"side": "home"}`))
+	w := httptest.NewRecorder()
+	handler.ForfeitMatchHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.MatchUpdateResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Match.Match.Status != "played" || resp.Match.Result != "0-3" {
+		t.Errorf("Expected a played 0-3 result, got status %s result %s", resp.Match.Match.Status, resp.Match.Result)
+	}
+
+	home := db.standings[1][match.HomeTeamID]
+	away := db.standings[1][match.AwayTeamID]
+	if away.Points != 3 || away.Wins != 1 || away.GoalsFor != 3 {
+		t.Errorf("Expected the away team to get a 3-0 win, got %+v", away)
+	}
+	if home.Points != 0 || home.Losses != 1 || home.GoalsAgainst != 3 {
+		t.Errorf("Expected the home team to get a 3-0 loss, got %+v", home)
+	}
+
+	// Forfeiting an already played match is rejected
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/forfeit", match.ID), strings.NewReader(`{"side": "away"}`))
+	w = httptest.NewRecorder()
+	handler.ForfeitMatchHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d for a played match, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestForfeitMatchHandler_InvalidSide(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/matches/1/forfeit", strings.NewReader(`{"side": "neither"}`))
+	w := httptest.NewRecorder()
+	handler.ForfeitMatchHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSimulateMatch_ScoreCorrelationReducesHighScorelines(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	// Count matches where both evenly matched sides score 3 or more
+	bothHigh := func(correlation float64) int {
+		count := 0
+		for i := 0; i < 4000; i++ {
+			homeGoals, awayGoals := handler.simulateMatch(50, 50, simulationSettings{scoreCorrelation: correlation})
+			if homeGoals >= 3 && awayGoals >= 3 {
+				count++
+			}
+		}
+		return count
+	}
+
+	independent := bothHigh(0)
+	correlated := bothHigh(1)
+
+	if independent == 0 {
+		t.Fatal("Expected some both-teams-high scorelines with independent sampling")
+	}
+	if correlated*2 >= independent {
+		t.Errorf("Expected correlation to at least halve both-teams-high scorelines, got %d correlated vs %d independent", correlated, independent)
+	}
+}
+
+func TestDampenedExpectancy(t *testing.T) {
+	if got := dampenedExpectancy(1.5, 1, 1.5, 1); got != 1.5 {
+		t.Errorf("Expected no dampening when the opponent scored below expectancy, got %.2f", got)
+	}
+	if got := dampenedExpectancy(1.5, 5, 1.5, 0); got != 1.5 {
+		t.Errorf("Expected no dampening with zero correlation, got %.2f", got)
+	}
+
+	// Opponent scored 3 against an expectancy of 1.5: half of its goals were excess
+	if got := dampenedExpectancy(2.0, 3, 1.5, 0.5); math.Abs(got-1.5) > 1e-9 {
+		t.Errorf("Expected expectancy 1.50, got %.2f", got)
+	}
+}
+
+func TestCreateLeagueHandler_InvalidScoreCorrelation(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Tuned", "score_correlation": 1.5}`))
+	w := httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestRoundFixturesHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		teams            []*models.Team
+		expectedFixtures int
+		expectedByes     int
+	}{
+		{name: "even team count", teams: fakeTeams(), expectedFixtures: 2, expectedByes: 0},
+		{name: "odd team count", teams: fakeTeams()[:3], expectedFixtures: 1, expectedByes: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeLeagueDB(tt.teams)
+			handler := NewLeagueHandler(db)
+			startFakeLeague(t, handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/round/1", nil)
+			w := httptest.NewRecorder()
+			handler.RoundFixturesHandler(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			var resp models.RoundFixturesResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if resp.Round != 1 {
+				t.Errorf("Expected round 1, got %d", resp.Round)
+			}
+			if len(resp.Fixtures) != tt.expectedFixtures {
+				t.Errorf("Expected %d fixtures, got %d", tt.expectedFixtures, len(resp.Fixtures))
+			}
+			if len(resp.Byes) != tt.expectedByes {
+				t.Fatalf("Expected %d byes, got %d", tt.expectedByes, len(resp.Byes))
+			}
+
+			// Every team either plays exactly once or has a bye
+			seen := make(map[int]int)
+			for _, fixture := range resp.Fixtures {
+				seen[fixture.Match.HomeTeamID]++
+				seen[fixture.Match.AwayTeamID]++
+			}
+			for _, bye := range resp.Byes {
+				seen[bye.ID]++
+			}
+			for _, team := range tt.teams {
+				if seen[team.ID] != 1 {
+					t.Errorf("Expected team %s to appear once in the round, got %d", team.Name, seen[team.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundFixturesHandler_UnknownRound(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/round/99", nil)
+	w := httptest.NewRecorder()
+	handler.RoundFixturesHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestScheduleHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	startReq := httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?first_kickoff=2025-08-16T15:00:00Z", nil)
+	startW := httptest.NewRecorder()
+	handler.StartLeagueHandler(startW, startReq)
+	if startW.Code != http.StatusOK {
+		t.Fatalf("Failed to start league: status %d, body %s", startW.Code, startW.Body.String())
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedWeeks []int
+	}{
+		{name: "first two weeks", query: "from=2025-08-16&to=2025-08-23", expectedWeeks: []int{1, 1, 2, 2}},
+		{name: "timestamp bounds", query: "from=2025-08-23T15:00:00Z&to=2025-08-30T15:00:00Z", expectedWeeks: []int{2, 2}},
+		{name: "no fixtures", query: "from=2024-01-01&to=2024-01-31", expectedWeeks: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/schedule?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handler.ScheduleHandler(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			var resp models.ScheduleResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if len(resp.Matches) != len(tt.expectedWeeks) {
+				t.Fatalf("Expected %d matches, got %d", len(tt.expectedWeeks), len(resp.Matches))
+			}
+			for i, match := range resp.Matches {
+				if match.Match.Week != tt.expectedWeeks[i] {
+					t.Errorf("Match %d: expected week %d, got %d", i, tt.expectedWeeks[i], match.Match.Week)
+				}
+				if i > 0 && match.Match.KickoffTime.Before(*resp.Matches[i-1].Match.KickoffTime) {
+					t.Errorf("Expected matches in chronological order")
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleHandler_InvalidDates(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	queries := []string{
+		"from=2025-13-01&to=2025-12-31",
+		"from=yesterday&to=2025-12-31",
+		"from=2025-01-01",
+		"from=2025-02-01&to=2025-01-01",
+	}
+
+	for _, query := range queries {
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/schedule?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.ScheduleHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Query %q: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestSimulateMatch_UpsetFactorRaisesWeakerTeamWinRate(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	// The weak home side (20) faces a strong away side (95)
+	weakWinRate := func(upsetFactor float64) float64 {
+		const runs = 3000
+		wins := 0
+		for i := 0; i < runs; i++ {
+			homeGoals, awayGoals := handler.simulateMatch(20, 95, simulationSettings{upsetFactor: upsetFactor})
+			if homeGoals > awayGoals {
+				wins++
+			}
+		}
+		return float64(wins) / runs
+	}
+
+	noUpsets := weakWinRate(0)
+	someUpsets := weakWinRate(0.5)
+	parity := weakWinRate(1)
+
+	if someUpsets <= noUpsets+0.1 {
+		t.Errorf("Expected upset factor 0.5 to raise the weaker team's win rate well above %.3f, got %.3f", noUpsets, someUpsets)
+	}
+	if parity <= noUpsets+0.1 {
+		t.Errorf("Expected upset factor 1 to raise the weaker team's win rate well above %.3f, got %.3f", noUpsets, parity)
+	}
+}
+
+func TestGoalExpectancy_UpsetFactor(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	home, away := handler.goalExpectancy(50, 80, 0)
+	compressedHome, compressedAway := handler.goalExpectancy(50, 80, 0.5)
+	parityHome, parityAway := handler.goalExpectancy(50, 80, 1)
+
+	if math.Abs(compressedAway-compressedHome) >= math.Abs(away-home) {
+		t.Errorf("Expected the expectancy gap to shrink, got %.2f-%.2f vs %.2f-%.2f", compressedHome, compressedAway, home, away)
+	}
+	if parityHome != 1.5 || parityAway != 1.5 {
+		t.Errorf("Expected equal expectancy at upset factor 1, got %.2f and %.2f", parityHome, parityAway)
+	}
+}
+
+func TestLeagueConfigHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Zones = models.DefaultLeagueZones
+	handler := NewLeagueHandler(db)
+
+	getConfig := func() (*httptest.ResponseRecorder, models.LeagueConfigResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/config", nil)
+		w := httptest.NewRecorder()
+		handler.LeagueConfigHandler(w, req)
+
+		var resp models.LeagueConfigResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	// Defaults
+	w, resp := getConfig()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if resp.Config.Zones != models.DefaultLeagueZones || resp.Config.ScoreCorrelation != 0 || resp.Config.UpsetFactor != 0 {
+		t.Errorf("Expected default settings, got %+v", resp.Config)
+	}
+
+	// Update several settings
+	patch := `{"zones": {"champion_spots": 1, "promotion_spots": 2, "relegation_spots": 1}, "upset_factor": 0.4}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(patch))
+	w = httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	_, resp = getConfig()
+	expected := models.LeagueConfig{
+		Zones:       models.LeagueZones{ChampionSpots: 1, PromotionSpots: 2, RelegationSpots: 1},
+		UpsetFactor: 0.4,
+	}
+	if resp.Config != expected {
+		t.Errorf("Expected config %+v, got %+v", expected, resp.Config)
+	}
+
+	// Invalid values are rejected
+	for _, body := range []string{`{"upset_factor": 2}`, `{"zones": {"champion_spots": -1}}`, `{"points_per_win": 3}`} {
+		req = httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(body))
+		w = httptest.NewRecorder()
+		handler.LeagueConfigHandler(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Body %s: expected status %d, got %d", body, http.StatusBadRequest, w.Code)
+		}
+	}
+
+	// Updates are rejected once the league has started
+	startFakeLeague(t, handler)
+	req = httptest.NewRequest(http.MethodPatch, "/api/leagues/1/config", strings.NewReader(`{"score_correlation": 0.5}`))
+	w = httptest.NewRecorder()
+	handler.LeagueConfigHandler(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d after start, got %d", http.StatusConflict, w.Code)
+	}
+
+	_, resp = getConfig()
+	if resp.Config.ScoreCorrelation != 0 {
+		t.Errorf("Expected score correlation to be unchanged, got %.2f", resp.Config.ScoreCorrelation)
+	}
+}
+
+func TestCalculateTotalWeeks(t *testing.T) {
+	handler := NewLeagueHandler(&mockDBService{})
+
+	tests := []struct {
+		numTeams int
+		expected int
+	}{
+		{1, 0},
+		{2, 2},
+		{3, 6},
+		{4, 6},
+		{5, 10},
+		{6, 10},
+	}
+
+	for _, tt := range tests {
+		if got := handler.calculateTotalWeeks(tt.numTeams, 0); got != tt.expected {
+			t.Errorf("calculateTotalWeeks(%d) = %d, expected %d", tt.numTeams, got, tt.expected)
+		}
+	}
+}
+
+func TestAdvanceWeekHandler_FinishesAfterFinalWeek(t *testing.T) {
+	tests := []struct {
+		name       string
+		numTeams   int
+		totalWeeks int
+	}{
+		{"even team count", 4, 6},
+		{"odd team count with byes", 3, 6},
+		{"odd team count with byes", 5, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s (%d teams)", tt.name, tt.numTeams), func(t *testing.T) {
+			var teams []*models.Team
+			for i := 1; i <= tt.numTeams; i++ {
+				teams = append(teams, &models.Team{ID: i, Name: fmt.Sprintf("Team %d", i), Strength: 50 + i})
+			}
+			db := newFakeLeagueDB(teams)
+			handler := NewLeagueHandler(db)
+			startFakeLeague(t, handler)
+
+			for week := 1; week <= tt.totalWeeks; week++ {
+				req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil)
+				w := httptest.NewRecorder()
+				handler.AdvanceWeekHandler(w, req)
+				if w.Code != http.StatusOK {
+					t.Fatalf("Week %d: expected status %d, got %d: %s", week, http.StatusOK, w.Code, w.Body.String())
+				}
+
+				var resp models.AdvanceWeekResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+
+				expectedStatus := "started"
+				if week == tt.totalWeeks {
+					expectedStatus = "finished"
+				}
+				if resp.League.Status != expectedStatus {
+					t.Errorf("After week %d: expected status %s, got %s", week, expectedStatus, resp.League.Status)
+				}
+			}
+
+			if finished := db.finishedAt[1]; len(finished) != 1 || finished[0] != tt.totalWeeks {
+				t.Errorf("Expected league to be marked finished once at week %d, got %v", tt.totalWeeks, finished)
+			}
+
+			for _, match := range db.matches {
+				if match.Status != "played" {
+					t.Errorf("Expected every match to be played, match %d in week %d is %s", match.ID, match.Week, match.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestAdvanceWeeksHandler_FinishesOddLeagueAtFinalWeek(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams()[:3])
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	advance := func(count int) models.AdvanceWeeksResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(fmt.Sprintf(`{"count": %d}`, count)))
+		w := httptest.NewRecorder()
+		handler.AdvanceWeeksHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var resp models.AdvanceWeeksResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	resp := advance(5)
+	if resp.League.Status != "started" {
+		t.Errorf("Expected league to still be started after week 5, got %s", resp.League.Status)
+	}
+
+	resp = advance(10)
+	if resp.FinalWeek != 6 || resp.WeeksPlayed != 1 {
+		t.Errorf("Expected to stop after playing week 6, got final week %d after %d weeks", resp.FinalWeek, resp.WeeksPlayed)
+	}
+	if resp.League.Status != "finished" {
+		t.Errorf("Expected league to be finished, got %s", resp.League.Status)
+	}
+	if finished := db.finishedAt[1]; len(finished) != 1 || finished[0] != 6 {
+		t.Errorf("Expected league to be marked finished once at week 6, got %v", finished)
+	}
+}
+
+func TestSimulateMatchHandler(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	simulate := func(body string) (*httptest.ResponseRecorder, models.SimulateMatchResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/simulate-match", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.SimulateMatchHandler(w, req)
+
+		var resp models.SimulateMatchResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	t.Run("equal strengths give symmetric expectancy", func(t *testing.T) {
+		w, resp := simulate(`{"home_strength": 70, "away_strength": 70, "home_advantage": 0}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if resp.HomeExpectancy != resp.AwayExpectancy {
+			t.Errorf("Expected symmetric expectancy, got %.2f vs %.2f", resp.HomeExpectancy, resp.AwayExpectancy)
+		}
+		if resp.HomeGoals < 0 || resp.AwayGoals < 0 {
+			t.Errorf("Expected non-negative goals, got %d-%d", resp.HomeGoals, resp.AwayGoals)
+		}
+	})
+
+	t.Run("default home advantage favours the home side", func(t *testing.T) {
+		_, resp := simulate(`{"home_strength": 70, "away_strength": 70}`)
+		if resp.HomeAdvantage != defaultHomeAdvantage || resp.HomeExpectancy <= resp.AwayExpectancy {
+			t.Errorf("Expected home advantage %d to favour the home side, got %+v", defaultHomeAdvantage, resp)
+		}
+	})
+
+	t.Run("strong team against weak team", func(t *testing.T) {
+		// Alpha (90) hosts Delta (45)
+		w, resp := simulate(`{"home_team_id": 1, "away_team_id": 4}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if resp.HomeStrength != 90 || resp.AwayStrength != 45 {
+			t.Errorf("Expected strengths 90 and 45, got %d and %d", resp.HomeStrength, resp.AwayStrength)
+		}
+		if resp.HomeExpectancy <= resp.AwayExpectancy {
+			t.Errorf("Expected the stronger team to have the higher expectancy, got %.2f vs %.2f", resp.HomeExpectancy, resp.AwayExpectancy)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			body           string
+			expectedStatus int
+		}{
+			{`{"away_strength": 50}`, http.StatusBadRequest},
+			{`{"home_strength": 50, "home_team_id": 1, "away_strength": 50}`, http.StatusBadRequest},
+			{`{"home_strength": 101, "away_strength": 50}`, http.StatusBadRequest},
+			{`{"home_strength": 50, "away_strength": 50, "home_advantage": -1}`, http.StatusBadRequest},
+			{`{"home_team_id": 99, "away_strength": 50}`, http.StatusNotFound},
+			{`not json`, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			w, _ := simulate(tt.body)
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Body %s: expected status %d, got %d", tt.body, tt.expectedStatus, w.Code)
+			}
+		}
+	})
+}
+
+func TestSimulateSeriesHandler(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB([]*models.Team{
+		{ID: 1, Name: "Strong", Strength: 100},
+		{ID: 2, Name: "Weak", Strength: 10},
+	}))
+
+	simulate := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/simulate-series", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.SimulateSeriesHandler(w, req)
+		return w
+	}
+
+	t.Run("stronger team wins the majority of a long series", func(t *testing.T) {
+		w := simulate(`{"team1_id": 2, "team2_id": 1, "matches": 200}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var resp models.SimulateSeriesResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Team1Wins+resp.Draws+resp.Team2Wins != 200 {
+			t.Errorf("Expected results for 200 matches, got %+v", resp)
+		}
+		if resp.Team2Wins <= 100 {
+			t.Errorf("Expected Strong to win most of the series, got %d of 200", resp.Team2Wins)
+		}
+		if resp.Team2AverageGoals <= resp.Team1AverageGoals {
+			t.Errorf("Expected Strong to average more goals, got %.2f vs %.2f", resp.Team2AverageGoals, resp.Team1AverageGoals)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			body           string
+			expectedStatus int
+		}{
+			{`{"team1_id": 1, "team2_id": 1, "matches": 10}`, http.StatusBadRequest},
+			{`{"team1_id": 1, "team2_id": 2, "matches": 0}`, http.StatusBadRequest},
+			{`{"team1_id": 1, "team2_id": 2, "matches": 1001}`, http.StatusBadRequest},
+			{`{"team1_id": 1, "team2_id": 99, "matches": 10}`, http.StatusNotFound},
+			{`not json`, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			if w := simulate(tt.body); w.Code != tt.expectedStatus {
+				t.Errorf("Body %s: expected status %d, got %d", tt.body, tt.expectedStatus, w.Code)
+			}
+		}
+	})
+}
+
+func TestStartLeagueHandler_OddTeamCountWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		numTeams    int
+		wantWarning bool
+	}{
+		{"even team count", 4, false},
+		{"odd team count", 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()[:tt.numTeams]))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil)
+			w := httptest.NewRecorder()
+			handler.StartLeagueHandler(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			var resp models.StartLeagueResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if tt.wantWarning && !strings.Contains(resp.Warning, "bye") {
+				t.Errorf("Expected a bye warning, got %q", resp.Warning)
+			}
+			if !tt.wantWarning && resp.Warning != "" {
+				t.Errorf("Expected no warning, got %q", resp.Warning)
+			}
+		})
+	}
+}
+
+func TestLeagueProgressHandler(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+
+	// Play 2 of the 6 weeks
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil)
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week: status %d", w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/progress", nil)
+	w := httptest.NewRecorder()
+	handler.LeagueProgressHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.LeagueProgressResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	expected := models.LeagueProgressResponse{
+		LeagueID:        1,
+		Status:          "started",
+		CurrentWeek:     2,
+		TotalWeeks:      6,
+		WeeksRemaining:  4,
+		PercentComplete: 33.3,
+	}
+	if resp != expected {
+		t.Errorf("Expected progress %+v, got %+v", expected, resp)
+	}
+}
+
+func TestLeagueProgressHandler_LeagueNotFound(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/99/progress", nil)
+	w := httptest.NewRecorder()
+	handler.LeagueProgressHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+// addPlayedFakeMatch adds a played match to league 1 of the fake database
+func addPlayedFakeMatch(db *fakeLeagueDB, week, homeTeamID, awayTeamID, homeGoals, awayGoals int) {
+	db.matches = append(db.matches, &models.Match{
+		ID:         db.nextMatchID,
+		LeagueID:   1,
+		HomeTeamID: homeTeamID,
+		AwayTeamID: awayTeamID,
+		Week:       week,
+		HomeGoals:  &homeGoals,
+		AwayGoals:  &awayGoals,
+		Status:     "played",
+	})
+	db.nextMatchID++
+}
+
+// finishedTitleRaceLeague builds a finished four-team league where Alpha wins every match 1-0
+// and every other match is a goalless draw
+func finishedTitleRaceLeague() *fakeLeagueDB {
+	db := newFakeLeagueDB(fakeTeams())
+	db.leagues[1].Status = "finished"
+	db.leagues[1].CurrentWeek = 6
+
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	addPlayedFakeMatch(db, 1, 3, 4, 0, 0)
+	addPlayedFakeMatch(db, 2, 1, 3, 1, 0)
+	addPlayedFakeMatch(db, 2, 2, 4, 0, 0)
+	addPlayedFakeMatch(db, 3, 1, 4, 1, 0)
+	addPlayedFakeMatch(db, 3, 2, 3, 0, 0)
+	addPlayedFakeMatch(db, 4, 2, 1, 0, 1)
+	addPlayedFakeMatch(db, 4, 4, 3, 0, 0)
+	addPlayedFakeMatch(db, 5, 3, 1, 0, 1)
+	addPlayedFakeMatch(db, 5, 4, 2, 0, 0)
+	addPlayedFakeMatch(db, 6, 4, 1, 0, 1)
+	addPlayedFakeMatch(db, 6, 3, 2, 0, 0)
+
+	handler := NewLeagueHandler(db)
+	table := handler.computeStandingsFromMatches(1, []int{1, 2, 3, 4}, db.matches, models.MatchBonuses{})
+	for teamID, standing := range table {
+		db.standings[1][teamID] = standing
+	}
+	return db
+}
+
+func TestTitleDecidedHandler(t *testing.T) {
+	db := finishedTitleRaceLeague()
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.TitleDecidedHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/title-decided", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.TitleDecidedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// After week 3 Alpha has 9 points and Bravo, Charlie and Delta 2 each, with 9 still to play for.
+	// After week 4 Alpha has 12 and the best of the rest 3, with only 6 left, so week 4 decided it.
+	if resp.Champion.TeamID != 1 {
+		t.Errorf("Expected Alpha as champion, got %s", resp.Champion.TeamName)
+	}
+	if resp.DecidedWeek != 4 || resp.FinalWeek != 6 || resp.WeeksToSpare != 2 {
+		t.Errorf("Expected the title decided in week 4 of 6 with 2 to spare, got week %d of %d with %d", resp.DecidedWeek, resp.FinalWeek, resp.WeeksToSpare)
+	}
+	if resp.Lead != 9 {
+		t.Errorf("Expected a 9 point lead, got %d", resp.Lead)
+	}
+}
+
+func TestTitleDecidedHandler_ByePointsDelayTheTitle(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams()[:3])
+	db.leagues[1].Status = "finished"
+	db.leagues[1].CurrentWeek = 3
+	db.leagues[1].ByePoints = 3
+
+	// Alpha wins both its matches, then sits out week 3 while Bravo and Charlie draw
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	addPlayedFakeMatch(db, 2, 3, 1, 0, 1)
+	addPlayedFakeMatch(db, 3, 2, 3, 0, 0)
+	db.standings[1][1].Points = 9
+	db.standings[1][2].Points = 4
+	db.standings[1][3].Points = 4
+
+	handler := NewLeagueHandler(db)
+	w := httptest.NewRecorder()
+	handler.TitleDecidedHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/title-decided", nil))
+
+	var resp models.TitleDecidedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// After week 2 Alpha has 6 and Bravo 3 from its bye, so a week 3 win would still draw Bravo level.
+	// Without bye points Bravo would have had none and the title would have been decided in week 2.
+	if resp.DecidedWeek != 3 {
+		t.Errorf("Expected the title decided in week 3, got %d", resp.DecidedWeek)
+	}
+}
+
+func TestTitleDecidedHandler_SettledOnTiebreaks(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams()[:2])
+	db.leagues[1].Status = "finished"
+	db.leagues[1].CurrentWeek = 2
+
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	addPlayedFakeMatch(db, 2, 2, 1, 1, 0)
+	db.standings[1][1].Points = 3
+	db.standings[1][2].Points = 3
+
+	handler := NewLeagueHandler(db)
+	w := httptest.NewRecorder()
+	handler.TitleDecidedHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/title-decided", nil))
+
+	var resp models.TitleDecidedResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.DecidedWeek != 2 || resp.WeeksToSpare != 0 || resp.Lead != 0 {
+		t.Errorf("Expected a title level on points decided in the final week, got %+v", resp)
+	}
+}
+
+func TestTitleDecidedHandler_NotFinished(t *testing.T) {
+	handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+	startFakeLeague(t, handler)
+
+	w := httptest.NewRecorder()
+	handler.TitleDecidedHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/title-decided", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPredictChampionHandler_SkipsForfeitedFutureMatches(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	startFakeLeague(t, handler)
+	db.leagues[1].CurrentWeek = 4
+	db.standings[1][1].Points = 12
+	db.standings[1][4].Points = 9
+
+	// Settle every remaining match by forfeit: Delta wins both of its matches and Alpha loses both,
+	// leaving Delta champion with nothing left to simulate
+	for _, match := range db.matches {
+		if match.Week <= 4 {
+			continue
+		}
+		side := "home"
+		if match.HomeTeamID == 4 || match.AwayTeamID == 1 {
+			side = "away"
+		}
+		if match.AwayTeamID == 4 {
+			side = "home"
+		}
+		w := httptest.NewRecorder()
+		handler.ForfeitMatchHandler(w, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/forfeit", match.ID), strings.NewReader(`{"side": "`+side+`"}`)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to forfeit match %d: status %d: %s", match.ID, w.Code, w.Body.String())
+		}
+	}
+	if db.standings[1][4].Points != 15 || db.standings[1][1].Points != 12 {
+		t.Fatalf("Expected Delta on 15 and Alpha on 12 after the forfeits, got %d and %d", db.standings[1][4].Points, db.standings[1][1].Points)
+	}
+
+	w := httptest.NewRecorder()
+	handler.PredictChampionHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/predict-champion/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.PredictChampionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.ChampionProbabilities[0].TeamID != 4 || resp.ChampionProbabilities[0].Probability != 100.0 {
+		t.Errorf("Expected Delta to be certain champion, got %+v", resp.ChampionProbabilities)
+	}
+}
+
+func TestPredictChampionHandler_SharedTitle(t *testing.T) {
+	finishedLeague := func(alphaGoals, bravoGoals int) *fakeLeagueDB {
+		db := newFakeLeagueDB(fakeTeams())
+		db.leagues[1].Status = "finished"
+		db.leagues[1].CurrentWeek = 6
+		// Alpha and Bravo finish level on points, goal difference and goals for
+		for teamID, standing := range map[int]models.Standing{
+			1: {Played: 6, Wins: 4, Draws: 1, Losses: 1, GoalsFor: 10, GoalsAgainst: 5, GoalDifference: 5, Points: 13},
+			2: {Played: 6, Wins: 4, Draws: 1, Losses: 1, GoalsFor: 10, GoalsAgainst: 5, GoalDifference: 5, Points: 13},
+			3: {Played: 6, Wins: 2, Draws: 0, Losses: 4, GoalsFor: 6, GoalsAgainst: 9, GoalDifference: -3, Points: 6},
+			4: {Played: 6, Wins: 0, Draws: 2, Losses: 4, GoalsFor: 3, GoalsAgainst: 10, GoalDifference: -7, Points: 2},
+		} {
+			standing.LeagueID = 1
+			standing.TeamID = teamID
+			*db.standings[1][teamID] = standing
+		}
+		addPlayedFakeMatch(db, 1, 1, 2, alphaGoals, bravoGoals)
+		addPlayedFakeMatch(db, 4, 2, 1, 1, 1)
+		return db
+	}
+
+	predict := func(db *fakeLeagueDB) models.PredictChampionResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		NewLeagueHandler(db).PredictChampionHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/predict-champion/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.PredictChampionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	// Level head-to-head as well: the title is shared
+	resp := predict(finishedLeague(1, 1))
+	if len(resp.CoChampions) != 2 || resp.CoChampions[0].TeamName != "Alpha" || resp.CoChampions[1].TeamName != "Bravo" {
+		t.Fatalf("Expected Alpha and Bravo as co-champions, got %+v", resp.CoChampions)
+	}
+	for _, probability := range resp.ChampionProbabilities {
+		expected := 0.0
+		if probability.TeamID == 1 || probability.TeamID == 2 {
+			expected = 50.0
+		}
+		if probability.Probability != expected {
+			t.Errorf("Expected %s to have probability %.0f, got %.1f", probability.TeamName, expected, probability.Probability)
+		}
+	}
+
+	// Bravo won the first meeting, so head-to-head decides the title
+	resp = predict(finishedLeague(0, 1))
+	if len(resp.CoChampions) != 0 {
+		t.Errorf("Expected no co-champions, got %+v", resp.CoChampions)
+	}
+	for _, probability := range resp.ChampionProbabilities {
+		if probability.TeamID == 2 && probability.Probability != 100.0 {
+			t.Errorf("Expected Bravo to be champion on head-to-head, got %+v", resp.ChampionProbabilities)
+		}
+	}
+}
+
+func TestHeadToHeadResultHandler(t *testing.T) {
+	getResult := func(db *fakeLeagueDB, query string) (*httptest.ResponseRecorder, models.HeadToHeadResultResponse) {
+		t.Helper()
+		handler := NewLeagueHandler(db)
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/head-to-head-result?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.HeadToHeadResultHandler(w, req)
+
+		var resp models.HeadToHeadResultResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w, resp
+	}
+
+	t.Run("clear aggregate win", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 1, 1, 2, 2, 0) // Alpha 2-0 Bravo
+		addPlayedFakeMatch(db, 4, 2, 1, 2, 1) // Bravo 2-1 Alpha
+
+		w, resp := getResult(db, "team1=1&team2=2")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if resp.Result.Team1Aggregate != 3 || resp.Result.Team2Aggregate != 2 {
+			t.Errorf("Expected aggregate 3-2, got %d-%d", resp.Result.Team1Aggregate, resp.Result.Team2Aggregate)
+		}
+		if resp.Result.WinnerID == nil || *resp.Result.WinnerID != 1 || resp.Result.DecidedBy != "aggregate" {
+			t.Errorf("Expected team 1 to win on aggregate, got %+v", resp.Result)
+		}
+		if len(resp.Legs) != 2 || resp.Legs[0].Week != 1 || resp.Legs[1].Week != 4 {
+			t.Errorf("Expected both legs in week order, got %+v", resp.Legs)
+		}
+	})
+
+	t.Run("level aggregate decided by away goals", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 2, 1, 3, 1, 2) // Alpha 1-2 Charlie
+		addPlayedFakeMatch(db, 5, 3, 1, 0, 1) // Charlie 0-1 Alpha
+
+		w, resp := getResult(db, "team1=1&team2=3")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if resp.Result.Team1Aggregate != 2 || resp.Result.Team2Aggregate != 2 {
+			t.Errorf("Expected aggregate 2-2, got %d-%d", resp.Result.Team1Aggregate, resp.Result.Team2Aggregate)
+		}
+		if resp.Result.Team1AwayGoals != 1 || resp.Result.Team2AwayGoals != 2 {
+			t.Errorf("Expected away goals 1-2, got %d-%d", resp.Result.Team1AwayGoals, resp.Result.Team2AwayGoals)
+		}
+		if resp.Result.WinnerID == nil || *resp.Result.WinnerID != 3 || resp.Result.DecidedBy != "away_goals" {
+			t.Errorf("Expected team 3 to win on away goals, got %+v", resp.Result)
+		}
+
+		// Without the away goals rule the tie stays level
+		_, resp = getResult(db, "team1=1&team2=3&away_goals=false")
+		if resp.Result.WinnerID != nil || resp.Result.DecidedBy != "level" {
+			t.Errorf("Expected a level tie without the away goals rule, got %+v", resp.Result)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 1, 1, 2, 2, 0)
+
+		tests := []struct {
+			query          string
+			expectedStatus int
+		}{
+			{"team1=1&team2=2", http.StatusConflict},
+			{"team1=1&team2=1", http.StatusBadRequest},
+			{"team1=1", http.StatusBadRequest},
+			{"team1=1&team2=2&away_goals=maybe", http.StatusBadRequest},
+			{"team1=1&team2=99", http.StatusNotFound},
+		}
+
+		for _, tt := range tests {
+			w, _ := getResult(db, tt.query)
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Query %s: expected status %d, got %d", tt.query, tt.expectedStatus, w.Code)
+			}
+		}
+	})
+}
+
+func TestCreateLeagueHandler_ReportsAllValidationErrors(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	body := `{"name": "", "zones": {"champion_spots": -1, "relegation_spots": -2}, "score_correlation": 2, "upset_factor": -0.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var resp models.ValidationErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	var fields []string
+	for _, fieldErr := range resp.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	expected := []string{"name", "zones.champion_spots", "zones.relegation_spots", "score_correlation", "upset_factor"}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected errors for %v, got %v", expected, fields)
+	}
+
+	if len(db.leagues) != 1 {
+		t.Errorf("Expected no league to be created")
+	}
+}
+
+func TestLeagueReplayHandler(t *testing.T) {
+	replaySeason := func(t *testing.T, db *fakeLeagueDB) {
+		t.Helper()
+		handler := NewLeagueHandler(db)
+		startFakeLeague(t, handler)
+
+		w := httptest.NewRecorder()
+		handler.AdvanceWeeksHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-weeks/1", strings.NewReader(`{"count": 6}`)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance weeks: status %d: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		handler.LeagueReplayHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/replay", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp models.LeagueReplayResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		// One snapshot per played week, holding that week's results
+		if len(resp.Weeks) != db.leagues[1].CurrentWeek || resp.Truncated {
+			t.Fatalf("Expected %d untruncated weeks, got %d (truncated %v)", db.leagues[1].CurrentWeek, len(resp.Weeks), resp.Truncated)
+		}
+		results := 0
+		for i, week := range resp.Weeks {
+			if week.Week != i+1 {
+				t.Errorf("Expected week %d at index %d, got %d", i+1, i, week.Week)
+			}
+			if len(week.Standings) != len(db.standings[1]) {
+				t.Errorf("Expected %d teams in week %d's standings, got %d", len(db.standings[1]), week.Week, len(week.Standings))
+			}
+			for _, result := range week.Results {
+				if result.Match.Week != week.Week {
+					t.Errorf("Expected only week %d results, got a week %d match", week.Week, result.Match.Week)
+				}
+			}
+			results += len(week.Results)
+		}
+		if results != len(db.matches) {
+			t.Errorf("Expected all %d played matches in the replay, got %d", len(db.matches), results)
+		}
+
+		// The last snapshot is the final table
+		final, _ := db.GetStandings(context.Background(), 1)
+		if last := resp.Weeks[len(resp.Weeks)-1].Standings; !reflect.DeepEqual(last, final) {
+			t.Errorf("Expected the last snapshot to match the final table\n got: %+v\nwant: %+v", last, final)
+		}
+	}
+
+	t.Run("full season", func(t *testing.T) {
+		replaySeason(t, newFakeLeagueDB(fakeTeams()))
+	})
+
+	t.Run("bye points", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams()[:3])
+		db.leagues[1].ByePoints = 2
+		replaySeason(t, db)
+	})
+
+	t.Run("forfeited future match", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams()[:3])
+		db.leagues[1].ByePoints = 2
+		handler := NewLeagueHandler(db)
+		startFakeLeague(t, handler)
+
+		w := httptest.NewRecorder()
+		handler.AdvanceWeekHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/advance-week/1", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to advance week: status %d: %s", w.Code, w.Body.String())
+		}
+
+		var future *models.Match
+		for _, match := range db.matches {
+			if match.Week == 4 {
+				future = match
+			}
+		}
+		w = httptest.NewRecorder()
+		handler.ForfeitMatchHandler(w, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/matches/%d/forfeit", future.ID), strings.NewReader(`{"side": "home"}`)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Failed to forfeit match: status %d: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		handler.LeagueReplayHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/replay", nil))
+		var resp models.LeagueReplayResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		// The replay runs on to the forfeited match's week, without byes for the weeks not yet reached
+		if len(resp.Weeks) != 4 || len(resp.Weeks[3].Results) != 1 || resp.Weeks[3].Results[0].Match.ID != future.ID {
+			t.Fatalf("Expected 4 weeks ending with the forfeited match, got %+v", resp.Weeks)
+		}
+		final, _ := db.GetStandings(context.Background(), 1)
+		if last := resp.Weeks[len(resp.Weeks)-1].Standings; !reflect.DeepEqual(last, final) {
+			t.Errorf("Expected the last snapshot to match the current table\n got: %+v\nwant: %+v", last, final)
+		}
+	})
+
+	t.Run("unstarted league", func(t *testing.T) {
+		handler := NewLeagueHandler(newFakeLeagueDB(fakeTeams()))
+		w := httptest.NewRecorder()
+		handler.LeagueReplayHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/replay", nil))
+		var resp models.LeagueReplayResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if w.Code != http.StatusOK || len(resp.Weeks) != 0 {
+			t.Errorf("Expected an empty replay, got status %d and %d weeks", w.Code, len(resp.Weeks))
+		}
+	})
+}
+
+func TestResultProbabilities(t *testing.T) {
+	for _, expectancies := range [][2]float64{{0.8, 0.8}, {1.5, 0.7}, {2.5, 0.5}, {0.6, 2.6}} {
+		homeWin, draw, awayWin := resultProbabilities(expectancies[0], expectancies[1])
+		if total := homeWin + draw + awayWin; math.Abs(total-1) > 1e-9 {
+			t.Errorf("Expected probabilities for %v to sum to 1, got %f", expectancies, total)
+		}
+	}
+
+	if homeWin, _, awayWin := resultProbabilities(0.8, 0.8); math.Abs(homeWin-awayWin) > 1e-9 {
+		t.Errorf("Expected even chances for equal expectancies, got %f and %f", homeWin, awayWin)
+	}
+	if homeWin, _, awayWin := resultProbabilities(2.5, 0.5); homeWin <= awayWin {
+		t.Errorf("Expected the high-scoring side to be favoured, got %f against %f", homeWin, awayWin)
+	}
+}
+
+func TestExpectedPointsHandler(t *testing.T) {
+	db := newFakeLeagueDB([]*models.Team{
+		{ID: 1, Name: "Alpha", Strength: 90},
+		{ID: 2, Name: "Minnow", Strength: 30},
+	})
+	addPlayedFakeMatch(db, 1, 1, 2, 0, 1) // Minnow wins away against a far stronger side
+	addPlayedFakeMatch(db, 2, 2, 1, 0, 3)
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.ExpectedPointsHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/expected-points", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.ExpectedPointsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Teams) != 2 {
+		t.Fatalf("Expected 2 teams, got %d", len(resp.Teams))
+	}
+
+	minnow, alpha := resp.Teams[0], resp.Teams[1]
+	if minnow.TeamName != "Minnow" || alpha.TeamName != "Alpha" {
+		t.Fatalf("Expected Minnow ahead of Alpha as the over-performer, got %s then %s", minnow.TeamName, alpha.TeamName)
+	}
+	if minnow.Played != 2 || minnow.Points != 3 || alpha.Played != 2 || alpha.Points != 3 {
+		t.Errorf("Expected both teams on 3 points from 2 matches, got %+v and %+v", minnow, alpha)
+	}
+	if minnow.ExpectedPoints >= 1.5 || minnow.Points <= int(math.Ceil(minnow.ExpectedPoints)) || minnow.Difference <= 0 {
+		t.Errorf("Expected Minnow's points well above its expected points, got %+v", minnow)
+	}
+	if alpha.Difference >= 0 {
+		t.Errorf("Expected Alpha below its expected points, got %+v", alpha)
+	}
+}
+
+func TestWeekAnalysisHandler(t *testing.T) {
+	getAnalysis := func(db *fakeLeagueDB) models.WeekAnalysisResponse {
+		t.Helper()
+		handler := NewLeagueHandler(db)
+		w := httptest.NewRecorder()
+		handler.WeekAnalysisHandler(w, httptest.NewRequest(http.MethodGet, "/api/leagues/1/week-analysis", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var resp models.WeekAnalysisResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("known fixtures", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 1, 1, 2, 1, 1) // week 1: 3 goals, margins 0 and 1
+		addPlayedFakeMatch(db, 1, 3, 4, 0, 1)
+		addPlayedFakeMatch(db, 2, 2, 3, 4, 3) // week 2: 9 goals, margins 1 and 2
+		addPlayedFakeMatch(db, 2, 4, 1, 2, 0)
+		addPlayedFakeMatch(db, 3, 1, 3, 5, 0) // week 3: 6 goals, margins 5 and 1
+		addPlayedFakeMatch(db, 3, 2, 4, 0, 1)
+
+		resp := getAnalysis(db)
+		want := []models.WeekAnalysis{
+			{Week: 1, MatchesPlayed: 2, TotalGoals: 3, AverageMargin: 0.5},
+			{Week: 2, MatchesPlayed: 2, TotalGoals: 9, AverageMargin: 1.5},
+			{Week: 3, MatchesPlayed: 2, TotalGoals: 6, AverageMargin: 3},
+		}
+		if len(resp.Weeks) != len(want) {
+			t.Fatalf("Expected %d weeks, got %+v", len(want), resp.Weeks)
+		}
+		for i := range want {
+			if resp.Weeks[i] != want[i] {
+				t.Errorf("Expected week %+v, got %+v", want[i], resp.Weeks[i])
+			}
+		}
+
+		if week := resp.HighestScoringWeek; week == nil || week.Week != 2 {
+			t.Errorf("Expected week 2 as the highest-scoring week, got %+v", week)
+		}
+		if week := resp.MostOneSidedWeek; week == nil || week.Week != 3 {
+			t.Errorf("Expected week 3 as the most one-sided week, got %+v", week)
+		}
+		if week := resp.TightestWeek; week == nil || week.Week != 1 {
+			t.Errorf("Expected week 1 as the tightest week, got %+v", week)
+		}
+	})
+
+	t.Run("unplayed matches are ignored and ties go to the earliest week", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 1, 1, 2, 2, 0)
+		addPlayedFakeMatch(db, 2, 3, 4, 0, 2)
+		db.matches = append(db.matches, &models.Match{ID: db.nextMatchID, LeagueID: 1, HomeTeamID: 1, AwayTeamID: 3, Week: 3, Status: "scheduled"})
+
+		resp := getAnalysis(db)
+		if len(resp.Weeks) != 2 {
+			t.Fatalf("Expected only the 2 played weeks, got %+v", resp.Weeks)
+		}
+		for name, week := range map[string]*models.WeekAnalysis{
+			"highest-scoring": resp.HighestScoringWeek,
+			"most one-sided":  resp.MostOneSidedWeek,
+			"tightest":        resp.TightestWeek,
+		} {
+			if week == nil || week.Week != 1 {
+				t.Errorf("Expected week 1 as the %s week, got %+v", name, week)
+			}
+		}
+	})
+
+	t.Run("no played weeks", func(t *testing.T) {
+		resp := getAnalysis(newFakeLeagueDB(fakeTeams()))
+		if len(resp.Weeks) != 0 || resp.HighestScoringWeek != nil || resp.MostOneSidedWeek != nil || resp.TightestWeek != nil {
+			t.Errorf("Expected no weeks for an unplayed league, got %+v", resp)
+		}
+	})
+}
+
+func TestLeagueRecordsHandler(t *testing.T) {
+	getRecords := func(db *fakeLeagueDB) models.LeagueRecordsResponse {
+		t.Helper()
+		handler := NewLeagueHandler(db)
+		req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/records", nil)
+		w := httptest.NewRecorder()
+		handler.LeagueRecordsHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var resp models.LeagueRecordsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("known fixtures", func(t *testing.T) {
+		db := newFakeLeagueDB(fakeTeams())
+		addPlayedFakeMatch(db, 1, 1, 2, 1, 1) // Alpha 1-1 Bravo
+		addPlayedFakeMatch(db, 1, 3, 4, 0, 4) // Charlie 0-4 Delta: biggest win
+		addPlayedFakeMatch(db, 2, 2, 3, 4, 3) // Bravo 4-3 Charlie: highest scoring
+		addPlayedFakeMatch(db, 3, 4, 1, 5, 2) // Delta 5-2 Alpha: most goals, ties the highest scoring later
+
+		resp := getRecords(db)
+		if resp.MatchesPlayed != 4 {
+			t.Errorf("Expected 4 matches played, got %d", resp.MatchesPlayed)
+		}
+
+		if rec := resp.BiggestWin; rec == nil || rec.Value != 4 || rec.Match.Match.ID != 2 || rec.TeamName != "Delta" {
+			t.Errorf("Expected Delta's 4-goal win in match 2 as biggest win, got %+v", rec)
+		}
+		if rec := resp.HighestScoring; rec == nil || rec.Value != 7 || rec.Match.Match.ID != 3 || rec.Match.Match.Week != 2 {
+			t.Errorf("Expected the earliest 7-goal match (3) as highest scoring, got %+v", rec)
+		}
+		if rec := resp.MostGoalsByTeam; rec == nil || rec.Value != 5 || rec.Match.Match.ID != 4 || rec.TeamID == nil || *rec.TeamID != 4 {
+			t.Errorf("Expected Delta's 5 goals in match 4 as most goals by a team, got %+v", rec)
+		}
+	})
+
+	t.Run("no played matches", func(t *testing.T) {
+		resp := getRecords(newFakeLeagueDB(fakeTeams()))
+		if resp.MatchesPlayed != 0 || resp.BiggestWin != nil || resp.HighestScoring != nil || resp.MostGoalsByTeam != nil {
+			t.Errorf("Expected no records, got %+v", resp)
+		}
+	})
+}
+
+// summaryDB returns a fixed league summary
+type summaryDB struct {
+	*mockDBService
+	summary *models.LeagueSummary
+}
+
+func (s *summaryDB) GetLeagueSummary(ctx context.Context) (*models.LeagueSummary, error) {
+	return s.summary, nil
+}
+
+func TestLeagueSummaryHandler(t *testing.T) {
+	db := &summaryDB{summary: &models.LeagueSummary{
+		LeaguesByStatus: map[string]int{"created": 2, "finished": 3},
+		TotalTeams:      12,
+	}}
+	handler := NewLeagueHandler(db)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/summary", nil)
+	w := httptest.NewRecorder()
+	handler.LeagueSummaryHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp models.LeagueSummary
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.TotalLeagues != 5 || resp.TotalTeams != 12 {
+		t.Errorf("Expected 5 leagues and 12 teams, got %d leagues and %d teams", resp.TotalLeagues, resp.TotalTeams)
+	}
+	expected := map[string]int{"created": 2, "started": 0, "finished": 3}
+	for status, count := range expected {
+		if got, ok := resp.LeaguesByStatus[status]; !ok || got != count {
+			t.Errorf("Expected %d %s leagues, got %d (present: %v)", count, status, got, ok)
+		}
+	}
+}
+
+// leaderboardDB returns fixed leaderboard entries and records the requested limit
+type leaderboardDB struct {
+	*mockDBService
+	entries []models.LeaderboardEntry
+	limit   int
+}
+
+func (l *leaderboardDB) GetLeaderboard(ctx context.Context, limit int) ([]models.LeaderboardEntry, error) {
+	l.limit = limit
+	return l.entries, nil
+}
+
+func TestLeaderboardHandler(t *testing.T) {
+	db := &leaderboardDB{entries: []models.LeaderboardEntry{
+		{Rank: 1, TeamID: 2, TeamName: "Bravo", Leagues: 2, Points: 21, Wins: 7},
+		{Rank: 2, TeamID: 1, TeamName: "Alpha", Leagues: 2, Points: 18, Wins: 5},
+	}}
+	handler := NewLeagueHandler(db)
+
+	for query, expectedLimit := range map[string]int{"": 10, "?limit=1": 1, "?limit=500": 100} {
+		w := httptest.NewRecorder()
+		handler.LeaderboardHandler(w, httptest.NewRequest(http.MethodGet, "/api/leaderboard"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %q, got %d", http.StatusOK, query, w.Code)
+		}
+		var resp models.LeaderboardResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if db.limit != expectedLimit || resp.Limit != expectedLimit {
+			t.Errorf("Expected limit %d for %q, got %d (response %d)", expectedLimit, query, db.limit, resp.Limit)
+		}
+		if len(resp.Entries) != 2 || resp.Entries[0].TeamName != "Bravo" || resp.Entries[0].Points != 21 {
+			t.Errorf("Unexpected entries for %q: %+v", query, resp.Entries)
+		}
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=abc"} {
+		w := httptest.NewRecorder()
+		handler.LeaderboardHandler(w, httptest.NewRequest(http.MethodGet, "/api/leaderboard"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestMatchSimulation_FatigueInCongestedFixtures(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+	league := db.leagues[1]
+	league.FatiguePenalty = 5
+
+	// Alpha beat Bravo on a Saturday
+	kickoff := time.Date(2025, 8, 16, 15, 0, 0, 0, time.UTC)
+	addPlayedFakeMatch(db, 1, 1, 2, 1, 0)
+	db.matches[0].KickoffTime = &kickoff
+
+	fixture := func(daysLater int) *models.Match {
+		next := kickoff.AddDate(0, 0, daysLater)
+		return &models.Match{ID: 100, LeagueID: 1, HomeTeamID: 1, AwayTeamID: 3, Week: 2, Status: "scheduled", KickoffTime: &next}
+	}
+
+	// Alpha hosts Charlie three days later, Charlie having not played
+	congested := handler.matchSimulation(league, fixture(3))
+	if !congested.homeFatigued || congested.awayFatigued {
+		t.Fatalf("Expected only Alpha to be fatigued, got home %v away %v", congested.homeFatigued, congested.awayFatigued)
+	}
+
+	freshHome, freshAway := handler.simulatedExpectancy(90, 60, defaultHomeAdvantage, leagueSimulation(league))
+	tiredHome, tiredAway := handler.simulatedExpectancy(90, 60, defaultHomeAdvantage, congested)
+	if tiredHome >= freshHome {
+		t.Errorf("Expected fatigue to reduce Alpha's expectancy, got %.2f fresh and %.2f fatigued", freshHome, tiredHome)
+	}
+	if freshHome-tiredHome > 0.1 {
+		t.Errorf("Expected only a slight reduction, got %.2f fresh and %.2f fatigued", freshHome, tiredHome)
+	}
+	if tiredAway <= freshAway {
+		t.Errorf("Expected Charlie to benefit from Alpha's fatigue, got %.2f fresh and %.2f", freshAway, tiredAway)
+	}
+
+	// A week's rest, or a league without a fatigue penalty, means no fatigue
+	if rested := handler.matchSimulation(league, fixture(7)); rested.homeFatigued || rested.awayFatigued {
+		t.Errorf("Expected no fatigue after a week's rest, got %+v", rested)
+	}
+	league.FatiguePenalty = 0
+	if off := handler.matchSimulation(league, fixture(3)); off.homeFatigued || off.awayFatigued {
+		t.Errorf("Expected no fatigue without a penalty, got %+v", off)
+	}
+}
+
+func TestGenerateMatchResult_RivalryVariance(t *testing.T) {
+	// Four teams of equal strength; only Alpha and Bravo are rivals
+	db := newRivalryDB(
+		&models.Team{ID: 1, Name: "Alpha", Strength: 60},
+		&models.Team{ID: 2, Name: "Bravo", Strength: 60},
+		&models.Team{ID: 3, Name: "Charlie", Strength: 60},
+		&models.Team{ID: 4, Name: "Delta", Strength: 60},
+	)
+	db.AddRivalry(context.Background(), 1, 2)
+	handler := NewLeagueHandler(db)
+	const matches = 4000
+
+	goalDifferenceVariance := func(homeTeamID, awayTeamID int) float64 {
+		differences := make([]float64, matches)
+		mean := 0.0
+		for i := range differences {
+			homeGoals, awayGoals := handler.generateMatchResult(homeTeamID, awayTeamID, simulationSettings{})
+			differences[i] = float64(homeGoals - awayGoals)
+			mean += differences[i] / matches
+		}
+		variance := 0.0
+		for _, difference := range differences {
+			variance += (difference - mean) * (difference - mean) / matches
+		}
+		return variance
+	}
+
+	rivalry := goalDifferenceVariance(1, 2)
+	normal := goalDifferenceVariance(3, 4)
+	if rivalry <= normal {
+		t.Errorf("Expected rivalry results to vary more than normal ones, got goal difference variance %.2f vs %.2f", rivalry, normal)
+	}
+}
+
+func TestSimulateMatch_ScoringPresetsAreDistinguishable(t *testing.T) {
+	handler := NewLeagueHandler(&mockDBService{})
+	const matches = 4000
+
+	averages := make(map[string]float64)
+	for name, profile := range models.ScoringPresets {
+		totalGoals := 0
+		for i := 0; i < matches; i++ {
+			homeGoals, awayGoals := handler.simulateMatch(60, 60, simulationSettings{scoring: profile})
+			totalGoals += homeGoals + awayGoals
+		}
+		averages[name] = float64(totalGoals) / matches
+	}
+
+	// Each archetype should average clearly more goals per match than the one before it
+	if averages["balanced"]-averages["defensive"] < 0.5 || averages["attacking"]-averages["balanced"] < 0.5 {
+		t.Errorf("Expected defensive < balanced < attacking goals per match, got %.2f, %.2f, %.2f",
+			averages["defensive"], averages["balanced"], averages["attacking"])
+	}
+}
+
+func TestCreateLeagueHandler_ScoringPreset(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Goal Fest", "scoring_preset": "attacking"}`))
+	w := httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp models.LeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Scoring != models.ScoringPresets["attacking"] || db.leagues[resp.ID].Scoring != models.ScoringPresets["attacking"] {
+		t.Errorf("Expected the attacking profile to be stored, got %+v", resp.Scoring)
+	}
+
+	// Omitting the preset stores the balanced values
+	req = httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Plain"}`))
+	w = httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Scoring != models.ScoringPresets[models.DefaultScoringPreset] {
+		t.Errorf("Expected the default profile, got %+v", resp.Scoring)
+	}
+
+	// Unknown presets are rejected
+	req = httptest.NewRequest(http.MethodPost, "/api/leagues/create", strings.NewReader(`{"name": "Odd", "scoring_preset": "chaotic"}`))
+	w = httptest.NewRecorder()
+	handler.CreateLeagueHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown preset, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestStartLeagueHandler_MatchesPerWeek(t *testing.T) {
+	teams := append(fakeTeams(),
+		&models.Team{ID: 5, Name: "Echo", Strength: 50},
+		&models.Team{ID: 6, Name: "Foxtrot", Strength: 40},
+	)
+	db := newFakeLeagueDB(teams)
+	db.leagues[1].MatchesPerWeek = 1
+	handler := NewLeagueHandler(db)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/leagues/start/1", nil)
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp models.StartLeagueResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// 10 rounds of 3 matches, one match a week
+	if resp.TotalWeeks != 30 {
+		t.Errorf("Expected 30 weeks, got %d", resp.TotalWeeks)
+	}
+	if len(db.matches) != 30 {
+		t.Fatalf("Expected 30 matches, got %d", len(db.matches))
+	}
+
+	perWeek := make(map[int]int)
+	fixtures := make(map[[2]int]int)
+	for _, match := range db.matches {
+		perWeek[match.Week]++
+		fixtures[[2]int{match.HomeTeamID, match.AwayTeamID}]++
+	}
+	for week := 1; week <= 30; week++ {
+		if perWeek[week] != 1 {
+			t.Errorf("Expected 1 match in week %d, got %d", week, perWeek[week])
+		}
+	}
+	for _, home := range teams {
+		for _, away := range teams {
+			if home.ID != away.ID && fixtures[[2]int{home.ID, away.ID}] != 1 {
+				t.Errorf("Expected %s to host %s once, got %d", home.Name, away.Name, fixtures[[2]int{home.ID, away.ID}])
+			}
+		}
+	}
+}
+
+func TestGenerateRoundRobinMatches_OddTeamCount(t *testing.T) {
+	handler := NewLeagueHandler(&mockDBService{})
+
+	// A team with the ID the bye used to be given must still play all its matches
+	for _, ids := range [][]int{{1, 2, 3, 4, 5}, {-1, 2, 3}, {0, -1, 7}} {
+		var teams []*models.Team
+		for _, id := range ids {
+			teams = append(teams, &models.Team{ID: id, Name: fmt.Sprintf("Team %d", id), Strength: 50})
+		}
+
+		matches := handler.generateRoundRobinMatches(teams, 1)
+		n := len(teams)
+		if want := n * (n - 1); len(matches) != want {
+			t.Errorf("Teams %v: expected %d matches, got %d", ids, want, len(matches))
+		}
+
+		fixtures := make(map[[2]int]int)
+		playing := make(map[[2]int]int)
+		for _, match := range matches {
+			fixtures[[2]int{match.HomeTeamID, match.AwayTeamID}]++
+			playing[[2]int{match.HomeTeamID, match.Week}]++
+			playing[[2]int{match.AwayTeamID, match.Week}]++
+		}
+		for _, home := range ids {
+			for _, away := range ids {
+				if home != away && fixtures[[2]int{home, away}] != 1 {
+					t.Errorf("Teams %v: expected %d to host %d once, got %d", ids, home, away, fixtures[[2]int{home, away}])
+				}
+			}
+		}
+
+		// Each week one team sits out on the bye and the rest play once
+		for week := 1; week <= 2*n; week++ {
+			byes := 0
+			for _, id := range ids {
+				switch playing[[2]int{id, week}] {
+				case 0:
+					byes++
+				case 1:
+				default:
+					t.Errorf("Teams %v: team %d plays more than once in week %d", ids, id, week)
+				}
+			}
+			if byes != 1 {
+				t.Errorf("Teams %v: expected 1 team on a bye in week %d, got %d", ids, week, byes)
+			}
+		}
+	}
+}
+
+// strengthGap averages the strength gap between opponents over weeks from to to of a schedule
+func strengthGap(matches []models.Match, teams []*models.Team, from, to int) float64 {
+	strengths := make(map[int]int, len(teams))
+	for _, team := range teams {
+		strengths[team.ID] = team.Strength
+	}
+	total, count := 0.0, 0
+	for _, match := range matches {
+		if match.Week >= from && match.Week <= to {
+			total += math.Abs(float64(strengths[match.HomeTeamID] - strengths[match.AwayTeamID]))
+			count++
+		}
+	}
+	return total / float64(count)
+}
+
+func TestBalanceRoundOrder(t *testing.T) {
+	handler := NewLeagueHandler(&mockDBService{})
+	teams := []*models.Team{
+		{ID: 1, Name: "Alpha", Strength: 95},
+		{ID: 2, Name: "Bravo", Strength: 15},
+		{ID: 3, Name: "Charlie", Strength: 90},
+		{ID: 4, Name: "Delta", Strength: 20},
+		{ID: 5, Name: "Echo", Strength: 60},
+		{ID: 6, Name: "Foxtrot", Strength: 55},
+	}
+
+	naive := handler.generateRoundRobinMatches(teams, 1)
+	balanced := balanceRoundOrder(handler.generateRoundRobinMatches(teams, 1), teams)
+
+	// The opening weeks pair closer teams than the naive order
+	for _, weeks := range []int{1, 2} {
+		naiveGap, balancedGap := strengthGap(naive, teams, 1, weeks), strengthGap(balanced, teams, 1, weeks)
+		if balancedGap >= naiveGap {
+			t.Errorf("Expected a smaller average gap over the first %d weeks than the naive %.2f, got %.2f", weeks, naiveGap, balancedGap)
+		}
+	}
+
+	// Within each half the gaps only widen
+	for week := 2; week <= 10; week++ {
+		if week == 6 {
+			continue
+		}
+		if previous, current := strengthGap(balanced, teams, week-1, week-1), strengthGap(balanced, teams, week, week); current < previous {
+			t.Errorf("Expected week %d's gap %.2f to be at least week %d's %.2f", week, current, week-1, previous)
+		}
+	}
+
+	// Only the order changes: the same fixtures, one match per team a week, second half mirroring the first
+	fixtures := make(map[[2]int]int)
+	for _, match := range naive {
+		fixtures[[2]int{match.HomeTeamID, match.AwayTeamID}] = match.Week
+	}
+	weekOf := make(map[[2]int]int)
+	playing := make(map[[2]int]bool)
+	for _, match := range balanced {
+		fixture := [2]int{match.HomeTeamID, match.AwayTeamID}
+		if _, ok := fixtures[fixture]; !ok {
+			t.Errorf("Unexpected fixture %v in the balanced schedule", fixture)
+		}
+		weekOf[fixture] = match.Week
+		for _, teamID := range fixture {
+			if playing[[2]int{teamID, match.Week}] {
+				t.Errorf("Team %d plays twice in week %d", teamID, match.Week)
+			}
+			playing[[2]int{teamID, match.Week}] = true
+		}
+	}
+	if len(weekOf) != len(fixtures) {
+		t.Errorf("Expected %d fixtures, got %d", len(fixtures), len(weekOf))
+	}
+	for fixture, week := range weekOf {
+		if week <= 5 && weekOf[[2]int{fixture[1], fixture[0]}] != week+5 {
+			t.Errorf("Expected the return of %v in week %d, got week %d", fixture, week+5, weekOf[[2]int{fixture[1], fixture[0]}])
+		}
+	}
+}
+
+func TestStartLeagueHandler_BalancedSeeding(t *testing.T) {
+	db := newFakeLeagueDB(fakeTeams())
+	handler := NewLeagueHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?seeding=balanced", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Alpha 90 v Bravo 75 and Charlie 60 v Delta 45 is the closest round, so it opens each half
+	for _, match := range db.matches {
+		if match.Week != 1 && match.Week != 4 {
+			continue
+		}
+		pair := [2]int{min(match.HomeTeamID, match.AwayTeamID), max(match.HomeTeamID, match.AwayTeamID)}
+		if pair != [2]int{1, 2} && pair != [2]int{3, 4} {
+			t.Errorf("Expected only Alpha v Bravo and Charlie v Delta in week %d, got teams %v", match.Week, pair)
+		}
+	}
+
+	db = newFakeLeagueDB(fakeTeams())
+	handler = NewLeagueHandler(db)
+	w = httptest.NewRecorder()
+	handler.StartLeagueHandler(w, httptest.NewRequest(http.MethodPost, "/api/leagues/start/1?seeding=random", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown seeding, got %d", http.StatusBadRequest, w.Code)
+	}
+	if db.leagues[1].Status != "created" {
+		t.Errorf("Expected the league to stay created, got %s", db.leagues[1].Status)
+	}
+}
+
+func TestCalculateTotalWeeks_MatchesPerWeek(t *testing.T) {
+	handler := NewLeagueHandler(&mockDBService{})
+
+	tests := []struct {
+		numTeams       int
+		matchesPerWeek int
+		expected       int
+	}{
+		{4, 0, 6},
+		{4, 2, 6},
+		{4, 1, 12},
+		{6, 2, 20},
+		{5, 1, 20},
+	}
+
+	for _, tt := range tests {
+		if got := handler.calculateTotalWeeks(tt.numTeams, tt.matchesPerWeek); got != tt.expected {
+			t.Errorf("calculateTotalWeeks(%d, %d) = %d, expected %d", tt.numTeams, tt.matchesPerWeek, got, tt.expected)
+		}
+	}
+}
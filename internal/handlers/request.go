@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// errEmptyBody is returned by decodeJSONBody when the request has no body
+var errEmptyBody = errors.New("request body is required")
+
+// decodeJSONBody decodes the JSON request body into v.
+// A missing or blank body returns errEmptyBody so it can be told apart from malformed JSON.
+func decodeJSONBody(r *http.Request, v any) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return errEmptyBody
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errEmptyBody
+		}
+		return err
+	}
+
+	return nil
+}
+
+// writeDecodeError responds with 400 Bad Request for a body decodeJSONBody couldn't decode.
+// A well-formed body with a value of the wrong type, such as a fractional or quoted strength,
+// is reported as a validation error on that field.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errEmptyBody) {
+		http.Error(w, "Request body is required", http.StatusBadRequest)
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		var errs validationErrors
+		errs.add(typeErr.Field, fmt.Sprintf("Must be %s, got %s", describeJSONType(typeErr.Type), typeErr.Value))
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+}
+
+// describeJSONType names the kind of JSON value a Go type decodes from
+func describeJSONType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Pointer:
+		return describeJSONType(t.Elem())
+	default:
+		return fmt.Sprintf("a %s", t)
+	}
+}
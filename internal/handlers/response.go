@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"insider-league-manager/internal/models"
+)
+
+// envelopeParam is the Accept media type parameter that asks for an enveloped response,
+// e.g. "Accept: application/json; envelope=true". Without it payloads are written raw.
+const envelopeParam = "envelope"
+
+// unplayedGoalsParam is the Accept media type parameter that chooses how the goals of an
+// unplayed match are rendered: "null" (the default) writes them as null, "omit" leaves the
+// home_goals and away_goals fields out, e.g. "Accept: application/json; unplayed_goals=omit".
+const unplayedGoalsParam = "unplayed_goals"
+
+// writeJSON writes a successful JSON response, wrapped in a models.ResponseEnvelope
+// when the request asks for one
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, payload any) {
+	body := payload
+	if wantsEnvelope(r) {
+		requestID := r.Header.Get("X-Request-ID")
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", requestID)
+
+		body = models.ResponseEnvelope{
+			Data: payload,
+			Meta: models.ResponseMeta{
+				RequestID: requestID,
+				Timestamp: time.Now().UTC(),
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	encoded := buf.Bytes()
+	if omitsUnplayedGoals(r) {
+		stripped, err := omitNullGoals(encoded)
+		if err != nil {
+			log.Printf("Failed to omit unplayed goals from response: %v", err)
+		} else {
+			encoded = stripped
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(encoded); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// acceptParams returns the value of a parameter in each media range of the Accept header that sets it
+func acceptParams(r *http.Request, name string) []string {
+	var values []string
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			if value, ok := params[name]; ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// wantsEnvelope reports whether any media range in the Accept header enables the envelope
+func wantsEnvelope(r *http.Request) bool {
+	for _, value := range acceptParams(r, envelopeParam) {
+		if enabled, err := strconv.ParseBool(value); err == nil && enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// omitsUnplayedGoals reports whether any media range in the Accept header asks for unplayed goals to be omitted
+func omitsUnplayedGoals(r *http.Request) bool {
+	for _, value := range acceptParams(r, unplayedGoalsParam) {
+		if strings.EqualFold(value, "omit") {
+			return true
+		}
+	}
+	return false
+}
+
+// omitNullGoals rewrites an encoded JSON document without any home_goals or away_goals
+// field whose value is null, keeping every other field in its original order
+func omitNullGoals(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := copyJSONValue(dec, &out, tok); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// copyJSONValue writes the value starting at tok to out, reading any nested values from dec
+func copyJSONValue(dec *json.Decoder, out *bytes.Buffer, tok json.Token) error {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		encoded, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		out.Write(encoded)
+		return nil
+	}
+
+	isObject := delim == '{'
+	out.WriteByte(byte(delim))
+	first := true
+	for dec.More() {
+		var key string
+		if isObject {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key = keyTok.(string)
+		}
+
+		valueTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if isObject && valueTok == nil && (key == "home_goals" || key == "away_goals") {
+			continue
+		}
+
+		if !first {
+			out.WriteByte(',')
+		}
+		first = false
+		if isObject {
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			out.Write(encodedKey)
+			out.WriteByte(':')
+		}
+		if err := copyJSONValue(dec, out, valueTok); err != nil {
+			return err
+		}
+	}
+
+	// Consume the closing delimiter
+	end, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	out.WriteByte(byte(end.(json.Delim)))
+	return nil
+}
+
+// newRequestID returns a random identifier for a request that didn't supply X-Request-ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"insider-league-manager/internal/models"
+)
+
+func TestWriteJSON_RawByDefault(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1", nil)
+	w := httptest.NewRecorder()
+
+	writeJSON(w, req, http.StatusOK, models.TeamResponse{ID: 1, Name: "Alpha", Strength: 90})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected content type application/json, got %s", contentType)
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if _, ok := body["data"]; ok {
+		t.Error("Expected a raw payload without an envelope")
+	}
+	if body["name"] != "Alpha" {
+		t.Errorf("Expected the raw team payload, got %v", body)
+	}
+}
+
+func TestWriteJSON_Envelope(t *testing.T) {
+	tests := []struct {
+		name      string
+		accept    string
+		requestID string
+	}{
+		{name: "generated request ID", accept: "application/json; envelope=true"},
+		{name: "client request ID", accept: "text/html, application/json;envelope=1", requestID: "abc-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/teams/1", nil)
+			req.Header.Set("Accept", tt.accept)
+			if tt.requestID != "" {
+				req.Header.Set("X-Request-ID", tt.requestID)
+			}
+			w := httptest.NewRecorder()
+
+			writeJSON(w, req, http.StatusCreated, models.TeamResponse{ID: 1, Name: "Alpha", Strength: 90})
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("Expected status %d, got %d", http.StatusCreated, w.Code)
+			}
+
+			var envelope struct {
+				Data models.TeamResponse `json:"data"`
+				Meta models.ResponseMeta `json:"meta"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&envelope); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if envelope.Data.Name != "Alpha" {
+				t.Errorf("Expected the team under data, got %+v", envelope.Data)
+			}
+			if envelope.Meta.RequestID == "" {
+				t.Error("Expected a request ID in meta")
+			}
+			if tt.requestID != "" && envelope.Meta.RequestID != tt.requestID {
+				t.Errorf("Expected request ID %s, got %s", tt.requestID, envelope.Meta.RequestID)
+			}
+			if w.Header().Get("X-Request-ID") != envelope.Meta.RequestID {
+				t.Error("Expected the X-Request-ID header to match meta.request_id")
+			}
+			if envelope.Meta.Timestamp.IsZero() {
+				t.Error("Expected a timestamp in meta")
+			}
+		})
+	}
+}
+
+func TestWriteJSON_EnvelopeDisabled(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1", nil)
+	req.Header.Set("Accept", "application/json; envelope=false")
+	w := httptest.NewRecorder()
+
+	writeJSON(w, req, http.StatusOK, models.TeamResponse{ID: 1, Name: "Alpha", Strength: 90})
+
+	var body map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if _, ok := body["data"]; ok {
+		t.Error("Expected a raw payload when the envelope is disabled")
+	}
+}
+
+func TestWriteJSON_UnplayedGoals(t *testing.T) {
+	homeGoals, awayGoals := 2, 1
+	payload := models.LeagueMatchesResponse{
+		Matches: []models.MatchResult{
+			{Match: models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2, Week: 1, Status: "scheduled"}, HomeTeam: "Alpha", AwayTeam: "Bravo"},
+			{Match: models.Match{ID: 2, HomeTeamID: 2, AwayTeamID: 1, Week: 2, Status: "played", HomeGoals: &homeGoals, AwayGoals: &awayGoals}, HomeTeam: "Bravo", AwayTeam: "Alpha", Result: "2-1"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		wantPresent bool
+	}{
+		{name: "null by default", accept: "", wantPresent: true},
+		{name: "explicit null", accept: "application/json; unplayed_goals=null", wantPresent: true},
+		{name: "omitted", accept: "application/json; unplayed_goals=omit", wantPresent: false},
+		{name: "omitted with envelope", accept: "application/json; envelope=true; unplayed_goals=omit", wantPresent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/leagues/1/matches", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			writeJSON(w, req, http.StatusOK, payload)
+
+			var body map[string]any
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if data, ok := body["data"].(map[string]any); ok {
+				body = data
+			}
+			matches := body["matches"].([]any)
+
+			scheduled := matches[0].(map[string]any)["match"].(map[string]any)
+			for _, field := range []string{"home_goals", "away_goals"} {
+				value, present := scheduled[field]
+				if present != tt.wantPresent {
+					t.Errorf("Expected %s present=%v on a scheduled match, got %v", field, tt.wantPresent, present)
+				}
+				if present && value != nil {
+					t.Errorf("Expected %s to be null on a scheduled match, got %v", field, value)
+				}
+			}
+
+			played := matches[1].(map[string]any)["match"].(map[string]any)
+			if played["home_goals"] != float64(2) || played["away_goals"] != float64(1) {
+				t.Errorf("Expected a played match to keep its goals, got %v", played)
+			}
+			if matches[1].(map[string]any)["home_team"] != "Bravo" {
+				t.Errorf("Expected other fields to be kept, got %v", matches[1])
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
-	"encoding/json"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
@@ -29,14 +34,14 @@ func (th *TeamHandler) CreateTeamHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req models.CreateTeamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
-	// Basic validation
-	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "Team name is required", http.StatusBadRequest)
+	// Validate every field, reporting all problems together
+	if errs := validateTeamRequest(&req); len(errs) > 0 {
+		writeValidationErrors(w, r, errs)
 		return
 	}
 
@@ -44,23 +49,18 @@ func (th *TeamHandler) CreateTeamHandler(w http.ResponseWriter, r *http.Request)
 	team, err := th.db.CreateTeam(r.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create team: %v", err)
-		http.Error(w, "Failed to create team", http.StatusInternalServerError)
+		if strings.Contains(err.Error(), "already taken") {
+			http.Error(w, fmt.Sprintf("A team named '%s' already exists", req.Name), http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to create team", http.StatusInternalServerError)
+		}
 		return
 	}
 
 	// Convert to response format
-	resp := models.TeamResponse{
-		ID:       team.ID,
-		Name:     team.Name,
-		Strength: team.Strength,
-	}
+	resp := models.NewTeamResponse(team)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusCreated, resp)
 }
 
 // GetAllTeamsHandler handles GET /api/teams
@@ -81,18 +81,10 @@ func (th *TeamHandler) GetAllTeamsHandler(w http.ResponseWriter, r *http.Request
 	// Convert to response format
 	var resp []models.TeamResponse
 	for _, team := range teams {
-		resp = append(resp, models.TeamResponse{
-			ID:       team.ID,
-			Name:     team.Name,
-			Strength: team.Strength,
-		})
+		resp = append(resp, models.NewTeamResponse(team))
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
 // GetTeamByIDHandler handles GET /api/teams/:teamID
@@ -109,9 +101,9 @@ func (th *TeamHandler) GetTeamByIDHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	teamID, err := strconv.Atoi(pathParts[2])
+	teamID, err := parsePathID(pathParts[2])
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -128,17 +120,9 @@ func (th *TeamHandler) GetTeamByIDHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	// Convert to response format
-	resp := models.TeamResponse{
-		ID:       team.ID,
-		Name:     team.Name,
-		Strength: team.Strength,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
+	resp := models.NewTeamResponse(team)
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
 // UpdateTeamHandler handles PUT /api/teams/:teamID
@@ -155,21 +139,21 @@ func (th *TeamHandler) UpdateTeamHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	teamID, err := strconv.Atoi(pathParts[2])
+	teamID, err := parsePathID(pathParts[2])
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
 	var req models.CreateTeamRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeDecodeError(w, r, err)
 		return
 	}
 
-	// Basic validation
-	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "Team name is required", http.StatusBadRequest)
+	// Validate every field, reporting all problems together
+	if errs := validateTeamRequest(&req); len(errs) > 0 {
+		writeValidationErrors(w, r, errs)
 		return
 	}
 
@@ -179,6 +163,8 @@ func (th *TeamHandler) UpdateTeamHandler(w http.ResponseWriter, r *http.Request)
 		log.Printf("Failed to update team with ID %d: %v", teamID, err)
 		if strings.Contains(err.Error(), "no rows") {
 			http.Error(w, "Team not found", http.StatusNotFound)
+		} else if strings.Contains(err.Error(), "already taken") {
+			http.Error(w, fmt.Sprintf("Another team is already named '%s'", req.Name), http.StatusConflict)
 		} else {
 			http.Error(w, "Failed to update team", http.StatusInternalServerError)
 		}
@@ -186,17 +172,9 @@ func (th *TeamHandler) UpdateTeamHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Convert to response format
-	resp := models.TeamResponse{
-		ID:       team.ID,
-		Name:     team.Name,
-		Strength: team.Strength,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
+	resp := models.NewTeamResponse(team)
 
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("Failed to encode response: %v", err)
-	}
+	writeJSON(w, r, http.StatusOK, resp)
 }
 
 // DeleteTeamHandler handles DELETE /api/teams/:teamID
@@ -213,9 +191,9 @@ func (th *TeamHandler) DeleteTeamHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	teamID, err := strconv.Atoi(pathParts[2])
+	teamID, err := parsePathID(pathParts[2])
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
 		return
 	}
 
@@ -234,3 +212,531 @@ func (th *TeamHandler) DeleteTeamHandler(w http.ResponseWriter, r *http.Request)
 	// Return 204 No Content for successful deletion
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetTeamLeaguesHandler handles GET /api/teams/:teamID/leagues
+func (th *TeamHandler) GetTeamLeaguesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract team ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "teams" || pathParts[3] != "leagues" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Validate team exists
+	if _, err := th.db.GetTeamByID(r.Context(), teamID); err != nil {
+		log.Printf("Failed to get team by ID %d: %v", teamID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Get the leagues the team belongs to
+	leagues, err := th.db.GetLeaguesForTeam(r.Context(), teamID)
+	if err != nil {
+		log.Printf("Failed to get leagues for team %d: %v", teamID, err)
+		http.Error(w, "Failed to get team leagues", http.StatusInternalServerError)
+		return
+	}
+
+	// Always encode an array, even for a team in no leagues
+	if leagues == nil {
+		leagues = []models.TeamLeague{}
+	}
+
+	writeJSON(w, r, http.StatusOK, leagues)
+}
+
+// headToHeadMaxLimit caps the number of meetings returned by the head-to-head endpoint
+const headToHeadMaxLimit = 100
+
+// GetHeadToHeadHandler handles GET /api/teams/:teamID/head-to-head/:opponentID?league_id=&limit=
+func (th *TeamHandler) GetHeadToHeadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract team IDs from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[0] != "api" || pathParts[1] != "teams" || pathParts[3] != "head-to-head" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	opponentID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid opponent ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if teamID == opponentID {
+		http.Error(w, "Team and opponent must be different", http.StatusBadRequest)
+		return
+	}
+
+	// Optional league scope; all-time by default
+	leagueID := 0
+	if value := r.URL.Query().Get("league_id"); value != "" {
+		leagueID, err = parsePathID(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid league_id: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Optional limit, capped to keep result sets bounded
+	limit := headToHeadMaxLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > headToHeadMaxLimit {
+			limit = headToHeadMaxLimit
+		}
+	}
+
+	ctx := r.Context()
+
+	// Validate both teams exist
+	team, err := th.db.GetTeamByID(ctx, teamID)
+	if err != nil {
+		log.Printf("Failed to get team by ID %d: %v", teamID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	opponent, err := th.db.GetTeamByID(ctx, opponentID)
+	if err != nil {
+		log.Printf("Failed to get team by ID %d: %v", opponentID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Opponent not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	matches, err := th.db.GetHeadToHead(ctx, teamID, opponentID, leagueID, limit)
+	if err != nil {
+		log.Printf("Failed to get head-to-head for teams %d and %d: %v", teamID, opponentID, err)
+		http.Error(w, "Failed to get head-to-head", http.StatusInternalServerError)
+		return
+	}
+
+	// Build the record from the team's perspective
+	var record models.HeadToHeadRecord
+	matchResponses := make([]models.Match, 0, len(matches))
+	for _, match := range matches {
+		matchResponses = append(matchResponses, *match)
+		if match.HomeGoals == nil || match.AwayGoals == nil {
+			continue
+		}
+
+		goalsFor, goalsAgainst := *match.HomeGoals, *match.AwayGoals
+		if match.AwayTeamID == teamID {
+			goalsFor, goalsAgainst = goalsAgainst, goalsFor
+		}
+
+		record.Played++
+		record.GoalsFor += goalsFor
+		record.GoalsAgainst += goalsAgainst
+		switch {
+		case goalsFor > goalsAgainst:
+			record.Wins++
+		case goalsFor < goalsAgainst:
+			record.Losses++
+		default:
+			record.Draws++
+		}
+	}
+
+	resp := models.HeadToHeadResponse{
+		Team:     models.NewTeamResponse(team),
+		Opponent: models.NewTeamResponse(opponent),
+		Limit:    limit,
+		Record:   record,
+		Matches:  matchResponses,
+	}
+	if leagueID != 0 {
+		resp.LeagueID = &leagueID
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
+
+// Limits and defaults for POST /api/teams/generate
+const (
+	maxGeneratedTeams           = 100
+	defaultGeneratedMinStrength = 40
+	defaultGeneratedMaxStrength = 90
+)
+
+// generatedTeamCities and generatedTeamSuffixes are combined to build random team names
+var (
+	generatedTeamCities   = []string{"Northport", "Eastvale", "Westbrook", "Southgate", "Riverton", "Highfield", "Lakeside", "Oakridge", "Stonebridge", "Fairhaven"}
+	generatedTeamSuffixes = []string{"United", "City", "Rovers", "Athletic", "Wanderers", "Albion", "Town", "Rangers"}
+)
+
+// GenerateTeamsHandler handles POST /api/teams/generate?count=&min_strength=&max_strength=
+func (th *TeamHandler) GenerateTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil || count < 1 || count > maxGeneratedTeams {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxGeneratedTeams), http.StatusBadRequest)
+		return
+	}
+
+	minStrength := defaultGeneratedMinStrength
+	if value := query.Get("min_strength"); value != "" {
+		if minStrength, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid min_strength", http.StatusBadRequest)
+			return
+		}
+	}
+
+	maxStrength := defaultGeneratedMaxStrength
+	if value := query.Get("max_strength"); value != "" {
+		if maxStrength, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid max_strength", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if minStrength < 0 || maxStrength > 100 || minStrength > maxStrength {
+		http.Error(w, "Strength range must satisfy 0 <= min_strength <= max_strength <= 100", http.StatusBadRequest)
+		return
+	}
+
+	// Create the teams together like normal teams, numbering names so they are unique within the
+	// batch. Either every team is created or none is.
+	reqs := make([]models.CreateTeamRequest, 0, count)
+	for i := 0; i < count; i++ {
+		reqs = append(reqs, models.CreateTeamRequest{
+			Name: fmt.Sprintf("%s %s %d",
+				generatedTeamCities[rand.Intn(len(generatedTeamCities))],
+				generatedTeamSuffixes[rand.Intn(len(generatedTeamSuffixes))],
+				i+1),
+			Strength: minStrength + rand.Intn(maxStrength-minStrength+1),
+		})
+	}
+
+	created, err := th.db.CreateTeams(r.Context(), reqs)
+	if err != nil {
+		log.Printf("Failed to create %d generated teams: %v", len(reqs), err)
+		if strings.Contains(err.Error(), "already taken") {
+			http.Error(w, "One of the generated team names is already taken; try again", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to create teams", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	teams := make([]models.TeamResponse, 0, len(created))
+	for _, team := range created {
+		teams = append(teams, models.NewTeamResponse(team))
+	}
+
+	writeJSON(w, r, http.StatusCreated, teams)
+}
+
+// maxImportedTeams caps the number of rows in a team import
+const maxImportedTeams = 500
+
+// importTeamColumns are the columns a team import can have; name and strength are required
+var importTeamColumns = map[string]bool{"name": true, "strength": true, "primary_color": true, "logo_url": true}
+
+// ImportTeamsHandler handles POST /api/teams/import
+// It reads teams from a text/csv body with a header row and creates them all in one transaction.
+// If any row is invalid nothing is created and every row's problems are reported.
+func (th *TeamHandler) ImportTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "text/csv" {
+		http.Error(w, "Content-Type must be text/csv", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	reader := csv.NewReader(r.Body)
+	reader.FieldsPerRecord = -1 // rows with the wrong number of fields are reported per row
+	reader.TrimLeadingSpace = true
+
+	// 1. Read the header and map each column to its position
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			http.Error(w, "CSV header row is required", http.StatusBadRequest)
+		} else {
+			http.Error(w, fmt.Sprintf("Invalid CSV: %v", err), http.StatusBadRequest)
+		}
+		return
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !importTeamColumns[name] {
+			http.Error(w, fmt.Sprintf("Unknown CSV column '%s'. Columns must be name, strength and optionally primary_color and logo_url", name), http.StatusBadRequest)
+			return
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		http.Error(w, "CSV header must include name and strength columns", http.StatusBadRequest)
+		return
+	}
+	if _, ok := columns["strength"]; !ok {
+		http.Error(w, "CSV header must include name and strength columns", http.StatusBadRequest)
+		return
+	}
+
+	// 2. Parse and validate every row, collecting problems instead of stopping at the first
+	var reqs []models.CreateTeamRequest
+	var rowErrors []models.TeamImportRowError
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid CSV: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if len(reqs)+len(rowErrors) == maxImportedTeams {
+			http.Error(w, fmt.Sprintf("A team import can have at most %d rows", maxImportedTeams), http.StatusBadRequest)
+			return
+		}
+
+		req, errs := parseImportedTeam(record, columns, len(header))
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, models.TeamImportRowError{Row: row, Errors: errs})
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+
+	if len(rowErrors) > 0 {
+		writeJSON(w, r, http.StatusBadRequest, models.ImportTeamsResponse{Teams: []models.TeamResponse{}, Errors: rowErrors})
+		return
+	}
+	if len(reqs) == 0 {
+		http.Error(w, "CSV has no team rows", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Create all the teams together
+	teams, err := th.db.CreateTeams(r.Context(), reqs)
+	if err != nil {
+		log.Printf("Failed to import %d teams: %v", len(reqs), err)
+		if strings.Contains(err.Error(), "already taken") {
+			http.Error(w, "One of the imported team names is already taken", http.StatusConflict)
+		} else {
+			http.Error(w, "Failed to import teams", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := models.ImportTeamsResponse{
+		Teams:  make([]models.TeamResponse, 0, len(teams)),
+		Errors: []models.TeamImportRowError{},
+	}
+	for _, team := range teams {
+		resp.Teams = append(resp.Teams, models.NewTeamResponse(team))
+	}
+
+	writeJSON(w, r, http.StatusCreated, resp)
+}
+
+// parseImportedTeam builds a team create request from one CSV row, validating it like a JSON request
+func parseImportedTeam(record []string, columns map[string]int, fields int) (models.CreateTeamRequest, validationErrors) {
+	var req models.CreateTeamRequest
+	var errs validationErrors
+	if len(record) != fields {
+		errs.add("row", fmt.Sprintf("Row has %d fields, expected %d", len(record), fields))
+		return req, errs
+	}
+
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	req.Name = value("name")
+	req.PrimaryColor = value("primary_color")
+	req.LogoURL = value("logo_url")
+
+	strength, err := strconv.Atoi(value("strength"))
+	if err != nil {
+		errs.add("strength", "Strength must be a whole number")
+	} else {
+		req.Strength = strength
+	}
+
+	for _, fieldErr := range validateTeamRequest(&req) {
+		// An unparsable strength is already reported
+		if err != nil && fieldErr.Field == "strength" {
+			continue
+		}
+		errs = append(errs, fieldErr)
+	}
+	return req, errs
+}
+
+// AddRivalryHandler handles POST /api/teams/:teamID/rivals/:rivalID
+// Matches between registered rivals are simulated with extra variance and a reduced home advantage.
+func (th *TeamHandler) AddRivalryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract team IDs from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[0] != "api" || pathParts[1] != "teams" || pathParts[3] != "rivals" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	rivalID, err := parsePathID(pathParts[4])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid rival ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if teamID == rivalID {
+		http.Error(w, "A team cannot be its own rival", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+
+	// Validate both teams exist
+	team, err := th.db.GetTeamByID(ctx, teamID)
+	if err != nil {
+		log.Printf("Failed to get team by ID %d: %v", teamID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	rival, err := th.db.GetTeamByID(ctx, rivalID)
+	if err != nil {
+		log.Printf("Failed to get team by ID %d: %v", rivalID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Rival not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := th.db.AddRivalry(ctx, teamID, rivalID); err != nil {
+		log.Printf("Failed to add rivalry between teams %d and %d: %v", teamID, rivalID, err)
+		http.Error(w, "Failed to add rivalry", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.RivalryResponse{
+		Team:    models.NewTeamResponse(team),
+		Rival:   models.NewTeamResponse(rival),
+		Message: fmt.Sprintf("'%s' and '%s' are now rivals", team.Name, rival.Name),
+	}
+
+	writeJSON(w, r, http.StatusCreated, resp)
+}
+
+// GetRivalsHandler handles GET /api/teams/:teamID/rivals
+func (th *TeamHandler) GetRivalsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract team ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[0] != "api" || pathParts[1] != "teams" || pathParts[3] != "rivals" {
+		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := parsePathID(pathParts[2])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid team ID: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Validate team exists
+	team, err := th.db.GetTeamByID(r.Context(), teamID)
+	if err != nil {
+		log.Printf("Failed to get team by ID %d: %v", teamID, err)
+		if strings.Contains(err.Error(), "no rows") {
+			http.Error(w, "Team not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get team", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	rivals, err := th.db.GetRivals(r.Context(), teamID)
+	if err != nil {
+		log.Printf("Failed to get rivals of team %d: %v", teamID, err)
+		http.Error(w, "Failed to get rivals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := models.RivalsResponse{
+		Team:   models.NewTeamResponse(team),
+		Rivals: make([]models.TeamResponse, 0, len(rivals)),
+	}
+	for _, rival := range rivals {
+		resp.Rivals = append(resp.Rivals, models.NewTeamResponse(rival))
+	}
+
+	writeJSON(w, r, http.StatusOK, resp)
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,12 +32,28 @@ func (m *mockDBService) InitializeTables(ctx context.Context) error {
 
 func (m *mockDBService) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
 	return &models.Team{
-		ID:       1,
-		Name:     req.Name,
-		Strength: req.Strength,
+		ID:           1,
+		Name:         req.Name,
+		Strength:     req.Strength,
+		PrimaryColor: req.PrimaryColor,
+		LogoURL:      req.LogoURL,
 	}, nil
 }
 
+func (m *mockDBService) CreateTeams(ctx context.Context, reqs []models.CreateTeamRequest) ([]*models.Team, error) {
+	teams := make([]*models.Team, 0, len(reqs))
+	for i, req := range reqs {
+		teams = append(teams, &models.Team{
+			ID:           i + 1,
+			Name:         req.Name,
+			Strength:     req.Strength,
+			PrimaryColor: req.PrimaryColor,
+			LogoURL:      req.LogoURL,
+		})
+	}
+	return teams, nil
+}
+
 func (m *mockDBService) GetAllTeams(ctx context.Context) ([]*models.Team, error) {
 	return []*models.Team{
 		{ID: 1, Name: "Team A", Strength: 85},
@@ -58,9 +76,11 @@ func (m *mockDBService) GetTeamByID(ctx context.Context, teamID int) (*models.Te
 func (m *mockDBService) UpdateTeam(ctx context.Context, teamID int, req *models.CreateTeamRequest) (*models.Team, error) {
 	if teamID == 1 {
 		return &models.Team{
-			ID:       1,
-			Name:     req.Name,
-			Strength: req.Strength,
+			ID:           1,
+			Name:         req.Name,
+			Strength:     req.Strength,
+			PrimaryColor: req.PrimaryColor,
+			LogoURL:      req.LogoURL,
 		}, nil
 	}
 	// Return error for any other ID to simulate not found
@@ -75,6 +95,18 @@ func (m *mockDBService) DeleteTeam(ctx context.Context, teamID int) error {
 	return fmt.Errorf("no team found with ID %d", teamID)
 }
 
+func (m *mockDBService) GetLeaguesForTeam(ctx context.Context, teamID int) ([]models.TeamLeague, error) {
+	first, second := 1, 3
+	return []models.TeamLeague{
+		{League: models.LeagueResponse{ID: 1, Name: "League One", Status: "started", CurrentWeek: 2}, Position: &first},
+		{League: models.LeagueResponse{ID: 2, Name: "League Two", Status: "created"}, Position: &second},
+	}, nil
+}
+
+func (m *mockDBService) GetHeadToHead(ctx context.Context, teamID, opponentID, leagueID, limit int) ([]*models.Match, error) {
+	return []*models.Match{}, nil
+}
+
 func (m *mockDBService) CreateLeague(ctx context.Context, req *models.CreateLeagueRequest) (*models.League, error) {
 	return &models.League{
 		ID:          1,
@@ -85,6 +117,10 @@ func (m *mockDBService) CreateLeague(ctx context.Context, req *models.CreateLeag
 	}, nil
 }
 
+func (m *mockDBService) InitializeLeagueWithTeams(ctx context.Context, req *models.CreateLeagueRequest, teams []*models.Team) (*models.League, error) {
+	return m.CreateLeague(ctx, req)
+}
+
 func (m *mockDBService) AddTeamToLeague(ctx context.Context, leagueID, teamID int) error {
 	return nil // Successful operation
 }
@@ -93,6 +129,38 @@ func (m *mockDBService) InitializeStanding(ctx context.Context, leagueID, teamID
 	return nil // Successful operation
 }
 
+func (m *mockDBService) CreateLeagueWithIdempotencyKey(ctx context.Context, key string, req *models.CreateLeagueRequest) (*models.League, bool, error) {
+	league, err := m.CreateLeague(ctx, req)
+	return league, true, err
+}
+
+func (m *mockDBService) CloneLeague(ctx context.Context, sourceLeagueID int, name string) (*models.League, error) {
+	if sourceLeagueID != 1 {
+		return nil, fmt.Errorf("no rows in result set")
+	}
+	return &models.League{
+		ID:          2,
+		Name:        name,
+		Status:      "created",
+		CurrentWeek: 0,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func (m *mockDBService) ImportLeague(ctx context.Context, req *models.ImportLeagueRequest) (*models.League, []*models.Team, error) {
+	teams := make([]*models.Team, 0, len(req.Teams))
+	for i, team := range req.Teams {
+		teams = append(teams, &models.Team{ID: 100 + i, Name: team.Name, Strength: team.Strength})
+	}
+	return &models.League{
+		ID:          1,
+		Name:        req.Name,
+		Status:      "created",
+		CurrentWeek: 0,
+		CreatedAt:   time.Now(),
+	}, teams, nil
+}
+
 func (m *mockDBService) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
 	return []*models.Team{
 		{ID: 1, Name: "Manchester City", Strength: 88},
@@ -102,6 +170,46 @@ func (m *mockDBService) GetDefaultTeams(ctx context.Context) ([]*models.Team, er
 	}, nil
 }
 
+func (m *mockDBService) GetMatchesByKickoffRange(ctx context.Context, leagueID int, from, to time.Time) ([]*models.Match, error) {
+	return []*models.Match{}, nil
+}
+
+func (m *mockDBService) UpdateLeagueConfig(ctx context.Context, leagueID int, config models.LeagueConfig) error {
+	return nil
+}
+
+func (m *mockDBService) GetLeagueSummary(ctx context.Context) (*models.LeagueSummary, error) {
+	return &models.LeagueSummary{LeaguesByStatus: map[string]int{}}, nil
+}
+
+func (m *mockDBService) RecordLeagueEvent(ctx context.Context, leagueID int, eventType, details string) error {
+	return nil
+}
+
+func (m *mockDBService) GetLeagueEvents(ctx context.Context, leagueID int) ([]models.LeagueEvent, error) {
+	return []models.LeagueEvent{}, nil
+}
+
+func (m *mockDBService) RecordSimulationRun(ctx context.Context, run models.SimulationRun) (*models.SimulationRun, error) {
+	return &run, nil
+}
+
+func (m *mockDBService) GetSimulationRuns(ctx context.Context, leagueID int) ([]models.SimulationRun, error) {
+	return []models.SimulationRun{}, nil
+}
+
+func (m *mockDBService) DeleteFinishedLeagues(ctx context.Context, finishedBefore time.Time) ([]int, error) {
+	return []int{}, nil
+}
+
+func (m *mockDBService) GetLeaderboard(ctx context.Context, limit int) ([]models.LeaderboardEntry, error) {
+	return []models.LeaderboardEntry{}, nil
+}
+
+func (m *mockDBService) GetAllLeagues(ctx context.Context, status string) ([]*models.League, error) {
+	return []*models.League{}, nil
+}
+
 func (m *mockDBService) GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error) {
 	if leagueID == 1 {
 		return &models.League{
@@ -125,6 +233,30 @@ func (m *mockDBService) RemoveTeamFromLeague(ctx context.Context, leagueID, team
 	return fmt.Errorf("team %d is not in league %d", teamID, leagueID)
 }
 
+func (m *mockDBService) AddRivalry(ctx context.Context, teamID, rivalID int) error {
+	return nil
+}
+
+func (m *mockDBService) GetRivals(ctx context.Context, teamID int) ([]*models.Team, error) {
+	return []*models.Team{}, nil
+}
+
+func (m *mockDBService) IsRivalry(ctx context.Context, teamID, rivalID int) (bool, error) {
+	return false, nil
+}
+
+func (m *mockDBService) GetTeamsNotInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	return []*models.Team{}, nil
+}
+
+func (m *mockDBService) SetTeamGroup(ctx context.Context, leagueID, teamID int, group string) error {
+	return nil
+}
+
+func (m *mockDBService) GetTeamGroups(ctx context.Context, leagueID int) (map[int]string, error) {
+	return map[int]string{}, nil
+}
+
 func (m *mockDBService) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
 	if leagueID == 1 {
 		return []*models.Team{
@@ -135,6 +267,29 @@ func (m *mockDBService) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*
 	return nil, fmt.Errorf("no teams found in league %d", leagueID)
 }
 
+func (m *mockDBService) ReplaceScheduledMatches(ctx context.Context, leagueID int, matches []models.Match) ([]*models.Match, error) {
+	created := make([]*models.Match, 0, len(matches))
+	for i, match := range matches {
+		match.ID = i + 1
+		match.LeagueID = leagueID
+		match.CreatedAt = time.Now()
+		created = append(created, &match)
+	}
+	return created, nil
+}
+
+func (m *mockDBService) GetVenueCounts(ctx context.Context, leagueID, teamID int) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *mockDBService) GetHomeAwaySplit(ctx context.Context, leagueID int) (*models.HomeAwaySplit, error) {
+	return &models.HomeAwaySplit{}, nil
+}
+
+func (m *mockDBService) TransitionLeagueStatus(ctx context.Context, leagueID int, fromStatus, toStatus string) error {
+	return nil
+}
+
 func (m *mockDBService) CreateMatch(ctx context.Context, match *models.Match) (*models.Match, error) {
 	// Return the match with an assigned ID
 	createdMatch := *match
@@ -154,10 +309,18 @@ func (m *mockDBService) GetMatchesByWeekAndLeague(ctx context.Context, leagueID,
 	return []*models.Match{}, nil
 }
 
+func (m *mockDBService) GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error) {
+	return []*models.Match{}, nil
+}
+
 func (m *mockDBService) PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals int) error {
 	return nil
 }
 
+func (m *mockDBService) AddStandingPoints(ctx context.Context, leagueID, teamID, points int) error {
+	return nil
+}
+
 func (m *mockDBService) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
 	return nil
 }
@@ -219,6 +382,14 @@ func (m *mockDBService) GetMatchByID(ctx context.Context, matchID int) (*models.
 	return nil, fmt.Errorf("no rows in result set")
 }
 
+func (m *mockDBService) UpdateMatchStatus(ctx context.Context, matchID int, fromStatus, toStatus string) error {
+	return nil
+}
+
+func (m *mockDBService) SwapMatchVenue(ctx context.Context, matchID int) error {
+	return nil
+}
+
 func (m *mockDBService) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayGoals int) error {
 	if matchID == 1 {
 		return nil // Successful edit
@@ -401,6 +572,62 @@ func TestUpdateTeamHandler_NotFound(t *testing.T) {
 	}
 }
 
+// takenNameDB rejects team names that are already in use, like a unique constraint on teams.name would
+type takenNameDB struct {
+	*mockDBService
+	names map[string]bool
+}
+
+func (db *takenNameDB) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
+	if db.names[req.Name] {
+		return nil, fmt.Errorf("failed to create team: team name %q is already taken: duplicate key value violates unique constraint", req.Name)
+	}
+	return db.mockDBService.CreateTeam(ctx, req)
+}
+
+func (db *takenNameDB) UpdateTeam(ctx context.Context, teamID int, req *models.CreateTeamRequest) (*models.Team, error) {
+	if db.names[req.Name] {
+		return nil, fmt.Errorf("failed to update team with ID %d: team name %q is already taken: duplicate key value violates unique constraint", teamID, req.Name)
+	}
+	return db.mockDBService.UpdateTeam(ctx, teamID, req)
+}
+
+func TestUpdateTeamHandler_NameTaken(t *testing.T) {
+	handler := NewTeamHandler(&takenNameDB{mockDBService: &mockDBService{}, names: map[string]bool{"Bravo": true}})
+
+	reqBody, _ := json.Marshal(models.CreateTeamRequest{Name: "Bravo", Strength: 80})
+	req := httptest.NewRequest(http.MethodPut, "/api/teams/1", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.UpdateTeamHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "already named 'Bravo'") {
+		t.Errorf("Expected a message naming the taken name, got %q", body)
+	}
+}
+
+func TestCreateTeamHandler_NameTaken(t *testing.T) {
+	handler := NewTeamHandler(&takenNameDB{mockDBService: &mockDBService{}, names: map[string]bool{"Bravo": true}})
+
+	reqBody, _ := json.Marshal(models.CreateTeamRequest{Name: "Bravo", Strength: 80})
+	req := httptest.NewRequest(http.MethodPost, "/api/teams", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.CreateTeamHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "team named 'Bravo' already exists") {
+		t.Errorf("Expected a message naming the taken name, got %q", body)
+	}
+}
+
 func TestGetTeamByIDHandler_NotFound(t *testing.T) {
 	handler := NewTeamHandler(&mockDBService{})
 
@@ -434,6 +661,39 @@ func TestCreateTeamHandler_EmptyName(t *testing.T) {
 	}
 }
 
+func TestCreateTeamHandler_NonIntegerStrength(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{"fractional", `{"name": "Alpha", "strength": 85.7}`, "Must be a whole number, got number 85.7"},
+		{"quoted", `{"name": "Alpha", "strength": "85"}`, "Must be a whole number, got string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/teams", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.CreateTeamHandler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			var resp models.ValidationErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Field != "strength" || resp.Errors[0].Message != tt.message {
+				t.Errorf("Expected a strength error %q, got %+v", tt.message, resp.Errors)
+			}
+		})
+	}
+}
+
 func TestUpdateTeamHandler_EmptyName(t *testing.T) {
 	handler := NewTeamHandler(&mockDBService{})
 
@@ -510,3 +770,615 @@ func TestDeleteTeamHandler_InvalidMethod(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
 	}
 }
+
+// mockNoLeaguesDBService simulates a team that belongs to no leagues
+type mockNoLeaguesDBService struct {
+	*mockDBService
+}
+
+func (m *mockNoLeaguesDBService) GetLeaguesForTeam(ctx context.Context, teamID int) ([]models.TeamLeague, error) {
+	return nil, nil
+}
+
+func TestGetTeamLeaguesHandler(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/leagues", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetTeamLeaguesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp []models.TeamLeague
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp) != 2 {
+		t.Fatalf("Expected 2 leagues, got %d", len(resp))
+	}
+	if resp[0].League.Status != "started" || resp[0].Position == nil || *resp[0].Position != 1 {
+		t.Errorf("Expected first league 'started' with position 1, got %+v", resp[0])
+	}
+	if resp[1].Position == nil || *resp[1].Position != 3 {
+		t.Errorf("Expected second league position 3, got %+v", resp[1])
+	}
+}
+
+func TestGetTeamLeaguesHandler_NoLeagues(t *testing.T) {
+	handler := NewTeamHandler(&mockNoLeaguesDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/leagues", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetTeamLeaguesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+		t.Errorf("Expected empty array, got %s", body)
+	}
+}
+
+func TestGetTeamLeaguesHandler_TeamNotFound(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/99/leagues", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetTeamLeaguesHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+// mockHeadToHeadDBService holds meetings between teams 1 and 2 across two leagues
+type mockHeadToHeadDBService struct {
+	*mockDBService
+	matches []*models.Match
+}
+
+func newMockHeadToHeadDBService() *mockHeadToHeadDBService {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	meeting := func(id, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals, daysAfter int) *models.Match {
+		playedAt := base.AddDate(0, 0, daysAfter)
+		return &models.Match{
+			ID: id, LeagueID: leagueID, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID, Week: 1,
+			HomeGoals: &homeGoals, AwayGoals: &awayGoals, Status: "played", PlayedAt: &playedAt,
+		}
+	}
+	return &mockHeadToHeadDBService{
+		matches: []*models.Match{
+			meeting(1, 1, 1, 2, 2, 0, 0),
+			meeting(2, 1, 2, 1, 1, 1, 7),
+			meeting(3, 2, 1, 2, 0, 3, 400),
+			meeting(4, 2, 2, 1, 0, 1, 407),
+		},
+	}
+}
+
+func (m *mockHeadToHeadDBService) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
+	if teamID == 1 || teamID == 2 {
+		return &models.Team{ID: teamID, Name: fmt.Sprintf("Team %d", teamID), Strength: 80}, nil
+	}
+	return nil, fmt.Errorf("no rows in result set")
+}
+
+func (m *mockHeadToHeadDBService) GetHeadToHead(ctx context.Context, teamID, opponentID, leagueID, limit int) ([]*models.Match, error) {
+	var matches []*models.Match
+	for i := len(m.matches) - 1; i >= 0 && len(matches) < limit; i-- { // most recent first
+		match := m.matches[i]
+		if leagueID == 0 || match.LeagueID == leagueID {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+func TestGetHeadToHeadHandler_AllTime(t *testing.T) {
+	handler := NewTeamHandler(newMockHeadToHeadDBService())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/head-to-head/2", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetHeadToHeadHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp models.HeadToHeadResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.LeagueID != nil {
+		t.Errorf("Expected no league scope, got %d", *resp.LeagueID)
+	}
+	if resp.Limit != headToHeadMaxLimit {
+		t.Errorf("Expected default limit %d, got %d", headToHeadMaxLimit, resp.Limit)
+	}
+	if len(resp.Matches) != 4 {
+		t.Fatalf("Expected 4 meetings, got %d", len(resp.Matches))
+	}
+	if resp.Matches[0].ID != 4 {
+		t.Errorf("Expected most recent meeting first, got match %d", resp.Matches[0].ID)
+	}
+
+	expected := models.HeadToHeadRecord{Played: 4, Wins: 2, Draws: 1, Losses: 1, GoalsFor: 4, GoalsAgainst: 4}
+	if resp.Record != expected {
+		t.Errorf("Expected record %+v, got %+v", expected, resp.Record)
+	}
+}
+
+func TestGetHeadToHeadHandler_ScopedAndLimited(t *testing.T) {
+	handler := NewTeamHandler(newMockHeadToHeadDBService())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/head-to-head/2?league_id=1&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetHeadToHeadHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp models.HeadToHeadResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.LeagueID == nil || *resp.LeagueID != 1 {
+		t.Errorf("Expected league scope 1, got %v", resp.LeagueID)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].ID != 2 {
+		t.Fatalf("Expected only the latest league 1 meeting, got %+v", resp.Matches)
+	}
+	if resp.Record.Draws != 1 || resp.Record.Played != 1 {
+		t.Errorf("Expected a single draw, got %+v", resp.Record)
+	}
+}
+
+func TestGetHeadToHeadHandler_LimitCapped(t *testing.T) {
+	handler := NewTeamHandler(newMockHeadToHeadDBService())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/head-to-head/2?limit=5000", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetHeadToHeadHandler(w, req)
+
+	var resp models.HeadToHeadResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Limit != headToHeadMaxLimit {
+		t.Errorf("Expected limit capped at %d, got %d", headToHeadMaxLimit, resp.Limit)
+	}
+}
+
+func TestGetHeadToHeadHandler_InvalidLimit(t *testing.T) {
+	handler := NewTeamHandler(newMockHeadToHeadDBService())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams/1/head-to-head/2?limit=0", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetHeadToHeadHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGenerateTeamsHandler(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/teams/generate?count=8&min_strength=60&max_strength=65", nil)
+	w := httptest.NewRecorder()
+
+	handler.GenerateTeamsHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var teams []models.TeamResponse
+	if err := json.NewDecoder(w.Body).Decode(&teams); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(teams) != 8 {
+		t.Fatalf("Expected 8 teams, got %d", len(teams))
+	}
+
+	names := make(map[string]bool)
+	for _, team := range teams {
+		if team.Strength < 60 || team.Strength > 65 {
+			t.Errorf("Expected strength between 60 and 65, got %d", team.Strength)
+		}
+		if team.Name == "" || names[team.Name] {
+			t.Errorf("Expected a unique non-empty name, got %q", team.Name)
+		}
+		names[team.Name] = true
+	}
+}
+
+// teamBatchDB only creates teams in batches, recording each one, and fails a batch when told to
+type teamBatchDB struct {
+	*mockDBService
+	batches [][]models.CreateTeamRequest
+	fail    bool
+}
+
+func (db *teamBatchDB) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
+	return nil, fmt.Errorf("teams must be created in one batch")
+}
+
+func (db *teamBatchDB) CreateTeams(ctx context.Context, reqs []models.CreateTeamRequest) ([]*models.Team, error) {
+	if db.fail {
+		return nil, fmt.Errorf("failed to create teams: connection reset")
+	}
+	db.batches = append(db.batches, reqs)
+	return db.mockDBService.CreateTeams(ctx, reqs)
+}
+
+func TestGenerateTeamsHandler_CreatesTeamsInOneBatch(t *testing.T) {
+	db := &teamBatchDB{mockDBService: &mockDBService{}}
+	handler := NewTeamHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.GenerateTeamsHandler(w, httptest.NewRequest(http.MethodPost, "/api/teams/generate?count=5", nil))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if len(db.batches) != 1 || len(db.batches[0]) != 5 {
+		t.Errorf("Expected one batch of 5 teams, got %d batches", len(db.batches))
+	}
+
+	// A failed batch creates nothing
+	db = &teamBatchDB{mockDBService: &mockDBService{}, fail: true}
+	handler = NewTeamHandler(db)
+
+	w = httptest.NewRecorder()
+	handler.GenerateTeamsHandler(w, httptest.NewRequest(http.MethodPost, "/api/teams/generate?count=5", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	if len(db.batches) != 0 {
+		t.Errorf("Expected no teams to be created, got %d batches", len(db.batches))
+	}
+}
+
+// importTeamsCSV posts a CSV body to the team import handler
+func importTeamsCSV(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	handler := NewTeamHandler(&mockDBService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/teams/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+	handler.ImportTeamsHandler(w, req)
+	return w
+}
+
+func TestImportTeamsHandler(t *testing.T) {
+	w := importTeamsCSV(t, "name,strength,primary_color\nRovers,72,#1D428A\n\"Athletic, The\", 64,\nUnited,88,\n")
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp models.ImportTeamsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Teams) != 3 || len(resp.Errors) != 0 {
+		t.Fatalf("Expected 3 teams and no errors, got %+v", resp)
+	}
+	if resp.Teams[0].Name != "Rovers" || resp.Teams[0].Strength != 72 || resp.Teams[0].PrimaryColor != "#1D428A" {
+		t.Errorf("Unexpected first team: %+v", resp.Teams[0])
+	}
+	if resp.Teams[1].Name != "Athletic, The" || resp.Teams[1].Strength != 64 {
+		t.Errorf("Expected a quoted name with a comma to be kept whole, got %+v", resp.Teams[1])
+	}
+}
+
+func TestImportTeamsHandler_InvalidRows(t *testing.T) {
+	w := importTeamsCSV(t, "name,strength\nRovers,72\nCity,strong\n,50\nTown,150\n")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	var resp models.ImportTeamsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Teams) != 0 {
+		t.Errorf("Expected no teams to be created, got %d", len(resp.Teams))
+	}
+
+	// Rows count the header as row 1; the valid Rovers row is not reported
+	expected := map[int]string{3: "strength", 4: "name", 5: "strength"}
+	if len(resp.Errors) != len(expected) {
+		t.Fatalf("Expected errors for %d rows, got %+v", len(expected), resp.Errors)
+	}
+	for _, rowErr := range resp.Errors {
+		if len(rowErr.Errors) != 1 || rowErr.Errors[0].Field != expected[rowErr.Row] {
+			t.Errorf("Expected row %d to report %q, got %+v", rowErr.Row, expected[rowErr.Row], rowErr.Errors)
+		}
+	}
+}
+
+func TestImportTeamsHandler_InvalidHeaderAndContentType(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"missing strength column", "name\nRovers\n", http.StatusBadRequest},
+		{"header is a data row", "Rovers,72\nCity,60\n", http.StatusBadRequest},
+		{"empty body", "", http.StatusBadRequest},
+		{"header only", "name,strength\n", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := importTeamsCSV(t, tt.body); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	handler := NewTeamHandler(&mockDBService{})
+	req := httptest.NewRequest(http.MethodPost, "/api/teams/import", strings.NewReader(`{"name": "Rovers"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ImportTeamsHandler(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status %d for a JSON body, got %d", http.StatusUnsupportedMediaType, w.Code)
+	}
+}
+
+func TestGenerateTeamsHandler_InvalidParams(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	for _, query := range []string{
+		"",
+		"?count=0",
+		"?count=101",
+		"?count=abc",
+		"?count=2&min_strength=80&max_strength=70",
+		"?count=2&min_strength=-1",
+		"?count=2&max_strength=101",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/teams/generate"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.GenerateTeamsHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+}
+
+func TestCreateTeamHandler_ReportsAllValidationErrors(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/teams", strings.NewReader(`{"name": " ", "strength": 150}`))
+	w := httptest.NewRecorder()
+	handler.CreateTeamHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var resp models.ValidationErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, fieldErr := range resp.Errors {
+		fields[fieldErr.Field] = true
+	}
+	if len(resp.Errors) != 2 || !fields["name"] || !fields["strength"] {
+		t.Errorf("Expected errors for name and strength, got %+v", resp.Errors)
+	}
+}
+
+func TestCreateTeamHandler_EmptyBodyAndMalformedJSON(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	tests := []struct {
+		name            string
+		body            io.Reader
+		expectedMessage string
+	}{
+		{"no body", nil, "Request body is required"},
+		{"blank body", strings.NewReader("  \n"), "Request body is required"},
+		{"malformed JSON", strings.NewReader(`{"name": "Broken"`), "Invalid JSON payload"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/teams", tt.body)
+			w := httptest.NewRecorder()
+			handler.CreateTeamHandler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, got)
+			}
+		})
+	}
+}
+
+func TestTeamHandlers_PrimaryColorAndLogoURL(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+	body := `{"name": "Manchester City", "strength": 88, "primary_color": "#6CABDD", "logo_url": "https://example.com/city.png"}`
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		serve  http.HandlerFunc
+		status int
+	}{
+		{"create", http.MethodPost, "/api/teams", handler.CreateTeamHandler, http.StatusCreated},
+		{"update", http.MethodPut, "/api/teams/1", handler.UpdateTeamHandler, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			w := httptest.NewRecorder()
+			tt.serve(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+
+			var resp models.TeamResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.PrimaryColor != "#6CABDD" || resp.LogoURL != "https://example.com/city.png" {
+				t.Errorf("Expected color and logo to round-trip, got %+v", resp)
+			}
+		})
+	}
+}
+
+func TestCreateTeamHandler_InvalidPrimaryColorAndLogoURL(t *testing.T) {
+	handler := NewTeamHandler(&mockDBService{})
+
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"color without hash", `{"name": "Team", "strength": 50, "primary_color": "6CABDD"}`, "primary_color"},
+		{"color with bad digits", `{"name": "Team", "strength": 50, "primary_color": "#GGGGGG"}`, "primary_color"},
+		{"relative logo URL", `{"name": "Team", "strength": 50, "logo_url": "/logos/team.png"}`, "logo_url"},
+		{"non-web logo URL", `{"name": "Team", "strength": 50, "logo_url": "ftp://example.com/team.png"}`, "logo_url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/teams", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.CreateTeamHandler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+
+			var resp models.ValidationErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Field != tt.field {
+				t.Errorf("Expected a single %s error, got %+v", tt.field, resp.Errors)
+			}
+		})
+	}
+}
+
+// rivalryDB keeps teams and registered rivalries in memory
+type rivalryDB struct {
+	*mockDBService
+	teams     map[int]*models.Team
+	rivalries map[[2]int]bool // lower team ID first
+}
+
+func newRivalryDB(teams ...*models.Team) *rivalryDB {
+	db := &rivalryDB{teams: make(map[int]*models.Team), rivalries: make(map[[2]int]bool)}
+	for _, team := range teams {
+		db.teams[team.ID] = team
+	}
+	return db
+}
+
+func rivalryKey(teamID, rivalID int) [2]int {
+	return [2]int{min(teamID, rivalID), max(teamID, rivalID)}
+}
+
+func (db *rivalryDB) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
+	if team, ok := db.teams[teamID]; ok {
+		return team, nil
+	}
+	return nil, fmt.Errorf("no rows in result set")
+}
+
+func (db *rivalryDB) AddRivalry(ctx context.Context, teamID, rivalID int) error {
+	db.rivalries[rivalryKey(teamID, rivalID)] = true
+	return nil
+}
+
+func (db *rivalryDB) GetRivals(ctx context.Context, teamID int) ([]*models.Team, error) {
+	var rivals []*models.Team
+	for key := range db.rivalries {
+		switch teamID {
+		case key[0]:
+			rivals = append(rivals, db.teams[key[1]])
+		case key[1]:
+			rivals = append(rivals, db.teams[key[0]])
+		}
+	}
+	return rivals, nil
+}
+
+func (db *rivalryDB) IsRivalry(ctx context.Context, teamID, rivalID int) (bool, error) {
+	return db.rivalries[rivalryKey(teamID, rivalID)], nil
+}
+
+func TestRivalryHandlers(t *testing.T) {
+	db := newRivalryDB(&models.Team{ID: 1, Name: "Team A", Strength: 80}, &models.Team{ID: 2, Name: "Team B", Strength: 80})
+	handler := NewTeamHandler(db)
+
+	w := httptest.NewRecorder()
+	handler.AddRivalryHandler(w, httptest.NewRequest(http.MethodPost, "/api/teams/2/rivals/1", nil))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if rivalry, _ := db.IsRivalry(context.Background(), 1, 2); !rivalry {
+		t.Error("Expected teams 1 and 2 to be registered rivals")
+	}
+
+	// The rivalry is listed from either side
+	for teamID, rivalName := range map[int]string{1: "Team B", 2: "Team A"} {
+		w := httptest.NewRecorder()
+		handler.GetRivalsHandler(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/teams/%d/rivals", teamID), nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		var resp models.RivalsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(resp.Rivals) != 1 || resp.Rivals[0].Name != rivalName {
+			t.Errorf("Expected team %d's only rival to be %s, got %+v", teamID, rivalName, resp.Rivals)
+		}
+	}
+
+	for path, status := range map[string]int{
+		"/api/teams/1/rivals/1":  http.StatusBadRequest,
+		"/api/teams/1/rivals/99": http.StatusNotFound,
+		"/api/teams/99/rivals/1": http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		handler.AddRivalryHandler(w, httptest.NewRequest(http.MethodPost, path, nil))
+		if w.Code != status {
+			t.Errorf("Expected status %d for %s, got %d", status, path, w.Code)
+		}
+	}
+}
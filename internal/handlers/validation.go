@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"insider-league-manager/internal/models"
+)
+
+// validationErrors collects every field-level problem with a request so they can be reported together
+type validationErrors []models.FieldError
+
+// add records a problem with a field
+func (v *validationErrors) add(field, message string) {
+	*v = append(*v, models.FieldError{Field: field, Message: message})
+}
+
+// writeValidationErrors responds with 400 Bad Request listing all collected problems
+func writeValidationErrors(w http.ResponseWriter, r *http.Request, errs validationErrors) {
+	resp := models.ValidationErrorResponse{
+		Error:  "Validation failed",
+		Errors: errs,
+	}
+
+	writeJSON(w, r, http.StatusBadRequest, resp)
+}
+
+// validateTeamRequest checks the fields of a team create or update request
+func validateTeamRequest(req *models.CreateTeamRequest) validationErrors {
+	var errs validationErrors
+	if strings.TrimSpace(req.Name) == "" {
+		errs.add("name", "Team name is required")
+	}
+	if req.Strength < 0 || req.Strength > 100 {
+		errs.add("strength", "Strength must be between 0 and 100")
+	}
+	if req.PrimaryColor != "" && !hexColorPattern.MatchString(req.PrimaryColor) {
+		errs.add("primary_color", "Primary color must be a hex color such as #6CABDD or #FFF")
+	}
+	if req.LogoURL != "" && !isWebURL(req.LogoURL) {
+		errs.add("logo_url", "Logo URL must be an absolute http or https URL")
+	}
+	return errs
+}
+
+// hexColorPattern matches #RGB and #RRGGBB colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// isWebURL reports whether value is an absolute http or https URL with a host
+func isWebURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validateLeagueRequest checks the fields of a league create request
+func validateLeagueRequest(req *models.CreateLeagueRequest) validationErrors {
+	var errs validationErrors
+	if strings.TrimSpace(req.Name) == "" {
+		errs.add("name", "League name is required")
+	}
+	if req.Zones != nil {
+		validateLeagueZones(&errs, *req.Zones)
+	}
+	validateUnitInterval(&errs, "score_correlation", "Score correlation", req.ScoreCorrelation)
+	validateUnitInterval(&errs, "upset_factor", "Upset factor", req.UpsetFactor)
+	validateScoringPreset(&errs, req.ScoringPreset)
+	validateCleanSheetBonus(&errs, req.CleanSheetBonus)
+	validateFatiguePenalty(&errs, req.FatiguePenalty)
+	validateMatchesPerWeek(&errs, req.MatchesPerWeek)
+	validateByePoints(&errs, req.ByePoints)
+	validateBigWin(&errs, req.BigWinMargin, req.BigWinBonus)
+	if req.FinalTiebreak != "" {
+		validateFinalTiebreak(&errs, req.FinalTiebreak)
+	}
+	if req.WeekNumbering != "" {
+		validateWeekNumbering(&errs, req.WeekNumbering)
+	}
+	return errs
+}
+
+// validateLeagueConfigUpdate checks the provided fields of a league config update
+func validateLeagueConfigUpdate(req *models.UpdateLeagueConfigRequest) validationErrors {
+	var errs validationErrors
+	if req.Zones != nil {
+		validateLeagueZones(&errs, *req.Zones)
+	}
+	validateUnitInterval(&errs, "score_correlation", "Score correlation", req.ScoreCorrelation)
+	validateUnitInterval(&errs, "upset_factor", "Upset factor", req.UpsetFactor)
+	if req.ScoringPreset != nil {
+		validateScoringPreset(&errs, *req.ScoringPreset)
+	}
+	validateCleanSheetBonus(&errs, req.CleanSheetBonus)
+	validateFatiguePenalty(&errs, req.FatiguePenalty)
+	validateMatchesPerWeek(&errs, req.MatchesPerWeek)
+	validateByePoints(&errs, req.ByePoints)
+	validateBigWin(&errs, req.BigWinMargin, req.BigWinBonus)
+	if req.FinalTiebreak != nil {
+		validateFinalTiebreak(&errs, *req.FinalTiebreak)
+	}
+	if req.WeekNumbering != nil {
+		validateWeekNumbering(&errs, *req.WeekNumbering)
+	}
+	return errs
+}
+
+// validateCleanSheetBonus checks that an optional clean sheet bonus isn't negative
+func validateCleanSheetBonus(errs *validationErrors, bonus *int) {
+	if bonus != nil && *bonus < 0 {
+		errs.add("clean_sheet_bonus", "Clean sheet bonus cannot be negative")
+	}
+}
+
+// validateFatiguePenalty checks that an optional fatigue penalty is within 0 and models.MaxFatiguePenalty
+func validateFatiguePenalty(errs *validationErrors, penalty *int) {
+	if penalty != nil && (*penalty < 0 || *penalty > models.MaxFatiguePenalty) {
+		errs.add("fatigue_penalty", fmt.Sprintf("Fatigue penalty must be between 0 and %d", models.MaxFatiguePenalty))
+	}
+}
+
+// validateMatchesPerWeek checks that an optional matches per week limit isn't negative
+func validateMatchesPerWeek(errs *validationErrors, limit *int) {
+	if limit != nil && *limit < 0 {
+		errs.add("matches_per_week", "Matches per week cannot be negative")
+	}
+}
+
+// validateByePoints checks that optional bye points are within 0 and models.MaxByePoints
+func validateByePoints(errs *validationErrors, points *int) {
+	if points != nil && (*points < 0 || *points > models.MaxByePoints) {
+		errs.add("bye_points", fmt.Sprintf("Bye points must be between 0 and %d", models.MaxByePoints))
+	}
+}
+
+// validateBigWin checks that an optional big win margin and bonus aren't negative
+func validateBigWin(errs *validationErrors, margin, bonus *int) {
+	if margin != nil && *margin < 0 {
+		errs.add("big_win_margin", "Big win margin cannot be negative")
+	}
+	if bonus != nil && *bonus < 0 {
+		errs.add("big_win_bonus", "Big win bonus cannot be negative")
+	}
+}
+
+// validateLeagueZones checks that the standings zone thresholds are usable
+func validateLeagueZones(errs *validationErrors, zones models.LeagueZones) {
+	if zones.ChampionSpots < 0 {
+		errs.add("zones.champion_spots", "Champion spots cannot be negative")
+	}
+	if zones.PromotionSpots < 0 {
+		errs.add("zones.promotion_spots", "Promotion spots cannot be negative")
+	}
+	if zones.RelegationSpots < 0 {
+		errs.add("zones.relegation_spots", "Relegation spots cannot be negative")
+	}
+}
+
+// validateUnitInterval checks that an optional simulation setting is within 0 and 1
+func validateUnitInterval(errs *validationErrors, field, label string, value *float64) {
+	if value != nil && (*value < 0 || *value > 1) {
+		errs.add(field, label+" must be between 0 and 1")
+	}
+}
+
+// validateScoringPreset checks that a scoring preset name is known; empty means the default
+func validateScoringPreset(errs *validationErrors, name string) {
+	if _, ok := models.ResolveScoringPreset(name); !ok {
+		errs.add("scoring_preset", "Scoring preset must be one of defensive, balanced or attacking")
+	}
+}
+
+// validateFinalTiebreak checks that a final tiebreak is a known one
+func validateFinalTiebreak(errs *validationErrors, tiebreak string) {
+	switch tiebreak {
+	case models.FinalTiebreakName, models.FinalTiebreakTeamID, models.FinalTiebreakSeeded:
+	default:
+		errs.add("final_tiebreak", "Final tiebreak must be one of name, team_id or seeded")
+	}
+}
+
+// validateWeekNumbering checks that a week numbering is a known one
+func validateWeekNumbering(errs *validationErrors, numbering string) {
+	switch numbering {
+	case models.WeekNumberingZeroBased, models.WeekNumberingOneBased:
+	default:
+		errs.add("week_numbering", "Week numbering must be one of zero_based or one_based")
+	}
+}
+
+// validateWhatIfOverrides checks that each hypothetical result names a played or scheduled
+// match of the league, at most once, with non-negative goals
+func validateWhatIfOverrides(overrides []models.WhatIfResult, matches map[int]*models.Match) validationErrors {
+	var errs validationErrors
+	if len(overrides) == 0 {
+		errs.add("overrides", "At least one override is required")
+	}
+
+	seen := make(map[int]bool, len(overrides))
+	for i, override := range overrides {
+		field := fmt.Sprintf("overrides[%d]", i)
+		match, ok := matches[override.MatchID]
+		switch {
+		case !ok:
+			errs.add(field+".match_id", fmt.Sprintf("Match %d is not in this league", override.MatchID))
+		case match.Status != "played" && match.Status != "scheduled":
+			errs.add(field+".match_id", fmt.Sprintf("Match %d is %s", override.MatchID, match.Status))
+		case seen[override.MatchID]:
+			errs.add(field+".match_id", fmt.Sprintf("Match %d is overridden more than once", override.MatchID))
+		}
+		seen[override.MatchID] = true
+
+		if override.HomeGoals < 0 {
+			errs.add(field+".home_goals", "Goals cannot be negative")
+		}
+		if override.AwayGoals < 0 {
+			errs.add(field+".away_goals", "Goals cannot be negative")
+		}
+	}
+	return errs
+}
@@ -4,25 +4,293 @@ import "time"
 
 // League represents a league in the database
 type League struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`       // "created", "started", "finished"
-	CurrentWeek int       `json:"current_week"` // Current week of the league
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Status      string      `json:"status"`       // "created", "started", "paused", "finished"
+	CurrentWeek int         `json:"current_week"` // Current week of the league
+	CreatedAt   time.Time   `json:"created_at"`
+	Zones       LeagueZones `json:"zones"`
+
+	// ScoreCorrelation (0 to 1) reduces a side's goal expectancy when its opponent scores
+	// above expectation, modelling game control. 0 samples both scores independently.
+	ScoreCorrelation float64 `json:"score_correlation"`
+
+	// UpsetFactor (0 to 1) compresses the strength difference between teams toward parity,
+	// so weaker teams win more often. 0 uses the full strength difference.
+	UpsetFactor float64 `json:"upset_factor"`
+
+	// Scoring sets the goal expectancy baseline and bounds used when simulating matches
+	Scoring ScoringProfile `json:"scoring"`
+
+	// CleanSheetBonus is added to a team's points for each played match in which it doesn't concede
+	CleanSheetBonus int `json:"clean_sheet_bonus"`
+
+	// FinalTiebreak orders teams that are level on points, goal difference and goals for.
+	// TiebreakSeed is only used by the "seeded" tiebreak.
+	FinalTiebreak string `json:"final_tiebreak"`
+	TiebreakSeed  int64  `json:"tiebreak_seed"`
+
+	// FatiguePenalty is taken off a team's strength when simulating a match that kicks off
+	// within a few days of its previous one. 0 disables fatigue.
+	FatiguePenalty int `json:"fatigue_penalty"`
+
+	// MatchesPerWeek limits how many matches are scheduled in a week, spreading each
+	// round-robin round over several weeks. 0 plays every round in a single week.
+	MatchesPerWeek int `json:"matches_per_week"`
+
+	// ByePoints are awarded to a team sitting out a week on a bye in a league with an
+	// odd number of teams. 0 gives byes no points.
+	ByePoints int `json:"bye_points"`
+
+	// BigWinBonus is added to the winner's points when it wins by at least BigWinMargin
+	// goals. A margin of 0 disables the bonus.
+	BigWinMargin int `json:"big_win_margin"`
+	BigWinBonus  int `json:"big_win_bonus"`
+
+	// WeekNumbering chooses how current_week is presented in responses; see ReportedWeek
+	WeekNumbering string `json:"week_numbering"`
+}
+
+// Week numberings for presenting a league's current week. Match weeks are numbered from 1 either way.
+const (
+	WeekNumberingZeroBased = "zero_based" // current_week is the last completed week, 0 before any is played (the default)
+	WeekNumberingOneBased  = "one_based"  // current_week is the week in progress, 1 before any is played
+)
+
+// ReportedWeek returns the league's current week under its week numbering. CurrentWeek itself
+// always counts completed weeks, so the next week to play is CurrentWeek+1 under either
+// numbering. With one_based numbering that next week is reported, and a finished league reports
+// its final week.
+func (l *League) ReportedWeek() int {
+	if l.WeekNumbering == WeekNumberingOneBased && l.Status != "finished" {
+		return l.CurrentWeek + 1
+	}
+	return l.CurrentWeek
+}
+
+// MatchBonuses are the points a league awards for a match on top of those for the result
+type MatchBonuses struct {
+	CleanSheet   int // see League.CleanSheetBonus
+	BigWinMargin int // see League.BigWinMargin
+	BigWin       int // see League.BigWinBonus
+}
+
+// Bonuses returns the match bonuses configured for the league
+func (l *League) Bonuses() MatchBonuses {
+	return MatchBonuses{
+		CleanSheet:   l.CleanSheetBonus,
+		BigWinMargin: l.BigWinMargin,
+		BigWin:       l.BigWinBonus,
+	}
+}
+
+// Points returns the bonus points each side earns from a match with the given score: the clean
+// sheet bonus for a side that didn't concede, and the big win bonus for a winner by at least the
+// big win margin
+func (b MatchBonuses) Points(homeGoals, awayGoals int) (home, away int) {
+	if awayGoals == 0 {
+		home += b.CleanSheet
+	}
+	if homeGoals == 0 {
+		away += b.CleanSheet
+	}
+	if b.BigWinMargin > 0 {
+		if homeGoals-awayGoals >= b.BigWinMargin {
+			home += b.BigWin
+		}
+		if awayGoals-homeGoals >= b.BigWinMargin {
+			away += b.BigWin
+		}
+	}
+	return home, away
+}
+
+// MaxFatiguePenalty is the largest strength penalty a league can set for fatigue
+const MaxFatiguePenalty = 20
+
+// MaxByePoints is the most points a league can award for a bye
+const MaxByePoints = 3
+
+// Final tiebreaks for teams level on points, goal difference and goals for
+const (
+	FinalTiebreakName   = "name"    // alphabetical by team name (the default)
+	FinalTiebreakTeamID = "team_id" // lowest team ID first
+	FinalTiebreakSeeded = "seeded"  // a fixed pseudo-random order derived from the league's tiebreak seed
+)
+
+// ScoringProfile sets how many goals simulated matches produce. ExpectancyBase is each
+// side's expected goals in an even match; strength differences move the expectancy
+// within ExpectancyMin and ExpectancyMax.
+type ScoringProfile struct {
+	ExpectancyBase float64 `json:"expectancy_base"`
+	ExpectancyMin  float64 `json:"expectancy_min"`
+	ExpectancyMax  float64 `json:"expectancy_max"`
+}
+
+// DefaultScoringPreset is the scoring preset used when a league doesn't choose one
+const DefaultScoringPreset = "balanced"
+
+// ScoringPresets are the named scoring archetypes a league can be created with
+var ScoringPresets = map[string]ScoringProfile{
+	"defensive": {ExpectancyBase: 0.9, ExpectancyMin: 0.3, ExpectancyMax: 2.0},
+	"balanced":  {ExpectancyBase: 1.5, ExpectancyMin: 0.5, ExpectancyMax: 3.0},
+	"attacking": {ExpectancyBase: 2.2, ExpectancyMin: 1.0, ExpectancyMax: 3.5},
 }
 
+// ResolveScoringPreset returns the scoring profile of a named preset, using
+// DefaultScoringPreset for an empty name. ok is false for an unknown preset.
+func ResolveScoringPreset(name string) (profile ScoringProfile, ok bool) {
+	if name == "" {
+		name = DefaultScoringPreset
+	}
+	profile, ok = ScoringPresets[name]
+	return profile, ok
+}
+
+// LeagueConfig holds the settings of a league that can be changed before it starts
+type LeagueConfig struct {
+	Zones            LeagueZones    `json:"zones"`
+	ScoreCorrelation float64        `json:"score_correlation"`
+	UpsetFactor      float64        `json:"upset_factor"`
+	Scoring          ScoringProfile `json:"scoring"`
+	CleanSheetBonus  int            `json:"clean_sheet_bonus"`
+	FinalTiebreak    string         `json:"final_tiebreak"`
+	TiebreakSeed     int64          `json:"tiebreak_seed"`
+	FatiguePenalty   int            `json:"fatigue_penalty"`
+	MatchesPerWeek   int            `json:"matches_per_week"`
+	ByePoints        int            `json:"bye_points"`
+	BigWinMargin     int            `json:"big_win_margin"`
+	BigWinBonus      int            `json:"big_win_bonus"`
+	WeekNumbering    string         `json:"week_numbering"`
+}
+
+// NewLeagueConfig returns the configurable settings of a league
+func NewLeagueConfig(league *League) LeagueConfig {
+	return LeagueConfig{
+		Zones:            league.Zones,
+		ScoreCorrelation: league.ScoreCorrelation,
+		UpsetFactor:      league.UpsetFactor,
+		Scoring:          league.Scoring,
+		CleanSheetBonus:  league.CleanSheetBonus,
+		FinalTiebreak:    league.FinalTiebreak,
+		TiebreakSeed:     league.TiebreakSeed,
+		FatiguePenalty:   league.FatiguePenalty,
+		MatchesPerWeek:   league.MatchesPerWeek,
+		ByePoints:        league.ByePoints,
+		BigWinMargin:     league.BigWinMargin,
+		BigWinBonus:      league.BigWinBonus,
+		WeekNumbering:    league.WeekNumbering,
+	}
+}
+
+// UpdateLeagueConfigRequest represents a partial update of a league's settings; omitted fields are unchanged
+type UpdateLeagueConfigRequest struct {
+	Zones            *LeagueZones `json:"zones,omitempty"`
+	ScoreCorrelation *float64     `json:"score_correlation,omitempty"`
+	UpsetFactor      *float64     `json:"upset_factor,omitempty"`
+	ScoringPreset    *string      `json:"scoring_preset,omitempty"` // replaces the scoring profile with a preset's values
+	CleanSheetBonus  *int         `json:"clean_sheet_bonus,omitempty"`
+	FinalTiebreak    *string      `json:"final_tiebreak,omitempty"`
+	TiebreakSeed     *int64       `json:"tiebreak_seed,omitempty"`
+	FatiguePenalty   *int         `json:"fatigue_penalty,omitempty"`
+	MatchesPerWeek   *int         `json:"matches_per_week,omitempty"`
+	ByePoints        *int         `json:"bye_points,omitempty"`
+	BigWinMargin     *int         `json:"big_win_margin,omitempty"`
+	BigWinBonus      *int         `json:"big_win_bonus,omitempty"`
+	WeekNumbering    *string      `json:"week_numbering,omitempty"`
+}
+
+// LeagueConfigResponse represents the response for reading or updating a league's settings
+type LeagueConfigResponse struct {
+	LeagueID int          `json:"league_id"`
+	Status   string       `json:"status"`
+	Config   LeagueConfig `json:"config"`
+}
+
+// LeagueZones configures how many standings positions fall in each zone.
+// Champion spots are counted from the top, promotion spots follow directly below them,
+// and relegation spots are counted from the bottom. Upper zones win where they overlap.
+type LeagueZones struct {
+	ChampionSpots   int `json:"champion_spots"`
+	PromotionSpots  int `json:"promotion_spots"`
+	RelegationSpots int `json:"relegation_spots"`
+}
+
+// DefaultLeagueZones marks only the top team, as champion
+var DefaultLeagueZones = LeagueZones{ChampionSpots: 1}
+
 // CreateLeagueRequest represents the request payload for creating a league
 type CreateLeagueRequest struct {
-	Name string `json:"name"`
+	Name             string       `json:"name"`
+	Zones            *LeagueZones `json:"zones,omitempty"`             // DefaultLeagueZones if omitted
+	ScoreCorrelation *float64     `json:"score_correlation,omitempty"` // 0 if omitted
+	UpsetFactor      *float64     `json:"upset_factor,omitempty"`      // 0 if omitted
+	ScoringPreset    string       `json:"scoring_preset,omitempty"`    // one of ScoringPresets, DefaultScoringPreset if omitted
+	CleanSheetBonus  *int         `json:"clean_sheet_bonus,omitempty"` // 0 if omitted
+	FinalTiebreak    string       `json:"final_tiebreak,omitempty"`    // FinalTiebreakName if omitted
+	TiebreakSeed     int64        `json:"tiebreak_seed,omitempty"`     // used by FinalTiebreakSeeded
+	FatiguePenalty   *int         `json:"fatigue_penalty,omitempty"`   // 0 if omitted
+	MatchesPerWeek   *int         `json:"matches_per_week,omitempty"`  // 0 (no limit) if omitted
+	ByePoints        *int         `json:"bye_points,omitempty"`        // 0 if omitted
+	BigWinMargin     *int         `json:"big_win_margin,omitempty"`    // 0 (disabled) if omitted
+	BigWinBonus      *int         `json:"big_win_bonus,omitempty"`     // 0 if omitted
+	WeekNumbering    string       `json:"week_numbering,omitempty"`    // WeekNumberingZeroBased if omitted
 }
 
-// LeagueResponse represents the response format for league operations
+// LeagueResponse represents the response format for league operations.
+//
+// Weeks are numbered from 1. By default CurrentWeek is the last completed week, so it is 0
+// before the first week has been played; a league with one_based week numbering reports the
+// week in progress instead (see League.ReportedWeek). NextWeek is the week the next advance
+// will play under either numbering and is omitted once the league is finished.
 type LeagueResponse struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`
-	CurrentWeek int       `json:"current_week"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID               int            `json:"id"`
+	Name             string         `json:"name"`
+	Status           string         `json:"status"`
+	CurrentWeek      int            `json:"current_week"`
+	NextWeek         int            `json:"next_week,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	Zones            LeagueZones    `json:"zones"`
+	ScoreCorrelation float64        `json:"score_correlation"`
+	UpsetFactor      float64        `json:"upset_factor"`
+	Scoring          ScoringProfile `json:"scoring"`
+	CleanSheetBonus  int            `json:"clean_sheet_bonus"`
+	FinalTiebreak    string         `json:"final_tiebreak"`
+	TiebreakSeed     int64          `json:"tiebreak_seed,omitempty"`
+	FatiguePenalty   int            `json:"fatigue_penalty"`
+	MatchesPerWeek   int            `json:"matches_per_week"`
+	ByePoints        int            `json:"bye_points"`
+	BigWinMargin     int            `json:"big_win_margin"`
+	BigWinBonus      int            `json:"big_win_bonus"`
+	WeekNumbering    string         `json:"week_numbering"`
+}
+
+// NewLeagueResponse converts a league to its response format
+func NewLeagueResponse(league *League) LeagueResponse {
+	resp := LeagueResponse{
+		ID:               league.ID,
+		Name:             league.Name,
+		Status:           league.Status,
+		CurrentWeek:      league.ReportedWeek(),
+		CreatedAt:        league.CreatedAt,
+		Zones:            league.Zones,
+		ScoreCorrelation: league.ScoreCorrelation,
+		UpsetFactor:      league.UpsetFactor,
+		Scoring:          league.Scoring,
+		CleanSheetBonus:  league.CleanSheetBonus,
+		FinalTiebreak:    league.FinalTiebreak,
+		TiebreakSeed:     league.TiebreakSeed,
+		FatiguePenalty:   league.FatiguePenalty,
+		MatchesPerWeek:   league.MatchesPerWeek,
+		ByePoints:        league.ByePoints,
+		BigWinMargin:     league.BigWinMargin,
+		BigWinBonus:      league.BigWinBonus,
+		WeekNumbering:    league.WeekNumbering,
+	}
+	if league.Status != "finished" {
+		resp.NextWeek = league.CurrentWeek + 1
+	}
+	return resp
 }
 
 // LeagueTeam represents the junction table for teams in leagues
@@ -34,16 +302,19 @@ type LeagueTeam struct {
 
 // Match represents a match between two teams in a league
 type Match struct {
-	ID         int        `json:"id"`
-	LeagueID   int        `json:"league_id"`
-	HomeTeamID int        `json:"home_team_id"`
-	AwayTeamID int        `json:"away_team_id"`
-	Week       int        `json:"week"`
-	HomeGoals  *int       `json:"home_goals"` // nullable until match is played
-	AwayGoals  *int       `json:"away_goals"` // nullable until match is played
-	Status     string     `json:"status"`     // "scheduled", "played", "cancelled"
-	PlayedAt   *time.Time `json:"played_at"`  // nullable until match is played
-	CreatedAt  time.Time  `json:"created_at"`
+	ID          int        `json:"id"`
+	LeagueID    int        `json:"league_id"`
+	HomeTeamID  int        `json:"home_team_id"`
+	AwayTeamID  int        `json:"away_team_id"`
+	Week        int        `json:"week"`
+	HomeGoals   *int       `json:"home_goals"` // nullable until match is played
+	AwayGoals   *int       `json:"away_goals"` // nullable until match is played
+	Status      string     `json:"status"`     // "scheduled", "played", "cancelled"
+	PlayedAt    *time.Time `json:"played_at"`  // nullable until match is played
+	CreatedAt   time.Time  `json:"created_at"`
+	KickoffTime *time.Time `json:"kickoff_time"` // nullable if the match has no scheduled kickoff
+	UpdatedAt   *time.Time `json:"updated_at"`   // nullable until the result is edited
+	Edited      bool       `json:"edited"`       // true once the result has been edited after play
 }
 
 // Standing represents team standings in a league
@@ -66,11 +337,45 @@ type StandingWithTeam struct {
 	TeamName string `json:"team_name"`
 }
 
+// StandingRow represents a team's standing with its table position and zone
+type StandingRow struct {
+	Position int    `json:"position"`
+	Zone     string `json:"zone"` // "champion", "promotion", "mid-table" or "relegation"
+	StandingWithTeam
+}
+
+// StandingsResponse represents the response for a league's standings table
+type StandingsResponse struct {
+	League    LeagueResponse `json:"league"`
+	AsOf      *time.Time     `json:"as_of,omitempty"` // set when the table is limited to matches before this time
+	Standings []StandingRow  `json:"standings"`
+}
+
+// WhatIfResult represents a hypothetical result for a match
+type WhatIfResult struct {
+	MatchID   int `json:"match_id"`
+	HomeGoals int `json:"home_goals"`
+	AwayGoals int `json:"away_goals"`
+}
+
+// WhatIfRequest represents a request for standings with hypothetical results applied
+type WhatIfRequest struct {
+	Overrides []WhatIfResult `json:"overrides"`
+}
+
+// WhatIfStandingsResponse represents the standings a league would have with hypothetical results
+type WhatIfStandingsResponse struct {
+	League    LeagueResponse `json:"league"`
+	Overrides []WhatIfResult `json:"overrides"`
+	Standings []StandingRow  `json:"standings"`
+}
+
 // InitializeLeagueResponse represents the response for league initialization
 type InitializeLeagueResponse struct {
 	League  LeagueResponse `json:"league"`
 	Teams   []Team         `json:"teams"`
 	Message string         `json:"message"`
+	Warning string         `json:"warning,omitempty"` // Set when a default team's strength is implausible
 }
 
 // AddTeamToLeagueResponse represents the response for adding a team to a league
@@ -94,6 +399,16 @@ type StartLeagueResponse struct {
 	MatchesCount int            `json:"matches_count"`
 	TotalWeeks   int            `json:"total_weeks"`
 	Message      string         `json:"message"`
+	Warning      string         `json:"warning,omitempty"` // Set when the schedule needs byes (odd team count)
+}
+
+// RegenerateScheduleResponse represents the response for regenerating a league's schedule
+type RegenerateScheduleResponse struct {
+	League       LeagueResponse `json:"league"`
+	TeamsCount   int            `json:"teams_count"`
+	MatchesCount int            `json:"matches_count"`
+	TotalWeeks   int            `json:"total_weeks"`
+	Message      string         `json:"message"`
 }
 
 // MatchResult represents a played match result
@@ -102,6 +417,13 @@ type MatchResult struct {
 	HomeTeam string `json:"home_team"`
 	AwayTeam string `json:"away_team"`
 	Result   string `json:"result"` // e.g. "3-1", "2-2"
+	Edited   bool   `json:"edited"` // true if the result was corrected after play
+}
+
+// LeagueStatusResponse represents the response for a league status change such as pausing or resuming
+type LeagueStatusResponse struct {
+	League  LeagueResponse `json:"league"`
+	Message string         `json:"message"`
 }
 
 // AdvanceWeekResponse represents the response for advancing a league week
@@ -117,7 +439,21 @@ type ViewMatchesResponse struct {
 	League      LeagueResponse `json:"league"`
 	CurrentWeek int            `json:"current_week"`
 	Matches     []MatchResult  `json:"matches"`
-	Message     string         `json:"message"`
+
+	// PlayedMatches and ScheduledMatches split Matches by status, so a client polling a week
+	// that is only partly played can show which results are in. Cancelled matches are in neither.
+	PlayedMatches    []MatchResult `json:"played_matches"`
+	ScheduledMatches []MatchResult `json:"scheduled_matches"`
+
+	Message string `json:"message"`
+}
+
+// LeagueMatchesResponse represents the response for listing all matches of a league
+type LeagueMatchesResponse struct {
+	League  LeagueResponse `json:"league"`
+	Status  string         `json:"status,omitempty"` // Status filter applied, empty when unfiltered
+	Matches []MatchResult  `json:"matches"`
+	Message string         `json:"message"`
 }
 
 // WeekResult represents match results for a specific week
@@ -129,14 +465,197 @@ type WeekResult struct {
 // PlayAllMatchesResponse represents the response for playing all remaining matches in a league
 type PlayAllMatchesResponse struct {
 	League             LeagueResponse `json:"league"`
+	Mode               string         `json:"mode"`                  // "random", "expected" or "tempered"
+	Temperature        *float64       `json:"temperature,omitempty"` // set in "tempered" mode
 	StartingWeek       int            `json:"starting_week"`
 	FinalWeek          int            `json:"final_week"`
 	WeeksPlayed        int            `json:"weeks_played"`
 	TotalMatchesPlayed int            `json:"total_matches_played"`
 	WeekResults        []WeekResult   `json:"week_results"`
+	RunID              *int           `json:"run_id,omitempty"` // the recorded simulation run; unset if recording failed
 	Message            string         `json:"message"`
 }
 
+// AdvanceWeeksRequest represents the request body for advancing several weeks at once
+type AdvanceWeeksRequest struct {
+	Count int `json:"count"`
+}
+
+// AdvanceWeeksResponse represents the response for advancing a league by several weeks
+type AdvanceWeeksResponse struct {
+	League             LeagueResponse `json:"league"`
+	RequestedWeeks     int            `json:"requested_weeks"`
+	StartingWeek       int            `json:"starting_week"`
+	FinalWeek          int            `json:"final_week"`
+	WeeksPlayed        int            `json:"weeks_played"`
+	TotalMatchesPlayed int            `json:"total_matches_played"`
+	WeekResults        []WeekResult   `json:"week_results"`
+	Message            string         `json:"message"`
+}
+
+// AdvanceAllLeagueResult reports the outcome of advancing a single league in a batch
+type AdvanceAllLeagueResult struct {
+	LeagueID      int    `json:"league_id"`
+	LeagueName    string `json:"league_name"`
+	WeekAdvanced  int    `json:"week_advanced,omitempty"`
+	MatchesPlayed int    `json:"matches_played"`
+	Finished      bool   `json:"finished"`
+	Error         string `json:"error,omitempty"`
+}
+
+// AdvanceAllLeaguesResponse represents the response for advancing every started league by one week
+type AdvanceAllLeaguesResponse struct {
+	LeaguesProcessed int                      `json:"leagues_processed"`
+	LeaguesAdvanced  int                      `json:"leagues_advanced"`
+	LeaguesFailed    int                      `json:"leagues_failed"`
+	Results          []AdvanceAllLeagueResult `json:"results"`
+	Message          string                   `json:"message"`
+}
+
+// CleanupFinishedLeaguesResponse represents the result of deleting old finished leagues
+type CleanupFinishedLeaguesResponse struct {
+	OlderThanDays  int       `json:"older_than_days"`
+	FinishedBefore time.Time `json:"finished_before"`
+	LeaguesDeleted int       `json:"leagues_deleted"`
+	LeagueIDs      []int     `json:"league_ids"`
+	Message        string    `json:"message"`
+}
+
+// ScheduleResponse represents the matches of a league kicking off within a time range
+type ScheduleResponse struct {
+	League  LeagueResponse `json:"league"`
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	Matches []MatchResult  `json:"matches"`
+}
+
+// RoundFixturesResponse represents the planned structure of a single round
+type RoundFixturesResponse struct {
+	League   LeagueResponse `json:"league"`
+	Round    int            `json:"round"`
+	Fixtures []MatchResult  `json:"fixtures"`
+	Byes     []TeamResponse `json:"byes"` // Teams without a fixture in the round
+}
+
+// TeamTrendWeek holds a team's cumulative totals at the end of a played week
+type TeamTrendWeek struct {
+	Week           int `json:"week"`
+	Played         int `json:"played"`
+	Points         int `json:"points"`
+	GoalsFor       int `json:"goals_for"`
+	GoalsAgainst   int `json:"goals_against"`
+	GoalDifference int `json:"goal_difference"`
+}
+
+// TeamTrendResponse represents a team's week-by-week progression in a league
+type TeamTrendResponse struct {
+	League LeagueResponse  `json:"league"`
+	Team   TeamResponse    `json:"team"`
+	Weeks  []TeamTrendWeek `json:"weeks"`
+}
+
+// VenueBalanceResponse represents how a team's fixtures in a league split between home and away
+type VenueBalanceResponse struct {
+	League       LeagueResponse `json:"league"`
+	Team         TeamResponse   `json:"team"`
+	HomeFixtures int            `json:"home_fixtures"` // scheduled and played
+	AwayFixtures int            `json:"away_fixtures"` // scheduled and played
+	Uneven       bool           `json:"uneven"`        // home and away differ by more than one
+}
+
+// MaxGroupNameLength is the longest group label a team can be given within a league
+const MaxGroupNameLength = 50
+
+// SetTeamGroupRequest represents the request to assign a team to a group within a league
+type SetTeamGroupRequest struct {
+	Group string `json:"group"` // empty removes the team from its group
+}
+
+// TeamGroupResponse represents the response after assigning a team to a group
+type TeamGroupResponse struct {
+	League  LeagueResponse `json:"league"`
+	Team    TeamResponse   `json:"team"`
+	Group   string         `json:"group"`
+	Message string         `json:"message"`
+}
+
+// GroupStandings represents the standings table of one group within a league
+type GroupStandings struct {
+	Group     string        `json:"group"` // empty for teams not assigned to a group
+	Standings []StandingRow `json:"standings"`
+}
+
+// GroupStandingsResponse represents a league's standings split by group
+type GroupStandingsResponse struct {
+	League LeagueResponse   `json:"league"`
+	Groups []GroupStandings `json:"groups"`
+}
+
+// AvailableTeamsResponse represents the teams that can still be added to a league
+type AvailableTeamsResponse struct {
+	League LeagueResponse `json:"league"`
+	Teams  []TeamResponse `json:"teams"`
+}
+
+// RemainingFixture represents a match a team still has to play
+type RemainingFixture struct {
+	MatchID      int        `json:"match_id"`
+	Week         int        `json:"week"`
+	Venue        string     `json:"venue"` // "home" or "away"
+	OpponentID   int        `json:"opponent_id"`
+	OpponentName string     `json:"opponent_name"`
+	KickoffTime  *time.Time `json:"kickoff_time,omitempty"`
+}
+
+// RemainingFixturesResponse represents the response for a team's unplayed fixtures in a league
+type RemainingFixturesResponse struct {
+	League   LeagueResponse     `json:"league"`
+	Team     TeamResponse       `json:"team"`
+	Fixtures []RemainingFixture `json:"fixtures"`
+}
+
+// NextFixtureResponse represents the response for a team's next match in a league
+type NextFixtureResponse struct {
+	League  LeagueResponse   `json:"league"`
+	Team    TeamResponse     `json:"team"`
+	Fixture RemainingFixture `json:"fixture"`
+}
+
+// TeamMatchesByVenueResponse represents a team's matches in a league split by venue, each list in week order
+type TeamMatchesByVenueResponse struct {
+	League LeagueResponse `json:"league"`
+	Team   TeamResponse   `json:"team"`
+	Home   []MatchResult  `json:"home"`
+	Away   []MatchResult  `json:"away"`
+}
+
+// TeamPositionResponse represents a single team's place in a league table
+type TeamPositionResponse struct {
+	League             LeagueResponse `json:"league"`
+	Team               TeamResponse   `json:"team"`
+	Position           int            `json:"position"` // 1-based
+	Zone               string         `json:"zone"`
+	Points             int            `json:"points"`
+	PointsBehindLeader int            `json:"points_behind_leader"`
+	// PointsAboveRelegation is the gap to the highest relegation place, zero or negative for a team
+	// in the relegation zone. Nil when the league relegates nobody.
+	PointsAboveRelegation *int `json:"points_above_relegation"`
+}
+
+// TeamStrengthOfSchedule represents how strong a team's remaining opponents are
+type TeamStrengthOfSchedule struct {
+	TeamID                  int     `json:"team_id"`
+	TeamName                string  `json:"team_name"`
+	RemainingMatches        int     `json:"remaining_matches"`
+	AverageOpponentStrength float64 `json:"average_opponent_strength"` // 0 when no matches remain
+}
+
+// StrengthOfScheduleResponse represents the response for a league's remaining strength of schedule
+type StrengthOfScheduleResponse struct {
+	League LeagueResponse           `json:"league"`
+	Teams  []TeamStrengthOfSchedule `json:"teams"` // hardest run-in first
+}
+
 // ChampionProbability represents championship probability for a team
 type ChampionProbability struct {
 	TeamID      int     `json:"team_id"`
@@ -151,9 +670,50 @@ type PredictChampionResponse struct {
 	Simulations           int                   `json:"simulations"`
 	CurrentStandings      []StandingWithTeam    `json:"current_standings"`
 	ChampionProbabilities []ChampionProbability `json:"champion_probabilities"`
+	CoChampions           []StandingWithTeam    `json:"co_champions,omitempty"` // set when a finished league's title is shared
 	Message               string                `json:"message"`
 }
 
+// BottomTeamResponse represents the response for the team currently last in a league
+type BottomTeamResponse struct {
+	League              LeagueResponse   `json:"league"`
+	Team                StandingWithTeam `json:"team"`
+	Position            int              `json:"position"`
+	RemainingMatches    int              `json:"remaining_matches"`
+	MaxPossiblePoints   int              `json:"max_possible_points"`
+	RelegationConfirmed bool             `json:"relegation_confirmed"` // true when the team can no longer climb off the bottom
+	Message             string           `json:"message"`
+}
+
+// TitleDecidedResponse represents the week a finished league's champion was mathematically confirmed
+type TitleDecidedResponse struct {
+	League       LeagueResponse   `json:"league"`
+	Champion     StandingWithTeam `json:"champion"`
+	DecidedWeek  int              `json:"decided_week"` // first week after which no other team could catch the champion
+	FinalWeek    int              `json:"final_week"`
+	WeeksToSpare int              `json:"weeks_to_spare"` // weeks left to play after the title was decided
+	Lead         int              `json:"lead"`           // points ahead of the nearest challenger after the deciding week
+	Message      string           `json:"message"`
+}
+
+// StandingDiscrepancy describes a standings field that doesn't match the played matches
+type StandingDiscrepancy struct {
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Field    string `json:"field"`
+	Stored   int    `json:"stored"`
+	Expected int    `json:"expected"`
+}
+
+// VerifyStandingsResponse represents the result of checking stored standings against the matches
+type VerifyStandingsResponse struct {
+	League         LeagueResponse        `json:"league"`
+	Consistent     bool                  `json:"consistent"`
+	MatchesChecked int                   `json:"matches_checked"`
+	Discrepancies  []StandingDiscrepancy `json:"discrepancies"`
+	Message        string                `json:"message"`
+}
+
 // EditMatchRequest represents the request to edit a match result
 type EditMatchRequest struct {
 	HomeGoals int `json:"home_goals"`
@@ -167,3 +727,302 @@ type EditMatchResponse struct {
 	NewResult      string      `json:"new_result"`
 	Message        string      `json:"message"`
 }
+
+// ForfeitMatchRequest represents the request body for forfeiting a match
+type ForfeitMatchRequest struct {
+	Side  string `json:"side"`            // "home" or "away", the side that forfeits
+	Goals *int   `json:"goals,omitempty"` // goals awarded to the opponent, defaults to 3
+}
+
+// MatchUpdateResponse represents the response for a change to a single match,
+// such as swapping its venue, cancelling, reinstating or forfeiting it
+type MatchUpdateResponse struct {
+	Match   MatchResult `json:"match"`
+	Message string      `json:"message"`
+}
+
+// CloneLeagueResponse represents the response for cloning a league's teams into a new league
+type CloneLeagueResponse struct {
+	League         LeagueResponse `json:"league"`
+	SourceLeagueID int            `json:"source_league_id"`
+	Teams          []Team         `json:"teams"`
+	Message        string         `json:"message"`
+}
+
+// ImportLeagueRequest represents a league document to import
+type ImportLeagueRequest struct {
+	Name    string        `json:"name"`
+	Teams   []ImportTeam  `json:"teams"`
+	Matches []ImportMatch `json:"matches"`
+}
+
+// ImportTeam represents a team in an imported league document.
+// ID only identifies the team within the document; new teams are created on import.
+type ImportTeam struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Strength int    `json:"strength"`
+}
+
+// ImportMatch represents a match in an imported league document, referencing teams by document ID
+type ImportMatch struct {
+	HomeTeamID int  `json:"home_team_id"`
+	AwayTeamID int  `json:"away_team_id"`
+	Week       int  `json:"week"`
+	HomeGoals  *int `json:"home_goals"` // nil for an unplayed match
+	AwayGoals  *int `json:"away_goals"` // nil for an unplayed match
+}
+
+// ImportLeagueResponse represents the response for importing a league
+type ImportLeagueResponse struct {
+	League       LeagueResponse `json:"league"`
+	Teams        []Team         `json:"teams"`
+	MatchesCount int            `json:"matches_count"`
+	Message      string         `json:"message"`
+}
+
+// SimulateMatchRequest represents the request body for simulating a standalone match.
+// Each side is given either as a strength or as the ID of an existing team.
+type SimulateMatchRequest struct {
+	HomeStrength  *int `json:"home_strength,omitempty"`
+	AwayStrength  *int `json:"away_strength,omitempty"`
+	HomeTeamID    *int `json:"home_team_id,omitempty"`
+	AwayTeamID    *int `json:"away_team_id,omitempty"`
+	HomeAdvantage *int `json:"home_advantage,omitempty"` // Strength bonus for the home side, defaults to 4
+}
+
+// SimulateMatchResponse represents a simulated scoreline and the expectancy it was drawn from
+type SimulateMatchResponse struct {
+	HomeStrength   int     `json:"home_strength"`
+	AwayStrength   int     `json:"away_strength"`
+	HomeAdvantage  int     `json:"home_advantage"`
+	HomeExpectancy float64 `json:"home_expectancy"`
+	AwayExpectancy float64 `json:"away_expectancy"`
+	HomeGoals      int     `json:"home_goals"`
+	AwayGoals      int     `json:"away_goals"`
+}
+
+// SimulateSeriesRequest represents the request body for simulating a series of matches between two teams
+type SimulateSeriesRequest struct {
+	Team1ID int `json:"team1_id"`
+	Team2ID int `json:"team2_id"`
+	Matches int `json:"matches"`
+}
+
+// SimulateSeriesResponse represents the aggregate outcome of a simulated series, from team 1's point of view
+type SimulateSeriesResponse struct {
+	Team1             TeamResponse `json:"team1"`
+	Team2             TeamResponse `json:"team2"`
+	Matches           int          `json:"matches"`
+	Team1Wins         int          `json:"team1_wins"`
+	Draws             int          `json:"draws"`
+	Team2Wins         int          `json:"team2_wins"`
+	Team1AverageGoals float64      `json:"team1_average_goals"`
+	Team2AverageGoals float64      `json:"team2_average_goals"`
+}
+
+// LeagueProgressResponse represents how far through its season a league is
+type LeagueProgressResponse struct {
+	LeagueID        int     `json:"league_id"`
+	Status          string  `json:"status"`
+	CurrentWeek     int     `json:"current_week"`
+	TotalWeeks      int     `json:"total_weeks"`
+	WeeksRemaining  int     `json:"weeks_remaining"`
+	PercentComplete float64 `json:"percent_complete"`
+}
+
+// TwoLegResult represents the outcome of a tie played over two legs, from team 1 and team 2's perspective
+type TwoLegResult struct {
+	Team1Aggregate int    `json:"team1_aggregate"`
+	Team2Aggregate int    `json:"team2_aggregate"`
+	Team1AwayGoals int    `json:"team1_away_goals"`
+	Team2AwayGoals int    `json:"team2_away_goals"`
+	WinnerID       *int   `json:"winner_id"`  // nil when the tie is level
+	DecidedBy      string `json:"decided_by"` // "aggregate", "away_goals" or "level"
+}
+
+// HeadToHeadResultResponse represents the aggregate result of two teams' league meetings treated as a two-legged tie
+type HeadToHeadResultResponse struct {
+	LeagueID      int          `json:"league_id"`
+	Team1         TeamResponse `json:"team1"`
+	Team2         TeamResponse `json:"team2"`
+	AwayGoalsRule bool         `json:"away_goals_rule"`
+	Legs          []Match      `json:"legs"` // in week order
+	Result        TwoLegResult `json:"result"`
+}
+
+// TeamComparisonStats holds one team's figures in a league for a side-by-side comparison
+type TeamComparisonStats struct {
+	Team           TeamResponse     `json:"team"`
+	Position       int              `json:"position"`
+	Played         int              `json:"played"`
+	Points         int              `json:"points"`
+	GoalsFor       int              `json:"goals_for"`
+	GoalsAgainst   int              `json:"goals_against"`
+	GoalDifference int              `json:"goal_difference"`
+	Form           []string         `json:"form"` // up to the last five results, oldest first: "W", "D" or "L"
+	Home           HeadToHeadRecord `json:"home"`
+	Away           HeadToHeadRecord `json:"away"`
+}
+
+// TeamComparisonResponse represents two teams' league stats side by side with their meetings
+type TeamComparisonResponse struct {
+	League     LeagueResponse      `json:"league"`
+	Team1      TeamComparisonStats `json:"team1"`
+	Team2      TeamComparisonStats `json:"team2"`
+	HeadToHead HeadToHeadRecord    `json:"head_to_head"` // from team1's perspective
+}
+
+// LeagueRecord represents the match that holds a league record.
+// Value is the record figure: the winning margin, the combined goals or one team's goals.
+type LeagueRecord struct {
+	Match    MatchResult `json:"match"`
+	Value    int         `json:"value"`
+	TeamID   *int        `json:"team_id,omitempty"` // the team holding the record, for team records
+	TeamName string      `json:"team_name,omitempty"`
+}
+
+// LeagueRecordsResponse represents a league's notable results; each record is null until a played match qualifies
+type LeagueRecordsResponse struct {
+	LeagueID        int           `json:"league_id"`
+	MatchesPlayed   int           `json:"matches_played"`
+	BiggestWin      *LeagueRecord `json:"biggest_win"`
+	HighestScoring  *LeagueRecord `json:"highest_scoring"`
+	MostGoalsByTeam *LeagueRecord `json:"most_goals_by_team"`
+}
+
+// ReplayWeek represents one week of a season replay: its played results and the standings after them
+type ReplayWeek struct {
+	Week      int                `json:"week"`
+	Results   []MatchResult      `json:"results"`
+	Standings []StandingWithTeam `json:"standings"`
+}
+
+// LeagueReplayResponse represents a league's season played back week by week, from week 1 to its
+// current week. Truncated is set when the season has more weeks than a replay holds.
+type LeagueReplayResponse struct {
+	League    LeagueResponse `json:"league"`
+	Weeks     []ReplayWeek   `json:"weeks"`
+	Truncated bool           `json:"truncated"`
+}
+
+// WeekAnalysis represents the goals and winning margins of one week's played matches
+type WeekAnalysis struct {
+	Week          int     `json:"week"`
+	MatchesPlayed int     `json:"matches_played"`
+	TotalGoals    int     `json:"total_goals"`
+	AverageMargin float64 `json:"average_margin"` // mean goal difference between the sides, a draw counting as 0
+}
+
+// WeekAnalysisResponse represents a league's played weeks in order with the standout ones picked
+// out; the standout weeks are null until a week has been played and ties go to the earliest week
+type WeekAnalysisResponse struct {
+	LeagueID           int            `json:"league_id"`
+	Weeks              []WeekAnalysis `json:"weeks"`
+	HighestScoringWeek *WeekAnalysis  `json:"highest_scoring_week"`
+	MostOneSidedWeek   *WeekAnalysis  `json:"most_one_sided_week"`
+	TightestWeek       *WeekAnalysis  `json:"tightest_week"`
+}
+
+// TeamExpectedPoints compares the points a team took from its played matches with the points
+// it was expected to take from them
+type TeamExpectedPoints struct {
+	TeamID         int     `json:"team_id"`
+	TeamName       string  `json:"team_name"`
+	Played         int     `json:"played"`
+	Points         int     `json:"points"`
+	ExpectedPoints float64 `json:"expected_points"`
+	Difference     float64 `json:"difference"` // points minus expected points; positive for an over-performer
+}
+
+// ExpectedPointsResponse represents every team's actual and expected points, biggest over-performer first
+type ExpectedPointsResponse struct {
+	League LeagueResponse       `json:"league"`
+	Teams  []TeamExpectedPoints `json:"teams"`
+}
+
+// LeaderboardEntry represents a team's combined record over every finished league it played in
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank"`
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Leagues  int    `json:"leagues"` // finished leagues the team has standings in
+	Points   int    `json:"points"`
+	Wins     int    `json:"wins"`
+}
+
+// LeaderboardResponse represents the all-time leaderboard across finished leagues
+type LeaderboardResponse struct {
+	Limit   int                `json:"limit"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// League audit log event types
+const (
+	LeagueEventCreated      = "created"
+	LeagueEventTeamAdded    = "team_added"
+	LeagueEventTeamRemoved  = "team_removed"
+	LeagueEventStarted      = "started"
+	LeagueEventWeekAdvanced = "week_advanced"
+	LeagueEventFinished     = "finished"
+	LeagueEventPaused       = "paused"
+	LeagueEventResumed      = "resumed"
+	LeagueEventMatchEdited  = "match_edited"
+)
+
+// LeagueEvent represents one entry in a league's audit log
+type LeagueEvent struct {
+	ID        int       `json:"id"`
+	LeagueID  int       `json:"league_id"`
+	Type      string    `json:"type"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LeagueEventsResponse represents a league's audit log, oldest event first
+type LeagueEventsResponse struct {
+	League LeagueResponse `json:"league"`
+	Events []LeagueEvent  `json:"events"`
+}
+
+// SimulationRun records the parameters and outcome of one play-all run
+type SimulationRun struct {
+	ID           int       `json:"id"`
+	LeagueID     int       `json:"league_id"`
+	Mode         string    `json:"mode"`                  // "random", "expected" or "tempered"
+	Temperature  *float64  `json:"temperature,omitempty"` // set in "tempered" mode
+	StartingWeek int       `json:"starting_week"`
+	FinalWeek    int       `json:"final_week"`
+	TableHash    string    `json:"table_hash"` // SHA-256 of the final table, equal for identical tables
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SimulationRunsResponse represents a league's play-all runs, oldest first
+type SimulationRunsResponse struct {
+	League LeagueResponse  `json:"league"`
+	Runs   []SimulationRun `json:"runs"`
+}
+
+// HomeAwaySplit represents league-wide results and goals by venue over played matches
+type HomeAwaySplit struct {
+	MatchesPlayed int `json:"matches_played"`
+	HomeWins      int `json:"home_wins"`
+	AwayWins      int `json:"away_wins"`
+	Draws         int `json:"draws"`
+	HomeGoals     int `json:"home_goals"`
+	AwayGoals     int `json:"away_goals"`
+}
+
+// HomeAwaySplitResponse represents the response for a league's home and away split
+type HomeAwaySplitResponse struct {
+	League LeagueResponse `json:"league"`
+	HomeAwaySplit
+	HomeWinPercent float64 `json:"home_win_percent"` // share of played matches won by the home side, 0-100
+}
+
+// LeagueSummary represents league counts by status and the total number of teams
+type LeagueSummary struct {
+	TotalLeagues    int            `json:"total_leagues"`
+	LeaguesByStatus map[string]int `json:"leagues_by_status"` // always includes created, started and finished
+	TotalTeams      int            `json:"total_teams"`
+}
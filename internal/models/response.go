@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ResponseEnvelope wraps a successful response payload with request metadata
+type ResponseEnvelope struct {
+	Data any          `json:"data"`
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ResponseMeta holds metadata about the request a response answers
+type ResponseMeta struct {
+	RequestID string    `json:"request_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FieldError describes a problem with one field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// UnplayedMatchesErrorResponse lists the matches that must be played before a request can proceed
+type UnplayedMatchesErrorResponse struct {
+	Error    string `json:"error"`
+	MatchIDs []int  `json:"match_ids"`
+}
+
+// ValidationErrorResponse lists every validation problem found in a request
+type ValidationErrorResponse struct {
+	Error  string       `json:"error"`
+	Errors []FieldError `json:"errors"`
+}
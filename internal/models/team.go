@@ -2,20 +2,89 @@ package models
 
 // Team represents a sports team in the league
 type Team struct {
-	ID       int    `json:"id" db:"id"`
-	Name     string `json:"name" db:"name"`
-	Strength int    `json:"strength" db:"strength"`
+	ID           int    `json:"id" db:"id"`
+	Name         string `json:"name" db:"name"`
+	Strength     int    `json:"strength" db:"strength"`
+	PrimaryColor string `json:"primary_color,omitempty" db:"primary_color"` // hex color such as "#6CABDD", empty if not set
+	LogoURL      string `json:"logo_url,omitempty" db:"logo_url"`           // empty if not set
 }
 
 // CreateTeamRequest represents the request payload for creating a team
 type CreateTeamRequest struct {
-	Name     string `json:"name" validate:"required"`
-	Strength int    `json:"strength"`
+	Name         string `json:"name" validate:"required"`
+	Strength     int    `json:"strength"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
 }
 
 // TeamResponse represents the response payload for team operations
 type TeamResponse struct {
-	ID       int    `json:"id"`
-	Name     string `json:"name"`
-	Strength int    `json:"strength"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Strength     int    `json:"strength"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
+}
+
+// NewTeamResponse converts a team to its response format
+func NewTeamResponse(team *Team) TeamResponse {
+	return TeamResponse{
+		ID:           team.ID,
+		Name:         team.Name,
+		Strength:     team.Strength,
+		PrimaryColor: team.PrimaryColor,
+		LogoURL:      team.LogoURL,
+	}
+}
+
+// TeamImportRowError lists the problems with one row of a team import; Row counts the header as row 1
+type TeamImportRowError struct {
+	Row    int          `json:"row"`
+	Errors []FieldError `json:"errors"`
+}
+
+// ImportTeamsResponse represents the result of a team import. Teams are only created when
+// every row is valid, so at most one of Teams and Errors is non-empty.
+type ImportTeamsResponse struct {
+	Teams  []TeamResponse       `json:"teams"`
+	Errors []TeamImportRowError `json:"errors"`
+}
+
+// TeamLeague represents a league a team belongs to and the team's position in its standings
+type TeamLeague struct {
+	League   LeagueResponse `json:"league"`
+	Position *int           `json:"position"` // nil if the team has no standings entry
+}
+
+// HeadToHeadRecord summarizes a team's results against an opponent
+type HeadToHeadRecord struct {
+	Played       int `json:"played"`
+	Wins         int `json:"wins"`
+	Draws        int `json:"draws"`
+	Losses       int `json:"losses"`
+	GoalsFor     int `json:"goals_for"`
+	GoalsAgainst int `json:"goals_against"`
+}
+
+// HeadToHeadResponse represents the played meetings between two teams, most recent first
+type HeadToHeadResponse struct {
+	Team     TeamResponse     `json:"team"`
+	Opponent TeamResponse     `json:"opponent"`
+	LeagueID *int             `json:"league_id,omitempty"` // nil when not scoped to a league
+	Limit    int              `json:"limit"`
+	Record   HeadToHeadRecord `json:"record"` // from the team's perspective, over the returned matches
+	Matches  []Match          `json:"matches"`
+}
+
+// RivalryResponse represents the response for registering a rivalry between two teams
+type RivalryResponse struct {
+	Team    TeamResponse `json:"team"`
+	Rival   TeamResponse `json:"rival"`
+	Message string       `json:"message"`
+}
+
+// RivalsResponse represents the teams registered as a team's rivals
+type RivalsResponse struct {
+	Team   TeamResponse   `json:"team"`
+	Rivals []TeamResponse `json:"rivals"`
 }
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// gzipMinSize is the smallest response body worth compressing; smaller bodies are sent as is
+const gzipMinSize = 1024
+
+// gzipMiddleware compresses successful responses of at least gzipMinSize bytes for clients
+// that send Accept-Encoding: gzip. Small responses and error responses are left uncompressed.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer gw.finish()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		// gzip;q=0 explicitly refuses the encoding
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			return err == nil && q > 0
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter holds back the status and body until it knows whether the response is
+// large enough to compress, then either streams it through gzip or writes it unchanged
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	passThrough bool // the response is written uncompressed as it arrives
+}
+
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	gw.status = code
+}
+
+func (gw *gzipResponseWriter) Write(p []byte) (int, error) {
+	switch {
+	case gw.gz != nil:
+		return gw.gz.Write(p)
+	case gw.passThrough:
+		return gw.ResponseWriter.Write(p)
+	}
+
+	// Errors and bodies the handler already encoded are never compressed
+	if gw.status >= http.StatusBadRequest || gw.Header().Get("Content-Encoding") != "" {
+		gw.passThrough = true
+		gw.ResponseWriter.WriteHeader(gw.status)
+		return gw.ResponseWriter.Write(p)
+	}
+
+	gw.buf.Write(p)
+	if gw.buf.Len() >= gzipMinSize {
+		if err := gw.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// startGzip switches the response to gzip and compresses the buffered body
+func (gw *gzipResponseWriter) startGzip() error {
+	header := gw.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	gw.ResponseWriter.WriteHeader(gw.status)
+
+	gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	_, err := gw.gz.Write(gw.buf.Bytes())
+	gw.buf.Reset()
+	return err
+}
+
+// finish flushes the gzip stream, or writes a response that stayed under the threshold as is
+func (gw *gzipResponseWriter) finish() {
+	switch {
+	case gw.gz != nil:
+		gw.gz.Close()
+	case !gw.passThrough:
+		gw.ResponseWriter.WriteHeader(gw.status)
+		gw.ResponseWriter.Write(gw.buf.Bytes())
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"insider-league-manager/internal/database"
+	"insider-league-manager/internal/handlers"
+	"insider-league-manager/internal/models"
+)
+
+// teamListDB serves a fixed team list; other Service methods are not used
+type teamListDB struct {
+	database.Service
+	teams []*models.Team
+}
+
+func (db *teamListDB) GetAllTeams(ctx context.Context) ([]*models.Team, error) {
+	return db.teams, nil
+}
+
+func newTeamListServer(count int) http.Handler {
+	db := &teamListDB{}
+	for i := 1; i <= count; i++ {
+		db.teams = append(db.teams, &models.Team{ID: i, Name: fmt.Sprintf("Team %d", i), Strength: 50})
+	}
+	s := &Server{db: db, teamHandler: handlers.NewTeamHandler(db)}
+	return s.RegisterRoutes()
+}
+
+func TestGzipCompressesLargeResponses(t *testing.T) {
+	handler := newTeamListServer(200)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/teams", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200; got %v", w.Code)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected Content-Encoding gzip; got %q", got)
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("expected a gzip body. Err: %v", err)
+	}
+	var teams []models.TeamResponse
+	if err := json.NewDecoder(reader).Decode(&teams); err != nil {
+		t.Fatalf("error decoding decompressed body. Err: %v", err)
+	}
+	if len(teams) != 200 {
+		t.Errorf("expected 200 teams; got %d", len(teams))
+	}
+}
+
+func TestGzipLeavesSmallAndErrorResponsesUncompressed(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		path           string
+		acceptEncoding string
+		status         int
+	}{
+		{"small response", newTeamListServer(2), "/api/teams", "gzip", http.StatusOK},
+		{"error response", newTeamListServer(200), "/api/unknown", "gzip", http.StatusNotFound},
+		{"gzip not accepted", newTeamListServer(200), "/api/teams", "", http.StatusOK},
+		{"gzip refused", newTeamListServer(200), "/api/teams", "gzip;q=0", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d; got %d", tt.status, w.Code)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("expected no Content-Encoding; got %q", got)
+			}
+			body, _ := io.ReadAll(w.Body)
+			if !json.Valid(body) {
+				t.Errorf("expected a plain JSON body; got %q", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// latencySamples is the number of recent request durations kept per route for percentiles
+const latencySamples = 1024
+
+// requestMetrics records per-route request counts, status codes and latencies in memory
+type requestMetrics struct {
+	mu     sync.Mutex
+	routes map[string]*routeMetrics
+}
+
+// routeMetrics holds the counters for a single route pattern
+type routeMetrics struct {
+	count       uint64
+	statusCodes map[int]uint64
+	latencies   [latencySamples]time.Duration // ring buffer of recent durations
+	next        int
+	filled      bool
+}
+
+// RouteMetricsResponse represents the metrics reported for a single route
+type RouteMetricsResponse struct {
+	Count       uint64             `json:"count"`
+	StatusCodes map[string]uint64  `json:"status_codes"`
+	LatencyMs   map[string]float64 `json:"latency_ms"` // p50, p90 and p99 over recent requests
+}
+
+// MetricsResponse represents the response for GET /metrics
+type MetricsResponse struct {
+	Routes map[string]RouteMetricsResponse `json:"routes"`
+}
+
+func newRequestMetrics() *requestMetrics {
+	return &requestMetrics{
+		routes: make(map[string]*routeMetrics),
+	}
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	sr.status = code
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+// middleware records the count, status code and duration of every request
+func (m *requestMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		// The mux sets the matched pattern, which keeps the number of tracked routes bounded
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		m.record(route, rec.status, time.Since(start))
+	})
+}
+
+// record adds a single observation for a route
+func (m *requestMetrics) record(route string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rm, ok := m.routes[route]
+	if !ok {
+		rm = &routeMetrics{statusCodes: make(map[int]uint64)}
+		m.routes[route] = rm
+	}
+
+	rm.count++
+	rm.statusCodes[status]++
+	rm.latencies[rm.next] = duration
+	rm.next++
+	if rm.next == latencySamples {
+		rm.next = 0
+		rm.filled = true
+	}
+}
+
+// snapshot builds the reported metrics, computing latency percentiles
+func (m *requestMetrics) snapshot() MetricsResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp := MetricsResponse{Routes: make(map[string]RouteMetricsResponse, len(m.routes))}
+	for route, rm := range m.routes {
+		statusCodes := make(map[string]uint64, len(rm.statusCodes))
+		for code, count := range rm.statusCodes {
+			statusCodes[strconv.Itoa(code)] = count
+		}
+
+		n := rm.next
+		if rm.filled {
+			n = latencySamples
+		}
+		samples := make([]time.Duration, n)
+		copy(samples, rm.latencies[:n])
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+		resp.Routes[route] = RouteMetricsResponse{
+			Count:       rm.count,
+			StatusCodes: statusCodes,
+			LatencyMs: map[string]float64{
+				"p50": percentile(samples, 50),
+				"p90": percentile(samples, 90),
+				"p99": percentile(samples, 99),
+			},
+		}
+	}
+
+	return resp
+}
+
+// percentile returns the p-th percentile of sorted durations in milliseconds
+func percentile(sorted []time.Duration, p int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := (len(sorted) - 1) * p / 100
+	return float64(sorted[index]) / float64(time.Millisecond)
+}
+
+// handler serves the collected metrics as JSON
+func (m *requestMetrics) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.snapshot()); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	s := &Server{}
+	handler := s.RegisterRoutes()
+
+	// Issue several requests against the root route
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status OK; got %v", w.Code)
+		}
+	}
+
+	// And one rejected request on a method-checked route
+	req := httptest.NewRequest(http.MethodGet, "/api/leagues/create", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status OK; got %v", w.Code)
+	}
+
+	var resp MetricsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("error decoding metrics response. Err: %v", err)
+	}
+
+	root, ok := resp.Routes["/{$}"]
+	if !ok {
+		t.Fatalf("expected metrics for route '/{$}'; got %v", resp.Routes)
+	}
+	if root.Count != 3 {
+		t.Errorf("expected count 3 for '/{$}'; got %d", root.Count)
+	}
+	if root.StatusCodes["200"] != 3 {
+		t.Errorf("expected 3 responses with status 200 for '/{$}'; got %d", root.StatusCodes["200"])
+	}
+	if _, ok := root.LatencyMs["p99"]; !ok {
+		t.Errorf("expected p99 latency for '/{$}'")
+	}
+
+	create := resp.Routes["/api/leagues/create"]
+	if create.Count != 1 || create.StatusCodes["405"] != 1 {
+		t.Errorf("expected one 405 for '/api/leagues/create'; got %+v", create)
+	}
+}
@@ -9,11 +9,14 @@ import (
 
 func (s *Server) RegisterRoutes() http.Handler {
 	mux := http.NewServeMux()
+	s.metrics = newRequestMetrics()
 
-	// Register routes
-	mux.HandleFunc("/", s.HelloWorldHandler)
+	// Register routes; "/{$}" matches only the root path, everything unregistered falls through to "/"
+	mux.HandleFunc("/{$}", s.indexHandler)
+	mux.HandleFunc("/", s.notFoundHandler)
 
 	mux.HandleFunc("/health", s.healthHandler)
+	mux.HandleFunc("/metrics", s.metrics.handler)
 
 	// Team routes
 	mux.HandleFunc("/api/teams", s.teamsHandler)
@@ -22,17 +25,36 @@ func (s *Server) RegisterRoutes() http.Handler {
 	// League routes
 	mux.HandleFunc("/api/leagues/create", s.leaguesCreateHandler)
 	mux.HandleFunc("/api/leagues/initialize", s.leaguesInitializeHandler)
+	mux.HandleFunc("/api/leagues/import", s.leaguesImportHandler)
+	mux.HandleFunc("/api/leagues/summary", s.leaguesSummaryHandler)
 	mux.HandleFunc("/api/leagues/add-team/", s.leaguesAddTeamHandler)
 	mux.HandleFunc("/api/leagues/remove-team/", s.leaguesRemoveTeamHandler)
 	mux.HandleFunc("/api/leagues/start/", s.leaguesStartHandler)
 	mux.HandleFunc("/api/leagues/advance-week/", s.leaguesAdvanceWeekHandler)
+	mux.HandleFunc("/api/leagues/advance-weeks/", s.leaguesAdvanceWeeksHandler)
 	mux.HandleFunc("/api/leagues/view-matches/", s.leaguesViewMatchesHandler)
 	mux.HandleFunc("/api/leagues/play-all-matches/", s.leaguesPlayAllMatchesHandler)
 	mux.HandleFunc("/api/leagues/predict-champion/", s.leaguesPredictChampionHandler)
+	mux.HandleFunc("/api/leagues/bottom/", s.leaguesBottomHandler)
 	mux.HandleFunc("/api/leagues/edit-match/", s.leaguesEditMatchHandler)
+	mux.HandleFunc("/api/leagues/", s.leagueResourceHandler) // Handle /api/leagues/:leagueID/* patterns
 
-	// Wrap the mux with CORS middleware
-	return s.corsMiddleware(mux)
+	// Admin routes
+	mux.HandleFunc("/api/admin/advance-all", s.adminAdvanceAllHandler)
+	mux.HandleFunc("/api/admin/cleanup-finished", s.adminCleanupFinishedHandler)
+
+	// Match routes
+	mux.HandleFunc("/api/matches/", s.matchesHandler) // Handle /api/matches/:matchID/* patterns
+
+	// Leaderboard routes
+	mux.HandleFunc("/api/leaderboard", s.leaderboardHandler)
+
+	// Simulation routes
+	mux.HandleFunc("/api/simulate-match", s.simulateMatchHandler)
+	mux.HandleFunc("/api/simulate-series", s.simulateSeriesHandler)
+
+	// Wrap the mux with metrics, compression and CORS middleware
+	return s.corsMiddleware(s.metrics.middleware(gzipMiddleware(mux)))
 }
 
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
@@ -40,7 +62,7 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*") // Replace "*" with specific origins if needed
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Request-ID, Idempotency-Key")
 		w.Header().Set("Access-Control-Allow-Credentials", "false") // Set to "true" if credentials are required
 
 		// Handle preflight OPTIONS requests
@@ -54,16 +76,59 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func (s *Server) HelloWorldHandler(w http.ResponseWriter, r *http.Request) {
-	resp := map[string]string{"message": "Hello World"}
-	jsonResp, err := json.Marshal(resp)
-	if err != nil {
-		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+// notFoundHandler returns a JSON 404 for paths that don't match any route
+func (s *Server) notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+
+	resp := map[string]string{"error": "Not found", "path": r.URL.Path}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// Version is the service version reported by the API index.
+// Override it at build time with -ldflags "-X insider-league-manager/internal/server.Version=<version>".
+var Version = "dev"
+
+// apiEndpoint describes one entry of the API index
+type apiEndpoint struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+// apiIndex is the static body served at the root path
+type apiIndex struct {
+	Name      string        `json:"name"`
+	Version   string        `json:"version"`
+	Endpoints []apiEndpoint `json:"endpoints"`
+}
+
+// apiEndpoints lists the base paths of each route group; see the README for every endpoint
+var apiEndpoints = []apiEndpoint{
+	{Path: "/health", Description: "Database health check"},
+	{Path: "/metrics", Description: "Per-route request counts, status codes and latencies"},
+	{Path: "/api/teams", Description: "Create, list, update and delete teams; team history, head-to-head and rivals"},
+	{Path: "/api/leagues", Description: "Create, start and simulate leagues; fixtures, standings, predictions and stats"},
+	{Path: "/api/matches", Description: "Swap venues, cancel, reinstate and forfeit matches"},
+	{Path: "/api/leaderboard", Description: "Teams ranked by points across finished leagues"},
+	{Path: "/api/simulate-match", Description: "Simulate a single match without saving it"},
+	{Path: "/api/simulate-series", Description: "Simulate a series between two teams and compare the results"},
+	{Path: "/api/admin/advance-all", Description: "Advance every started league by one week"},
+	{Path: "/api/admin/cleanup-finished", Description: "Delete leagues that finished a while ago"},
+}
+
+// indexHandler returns the API index listing the available endpoints and the service version
+func (s *Server) indexHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+
+	resp := apiIndex{Name: "Insider League Manager", Version: Version, Endpoints: apiEndpoints}
 	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(jsonResp); err != nil {
-		log.Printf("Failed to write response: %v", err)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode response: %v", err)
 	}
 }
 
@@ -97,6 +162,26 @@ func (s *Server) teamsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Handle /api/teams/generate before treating the segment as a team ID
+	if path == "api/teams/generate" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.GenerateTeamsHandler(w, r)
+		return
+	}
+
+	// Handle /api/teams/import before treating the segment as a team ID
+	if path == "api/teams/import" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.ImportTeamsHandler(w, r)
+		return
+	}
+
 	// Handle /api/teams/{id}
 	if len(pathParts) == 3 && pathParts[0] == "api" && pathParts[1] == "teams" {
 		switch r.Method {
@@ -112,8 +197,48 @@ func (s *Server) teamsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Handle /api/teams/{id}/leagues
+	if len(pathParts) == 4 && pathParts[0] == "api" && pathParts[1] == "teams" && pathParts[3] == "leagues" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.GetTeamLeaguesHandler(w, r)
+		return
+	}
+
+	// Handle /api/teams/{id}/rivals
+	if len(pathParts) == 4 && pathParts[0] == "api" && pathParts[1] == "teams" && pathParts[3] == "rivals" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.GetRivalsHandler(w, r)
+		return
+	}
+
+	// Handle /api/teams/{id}/rivals/{rivalID}
+	if len(pathParts) == 5 && pathParts[0] == "api" && pathParts[1] == "teams" && pathParts[3] == "rivals" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.AddRivalryHandler(w, r)
+		return
+	}
+
+	// Handle /api/teams/{id}/head-to-head/{opponentID}
+	if len(pathParts) == 5 && pathParts[0] == "api" && pathParts[1] == "teams" && pathParts[3] == "head-to-head" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.GetHeadToHeadHandler(w, r)
+		return
+	}
+
 	// If we get here, the path doesn't match any known pattern
-	http.Error(w, "Not found", http.StatusNotFound)
+	s.notFoundHandler(w, r)
 }
 
 // leaguesCreateHandler handles POST /api/leagues/create
@@ -136,6 +261,25 @@ func (s *Server) leaguesInitializeHandler(w http.ResponseWriter, r *http.Request
 	s.leagueHandler.InitializeLeagueHandler(w, r)
 }
 
+// leaguesImportHandler handles POST /api/leagues/import
+func (s *Server) leaguesSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.LeagueSummaryHandler(w, r)
+}
+
+func (s *Server) leaguesImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.ImportLeagueHandler(w, r)
+}
+
 // leaguesAddTeamHandler handles POST /api/leagues/add-team/:leagueID/:teamID
 func (s *Server) leaguesAddTeamHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -176,6 +320,16 @@ func (s *Server) leaguesAdvanceWeekHandler(w http.ResponseWriter, r *http.Reques
 	s.leagueHandler.AdvanceWeekHandler(w, r)
 }
 
+// leaguesAdvanceWeeksHandler handles POST /api/leagues/advance-weeks/:leagueID
+func (s *Server) leaguesAdvanceWeeksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.AdvanceWeeksHandler(w, r)
+}
+
 // leaguesViewMatchesHandler handles GET /api/leagues/view-matches/:leagueID
 func (s *Server) leaguesViewMatchesHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -206,6 +360,16 @@ func (s *Server) leaguesPredictChampionHandler(w http.ResponseWriter, r *http.Re
 	s.leagueHandler.PredictChampionHandler(w, r)
 }
 
+// leaguesBottomHandler handles GET /api/leagues/bottom/:leagueID
+func (s *Server) leaguesBottomHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.BottomTeamHandler(w, r)
+}
+
 // leaguesEditMatchHandler handles POST /api/leagues/edit-match/:matchID
 func (s *Server) leaguesEditMatchHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -215,3 +379,370 @@ func (s *Server) leaguesEditMatchHandler(w http.ResponseWriter, r *http.Request)
 
 	s.leagueHandler.EditMatchHandler(w, r)
 }
+
+// adminAdvanceAllHandler handles POST /api/admin/advance-all
+func (s *Server) adminAdvanceAllHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.AdvanceAllLeaguesHandler(w, r)
+}
+
+// adminCleanupFinishedHandler handles POST /api/admin/cleanup-finished
+func (s *Server) adminCleanupFinishedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.CleanupFinishedLeaguesHandler(w, r)
+}
+
+func (s *Server) leaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.LeaderboardHandler(w, r)
+}
+
+func (s *Server) simulateMatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.SimulateMatchHandler(w, r)
+}
+
+func (s *Server) simulateSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.leagueHandler.SimulateSeriesHandler(w, r)
+}
+
+// leagueResourceHandler routes /api/leagues/:leagueID/* requests based on method and path
+func (s *Server) leagueResourceHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	pathParts := strings.Split(path, "/")
+
+	// Handle /api/leagues/{id}/{resource}
+	if len(pathParts) == 4 && pathParts[0] == "api" && pathParts[1] == "leagues" {
+		switch pathParts[3] {
+		case "matches":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ListMatchesHandler(w, r)
+			return
+		case "cancelled":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ListMatchesHandler(w, r)
+			return
+		case "schedule":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ScheduleHandler(w, r)
+			return
+		case "calendar.ics":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.CalendarHandler(w, r)
+			return
+		case "standings":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.StandingsHandler(w, r)
+			return
+		case "verify":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.VerifyStandingsHandler(w, r)
+			return
+		case "head-to-head-result":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.HeadToHeadResultHandler(w, r)
+			return
+		case "compare":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.CompareTeamsHandler(w, r)
+			return
+		case "records":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.LeagueRecordsHandler(w, r)
+			return
+		case "week-analysis":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.WeekAnalysisHandler(w, r)
+			return
+		case "replay":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.LeagueReplayHandler(w, r)
+			return
+		case "expected-points":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ExpectedPointsHandler(w, r)
+			return
+		case "progress":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.LeagueProgressHandler(w, r)
+			return
+		case "title-decided":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.TitleDecidedHandler(w, r)
+			return
+		case "home-away-split":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.HomeAwaySplitHandler(w, r)
+			return
+		case "strength-of-schedule":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.StrengthOfScheduleHandler(w, r)
+			return
+		case "config":
+			if r.Method != http.MethodGet && r.Method != http.MethodPatch {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.LeagueConfigHandler(w, r)
+			return
+		case "groups":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.GroupStandingsHandler(w, r)
+			return
+		case "events":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.LeagueEventsHandler(w, r)
+			return
+		case "runs":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.SimulationRunsHandler(w, r)
+			return
+		case "available-teams":
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.AvailableTeamsHandler(w, r)
+			return
+		case "what-if":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.WhatIfStandingsHandler(w, r)
+			return
+		case "clone":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.CloneLeagueHandler(w, r)
+			return
+		case "pause":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.PauseLeagueHandler(w, r)
+			return
+		case "resume":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ResumeLeagueHandler(w, r)
+			return
+		case "regenerate-schedule":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.RegenerateScheduleHandler(w, r)
+			return
+		}
+	}
+
+	// Handle /api/leagues/{id}/round/{round}
+	if len(pathParts) == 5 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "round" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.RoundFixturesHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/trend
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "trend" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.TeamTrendHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/remaining
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "remaining" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.RemainingFixturesHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/next
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "next" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.NextFixtureHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/by-venue
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "by-venue" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.TeamMatchesByVenueHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/position
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "position" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.TeamPositionHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/venue-balance
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "venue-balance" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.VenueBalanceHandler(w, r)
+		return
+	}
+
+	// Handle /api/leagues/{id}/teams/{teamID}/group
+	if len(pathParts) == 6 && pathParts[0] == "api" && pathParts[1] == "leagues" && pathParts[3] == "teams" && pathParts[5] == "group" {
+		if r.Method != http.MethodPut {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.leagueHandler.SetTeamGroupHandler(w, r)
+		return
+	}
+
+	// If we get here, the path doesn't match any known pattern
+	s.notFoundHandler(w, r)
+}
+
+// matchesHandler routes /api/matches/:matchID/* requests based on method and path
+func (s *Server) matchesHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	pathParts := strings.Split(path, "/")
+
+	// Handle /api/matches/{id}/{action}
+	if len(pathParts) == 4 && pathParts[0] == "api" && pathParts[1] == "matches" {
+		switch pathParts[3] {
+		case "swap-venue":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.SwapVenueHandler(w, r)
+			return
+		case "cancel":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.CancelMatchHandler(w, r)
+			return
+		case "reinstate":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ReinstateMatchHandler(w, r)
+			return
+		case "forfeit":
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.leagueHandler.ForfeitMatchHandler(w, r)
+			return
+		}
+	}
+
+	// If we get here, the path doesn't match any known pattern
+	s.notFoundHandler(w, r)
+}
@@ -1,15 +1,16 @@
 package server
 
 import (
-	"io"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 func TestHandler(t *testing.T) {
 	s := &Server{}
-	server := httptest.NewServer(http.HandlerFunc(s.HelloWorldHandler))
+	server := httptest.NewServer(http.HandlerFunc(s.indexHandler))
 	defer server.Close()
 	resp, err := http.Get(server.URL)
 	if err != nil {
@@ -20,12 +21,67 @@ func TestHandler(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Errorf("expected status OK; got %v", resp.Status)
 	}
-	expected := "{\"message\":\"Hello World\"}"
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		t.Fatalf("error reading response body. Err: %v", err)
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type; got %q", ct)
+	}
+	var index apiIndex
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		t.Fatalf("error decoding response body. Err: %v", err)
+	}
+	if index.Version != Version {
+		t.Errorf("expected version %q; got %q", Version, index.Version)
+	}
+}
+
+func TestUnknownRoutesReturnNotFound(t *testing.T) {
+	s := &Server{}
+	handler := s.RegisterRoutes()
+
+	for _, path := range []string{"/api/nonexistent", "/hello", "/api/leagues/1/unknown", "/api/matches/1/unknown"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 for %s; got %v", path, w.Code)
+		}
+		if strings.Contains(w.Body.String(), "endpoints") {
+			t.Errorf("expected %s not to return the API index", path)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("expected JSON body for %s. Err: %v", path, err)
+		}
+		if body["error"] != "Not found" {
+			t.Errorf("expected error 'Not found' for %s; got %v", path, body)
+		}
+	}
+}
+
+func TestRootReturnsAPIIndex(t *testing.T) {
+	s := &Server{}
+	handler := s.RegisterRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status OK; got %v", w.Code)
+	}
+	var index apiIndex
+	if err := json.NewDecoder(w.Body).Decode(&index); err != nil {
+		t.Fatalf("expected JSON body. Err: %v", err)
+	}
+
+	paths := make(map[string]bool)
+	for _, endpoint := range index.Endpoints {
+		paths[endpoint.Path] = true
 	}
-	if expected != string(body) {
-		t.Errorf("expected response body to be %v; got %v", expected, string(body))
+	for _, path := range []string{"/api/leagues", "/api/teams"} {
+		if !paths[path] {
+			t.Errorf("expected index to list %s; got %v", path, index.Endpoints)
+		}
 	}
 }
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strconv"
@@ -15,17 +17,22 @@ import (
 )
 
 type Server struct {
-	port int
+	port       int
+	httpServer *http.Server
 
 	db            database.Service
 	teamHandler   *handlers.TeamHandler
 	leagueHandler *handlers.LeagueHandler
+	metrics       *requestMetrics
 }
 
-func NewServer() *http.Server {
+func NewServer() *Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
 
 	db := database.New()
+	if threshold := slowQueryThreshold(); threshold > 0 {
+		db = withSlowQueryLog(db, threshold)
+	}
 
 	// Initialize database tables
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -42,14 +49,58 @@ func NewServer() *http.Server {
 		leagueHandler: handlers.NewLeagueHandler(db),
 	}
 
-	// Declare Server config
-	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", NewServer.port),
-		Handler:      NewServer.RegisterRoutes(),
+	NewServer.httpServer = newHTTPServer(NewServer)
+
+	return NewServer
+}
+
+// Default HTTP timeouts, overridable with the READ_TIMEOUT and WRITE_TIMEOUT environment variables
+const (
+	defaultReadTimeout  = 10 * time.Second
+	defaultWriteTimeout = 30 * time.Second
+)
+
+// newHTTPServer declares the HTTP server config for the server's routes
+func newHTTPServer(s *Server) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.port),
+		Handler:      s.RegisterRoutes(),
 		IdleTimeout:  time.Minute,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		ReadTimeout:  envDuration("READ_TIMEOUT", defaultReadTimeout),
+		WriteTimeout: envDuration("WRITE_TIMEOUT", defaultWriteTimeout),
+	}
+}
+
+// envDuration reads a positive duration such as "45s" or "2m" from an environment variable,
+// using the fallback when it is unset or invalid
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// ListenAndServe starts serving HTTP requests on the configured port
+func (s *Server) ListenAndServe() error {
+	return s.httpServer.ListenAndServe()
+}
+
+// Shutdown gracefully stops the HTTP server and then closes the database.
+// The database is closed after in-flight requests finish so their queries aren't cut off.
+func (s *Server) Shutdown(ctx context.Context) error {
+	shutdownErr := s.httpServer.Shutdown(ctx)
+
+	if err := s.db.Close(); err != nil {
+		return errors.Join(shutdownErr, fmt.Errorf("failed to close database: %w", err))
 	}
 
-	return server
+	return shutdownErr
 }
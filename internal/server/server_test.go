@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"insider-league-manager/internal/database"
+)
+
+// closeRecordingDB records calls to Close; other Service methods are not used
+type closeRecordingDB struct {
+	database.Service
+	closed   int
+	closeErr error
+}
+
+func (db *closeRecordingDB) Close() error {
+	db.closed++
+	return db.closeErr
+}
+
+func TestShutdownClosesDatabase(t *testing.T) {
+	db := &closeRecordingDB{}
+	s := &Server{db: db}
+	s.httpServer = newHTTPServer(s)
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if db.closed != 1 {
+		t.Errorf("expected Close to be called once, got %d", db.closed)
+	}
+}
+
+func TestShutdownReportsCloseError(t *testing.T) {
+	closeErr := errors.New("close failed")
+	db := &closeRecordingDB{closeErr: closeErr}
+	s := &Server{db: db}
+	s.httpServer = newHTTPServer(s)
+
+	if err := s.Shutdown(context.Background()); !errors.Is(err, closeErr) {
+		t.Errorf("expected close error, got %v", err)
+	}
+}
+
+func TestNewHTTPServerTimeouts(t *testing.T) {
+	s := &Server{db: &closeRecordingDB{}}
+
+	httpServer := newHTTPServer(s)
+	if httpServer.ReadTimeout != defaultReadTimeout || httpServer.WriteTimeout != defaultWriteTimeout {
+		t.Errorf("expected default timeouts, got read %s and write %s", httpServer.ReadTimeout, httpServer.WriteTimeout)
+	}
+
+	t.Setenv("READ_TIMEOUT", "15s")
+	t.Setenv("WRITE_TIMEOUT", "2m")
+	httpServer = newHTTPServer(s)
+	if httpServer.ReadTimeout != 15*time.Second || httpServer.WriteTimeout != 2*time.Minute {
+		t.Errorf("expected timeouts from env, got read %s and write %s", httpServer.ReadTimeout, httpServer.WriteTimeout)
+	}
+
+	// Invalid values fall back to the defaults
+	t.Setenv("READ_TIMEOUT", "soon")
+	t.Setenv("WRITE_TIMEOUT", "-5s")
+	httpServer = newHTTPServer(s)
+	if httpServer.ReadTimeout != defaultReadTimeout || httpServer.WriteTimeout != defaultWriteTimeout {
+		t.Errorf("expected default timeouts for invalid values, got read %s and write %s", httpServer.ReadTimeout, httpServer.WriteTimeout)
+	}
+}
@@ -0,0 +1,326 @@
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"insider-league-manager/internal/database"
+	"insider-league-manager/internal/models"
+)
+
+// slowQueryDB wraps a database.Service and logs a warning for every call that takes
+// longer than the threshold, naming the operation so slow paths can be found
+type slowQueryDB struct {
+	db        database.Service
+	threshold time.Duration
+	logf      func(format string, args ...any)
+}
+
+var _ database.Service = (*slowQueryDB)(nil)
+
+// withSlowQueryLog wraps db so calls slower than threshold are logged
+func withSlowQueryLog(db database.Service, threshold time.Duration) *slowQueryDB {
+	return &slowQueryDB{db: db, threshold: threshold, logf: log.Printf}
+}
+
+// slowQueryThreshold reads the slow query threshold in milliseconds from SLOW_QUERY_MS.
+// It returns 0, disabling slow query logging, when the variable is unset or invalid.
+func slowQueryThreshold() time.Duration {
+	value := os.Getenv("SLOW_QUERY_MS")
+	if value == "" {
+		return 0
+	}
+
+	ms, err := strconv.Atoi(value)
+	if err != nil || ms <= 0 {
+		log.Printf("Invalid SLOW_QUERY_MS %q, slow query logging disabled", value)
+		return 0
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+// observe logs the operation if it has been running longer than the threshold since start
+func (s *slowQueryDB) observe(operation string, start time.Time) {
+	if elapsed := time.Since(start); elapsed > s.threshold {
+		s.logf("WARN slow query: %s took %s (threshold %s)", operation, elapsed.Round(time.Millisecond), s.threshold)
+	}
+}
+
+// Health and Close are passed straight through; they aren't queries worth timing
+
+func (s *slowQueryDB) Health() map[string]string {
+	return s.db.Health()
+}
+
+func (s *slowQueryDB) Close() error {
+	return s.db.Close()
+}
+
+func (s *slowQueryDB) InitializeTables(ctx context.Context) error {
+	defer s.observe("InitializeTables", time.Now())
+	return s.db.InitializeTables(ctx)
+}
+
+func (s *slowQueryDB) CreateTeam(ctx context.Context, req *models.CreateTeamRequest) (*models.Team, error) {
+	defer s.observe("CreateTeam", time.Now())
+	return s.db.CreateTeam(ctx, req)
+}
+
+func (s *slowQueryDB) CreateTeams(ctx context.Context, reqs []models.CreateTeamRequest) ([]*models.Team, error) {
+	defer s.observe("CreateTeams", time.Now())
+	return s.db.CreateTeams(ctx, reqs)
+}
+
+func (s *slowQueryDB) GetAllTeams(ctx context.Context) ([]*models.Team, error) {
+	defer s.observe("GetAllTeams", time.Now())
+	return s.db.GetAllTeams(ctx)
+}
+
+func (s *slowQueryDB) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
+	defer s.observe("GetTeamByID", time.Now())
+	return s.db.GetTeamByID(ctx, teamID)
+}
+
+func (s *slowQueryDB) UpdateTeam(ctx context.Context, teamID int, req *models.CreateTeamRequest) (*models.Team, error) {
+	defer s.observe("UpdateTeam", time.Now())
+	return s.db.UpdateTeam(ctx, teamID, req)
+}
+
+func (s *slowQueryDB) DeleteTeam(ctx context.Context, teamID int) error {
+	defer s.observe("DeleteTeam", time.Now())
+	return s.db.DeleteTeam(ctx, teamID)
+}
+
+func (s *slowQueryDB) GetLeaguesForTeam(ctx context.Context, teamID int) ([]models.TeamLeague, error) {
+	defer s.observe("GetLeaguesForTeam", time.Now())
+	return s.db.GetLeaguesForTeam(ctx, teamID)
+}
+
+func (s *slowQueryDB) GetHeadToHead(ctx context.Context, teamID, opponentID, leagueID, limit int) ([]*models.Match, error) {
+	defer s.observe("GetHeadToHead", time.Now())
+	return s.db.GetHeadToHead(ctx, teamID, opponentID, leagueID, limit)
+}
+
+func (s *slowQueryDB) AddRivalry(ctx context.Context, teamID, rivalID int) error {
+	defer s.observe("AddRivalry", time.Now())
+	return s.db.AddRivalry(ctx, teamID, rivalID)
+}
+
+func (s *slowQueryDB) GetRivals(ctx context.Context, teamID int) ([]*models.Team, error) {
+	defer s.observe("GetRivals", time.Now())
+	return s.db.GetRivals(ctx, teamID)
+}
+
+func (s *slowQueryDB) IsRivalry(ctx context.Context, teamID, rivalID int) (bool, error) {
+	defer s.observe("IsRivalry", time.Now())
+	return s.db.IsRivalry(ctx, teamID, rivalID)
+}
+
+func (s *slowQueryDB) CreateLeague(ctx context.Context, req *models.CreateLeagueRequest) (*models.League, error) {
+	defer s.observe("CreateLeague", time.Now())
+	return s.db.CreateLeague(ctx, req)
+}
+
+func (s *slowQueryDB) CreateLeagueWithIdempotencyKey(ctx context.Context, key string, req *models.CreateLeagueRequest) (*models.League, bool, error) {
+	defer s.observe("CreateLeagueWithIdempotencyKey", time.Now())
+	return s.db.CreateLeagueWithIdempotencyKey(ctx, key, req)
+}
+
+func (s *slowQueryDB) AddTeamToLeague(ctx context.Context, leagueID, teamID int) error {
+	defer s.observe("AddTeamToLeague", time.Now())
+	return s.db.AddTeamToLeague(ctx, leagueID, teamID)
+}
+
+func (s *slowQueryDB) InitializeStanding(ctx context.Context, leagueID, teamID int) error {
+	defer s.observe("InitializeStanding", time.Now())
+	return s.db.InitializeStanding(ctx, leagueID, teamID)
+}
+
+func (s *slowQueryDB) InitializeLeagueWithTeams(ctx context.Context, req *models.CreateLeagueRequest, teams []*models.Team) (*models.League, error) {
+	defer s.observe("InitializeLeagueWithTeams", time.Now())
+	return s.db.InitializeLeagueWithTeams(ctx, req, teams)
+}
+
+func (s *slowQueryDB) CloneLeague(ctx context.Context, sourceLeagueID int, name string) (*models.League, error) {
+	defer s.observe("CloneLeague", time.Now())
+	return s.db.CloneLeague(ctx, sourceLeagueID, name)
+}
+
+func (s *slowQueryDB) ImportLeague(ctx context.Context, req *models.ImportLeagueRequest) (*models.League, []*models.Team, error) {
+	defer s.observe("ImportLeague", time.Now())
+	return s.db.ImportLeague(ctx, req)
+}
+
+func (s *slowQueryDB) GetDefaultTeams(ctx context.Context) ([]*models.Team, error) {
+	defer s.observe("GetDefaultTeams", time.Now())
+	return s.db.GetDefaultTeams(ctx)
+}
+
+func (s *slowQueryDB) GetLeagueByID(ctx context.Context, leagueID int) (*models.League, error) {
+	defer s.observe("GetLeagueByID", time.Now())
+	return s.db.GetLeagueByID(ctx, leagueID)
+}
+
+func (s *slowQueryDB) UpdateLeagueConfig(ctx context.Context, leagueID int, config models.LeagueConfig) error {
+	defer s.observe("UpdateLeagueConfig", time.Now())
+	return s.db.UpdateLeagueConfig(ctx, leagueID, config)
+}
+
+func (s *slowQueryDB) GetAllLeagues(ctx context.Context, status string) ([]*models.League, error) {
+	defer s.observe("GetAllLeagues", time.Now())
+	return s.db.GetAllLeagues(ctx, status)
+}
+
+func (s *slowQueryDB) GetLeagueSummary(ctx context.Context) (*models.LeagueSummary, error) {
+	defer s.observe("GetLeagueSummary", time.Now())
+	return s.db.GetLeagueSummary(ctx)
+}
+
+func (s *slowQueryDB) RecordLeagueEvent(ctx context.Context, leagueID int, eventType, details string) error {
+	defer s.observe("RecordLeagueEvent", time.Now())
+	return s.db.RecordLeagueEvent(ctx, leagueID, eventType, details)
+}
+
+func (s *slowQueryDB) GetLeagueEvents(ctx context.Context, leagueID int) ([]models.LeagueEvent, error) {
+	defer s.observe("GetLeagueEvents", time.Now())
+	return s.db.GetLeagueEvents(ctx, leagueID)
+}
+
+func (s *slowQueryDB) RecordSimulationRun(ctx context.Context, run models.SimulationRun) (*models.SimulationRun, error) {
+	defer s.observe("RecordSimulationRun", time.Now())
+	return s.db.RecordSimulationRun(ctx, run)
+}
+
+func (s *slowQueryDB) GetSimulationRuns(ctx context.Context, leagueID int) ([]models.SimulationRun, error) {
+	defer s.observe("GetSimulationRuns", time.Now())
+	return s.db.GetSimulationRuns(ctx, leagueID)
+}
+
+func (s *slowQueryDB) DeleteFinishedLeagues(ctx context.Context, finishedBefore time.Time) ([]int, error) {
+	defer s.observe("DeleteFinishedLeagues", time.Now())
+	return s.db.DeleteFinishedLeagues(ctx, finishedBefore)
+}
+
+func (s *slowQueryDB) GetLeaderboard(ctx context.Context, limit int) ([]models.LeaderboardEntry, error) {
+	defer s.observe("GetLeaderboard", time.Now())
+	return s.db.GetLeaderboard(ctx, limit)
+}
+
+func (s *slowQueryDB) RemoveTeamFromLeague(ctx context.Context, leagueID, teamID int) error {
+	defer s.observe("RemoveTeamFromLeague", time.Now())
+	return s.db.RemoveTeamFromLeague(ctx, leagueID, teamID)
+}
+
+func (s *slowQueryDB) GetTeamsInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	defer s.observe("GetTeamsInLeague", time.Now())
+	return s.db.GetTeamsInLeague(ctx, leagueID)
+}
+
+func (s *slowQueryDB) GetTeamsNotInLeague(ctx context.Context, leagueID int) ([]*models.Team, error) {
+	defer s.observe("GetTeamsNotInLeague", time.Now())
+	return s.db.GetTeamsNotInLeague(ctx, leagueID)
+}
+
+func (s *slowQueryDB) SetTeamGroup(ctx context.Context, leagueID, teamID int, group string) error {
+	defer s.observe("SetTeamGroup", time.Now())
+	return s.db.SetTeamGroup(ctx, leagueID, teamID, group)
+}
+
+func (s *slowQueryDB) GetTeamGroups(ctx context.Context, leagueID int) (map[int]string, error) {
+	defer s.observe("GetTeamGroups", time.Now())
+	return s.db.GetTeamGroups(ctx, leagueID)
+}
+
+func (s *slowQueryDB) CreateMatch(ctx context.Context, match *models.Match) (*models.Match, error) {
+	defer s.observe("CreateMatch", time.Now())
+	return s.db.CreateMatch(ctx, match)
+}
+
+func (s *slowQueryDB) ReplaceScheduledMatches(ctx context.Context, leagueID int, matches []models.Match) ([]*models.Match, error) {
+	defer s.observe("ReplaceScheduledMatches", time.Now())
+	return s.db.ReplaceScheduledMatches(ctx, leagueID, matches)
+}
+
+func (s *slowQueryDB) GetVenueCounts(ctx context.Context, leagueID, teamID int) (home, away int, err error) {
+	defer s.observe("GetVenueCounts", time.Now())
+	return s.db.GetVenueCounts(ctx, leagueID, teamID)
+}
+
+func (s *slowQueryDB) GetHomeAwaySplit(ctx context.Context, leagueID int) (*models.HomeAwaySplit, error) {
+	defer s.observe("GetHomeAwaySplit", time.Now())
+	return s.db.GetHomeAwaySplit(ctx, leagueID)
+}
+
+func (s *slowQueryDB) UpdateLeagueStatus(ctx context.Context, leagueID int, status string) error {
+	defer s.observe("UpdateLeagueStatus", time.Now())
+	return s.db.UpdateLeagueStatus(ctx, leagueID, status)
+}
+
+func (s *slowQueryDB) TransitionLeagueStatus(ctx context.Context, leagueID int, fromStatus, toStatus string) error {
+	defer s.observe("TransitionLeagueStatus", time.Now())
+	return s.db.TransitionLeagueStatus(ctx, leagueID, fromStatus, toStatus)
+}
+
+func (s *slowQueryDB) GetMatchesByWeekAndLeague(ctx context.Context, leagueID, week int) ([]*models.Match, error) {
+	defer s.observe("GetMatchesByWeekAndLeague", time.Now())
+	return s.db.GetMatchesByWeekAndLeague(ctx, leagueID, week)
+}
+
+func (s *slowQueryDB) GetMatchesByKickoffRange(ctx context.Context, leagueID int, from, to time.Time) ([]*models.Match, error) {
+	defer s.observe("GetMatchesByKickoffRange", time.Now())
+	return s.db.GetMatchesByKickoffRange(ctx, leagueID, from, to)
+}
+
+func (s *slowQueryDB) GetMatchesByLeague(ctx context.Context, leagueID int, status string) ([]*models.Match, error) {
+	defer s.observe("GetMatchesByLeague", time.Now())
+	return s.db.GetMatchesByLeague(ctx, leagueID, status)
+}
+
+func (s *slowQueryDB) PlayMatch(ctx context.Context, matchID, homeGoals, awayGoals int) error {
+	defer s.observe("PlayMatch", time.Now())
+	return s.db.PlayMatch(ctx, matchID, homeGoals, awayGoals)
+}
+
+func (s *slowQueryDB) AddStandingPoints(ctx context.Context, leagueID, teamID, points int) error {
+	defer s.observe("AddStandingPoints", time.Now())
+	return s.db.AddStandingPoints(ctx, leagueID, teamID, points)
+}
+
+func (s *slowQueryDB) UpdateStandings(ctx context.Context, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals int) error {
+	defer s.observe("UpdateStandings", time.Now())
+	return s.db.UpdateStandings(ctx, leagueID, homeTeamID, awayTeamID, homeGoals, awayGoals)
+}
+
+func (s *slowQueryDB) AdvanceLeagueWeek(ctx context.Context, leagueID int) error {
+	defer s.observe("AdvanceLeagueWeek", time.Now())
+	return s.db.AdvanceLeagueWeek(ctx, leagueID)
+}
+
+func (s *slowQueryDB) GetStandings(ctx context.Context, leagueID int) ([]models.StandingWithTeam, error) {
+	defer s.observe("GetStandings", time.Now())
+	return s.db.GetStandings(ctx, leagueID)
+}
+
+func (s *slowQueryDB) GetMatchByID(ctx context.Context, matchID int) (*models.Match, error) {
+	defer s.observe("GetMatchByID", time.Now())
+	return s.db.GetMatchByID(ctx, matchID)
+}
+
+func (s *slowQueryDB) UpdateMatchStatus(ctx context.Context, matchID int, fromStatus, toStatus string) error {
+	defer s.observe("UpdateMatchStatus", time.Now())
+	return s.db.UpdateMatchStatus(ctx, matchID, fromStatus, toStatus)
+}
+
+func (s *slowQueryDB) SwapMatchVenue(ctx context.Context, matchID int) error {
+	defer s.observe("SwapMatchVenue", time.Now())
+	return s.db.SwapMatchVenue(ctx, matchID)
+}
+
+func (s *slowQueryDB) EditMatch(ctx context.Context, matchID, newHomeGoals, newAwayGoals int) error {
+	defer s.observe("EditMatch", time.Now())
+	return s.db.EditMatch(ctx, matchID, newHomeGoals, newAwayGoals)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"insider-league-manager/internal/database"
+	"insider-league-manager/internal/models"
+)
+
+// delayedDB answers GetTeamByID after a delay; other Service methods are not used
+type delayedDB struct {
+	database.Service
+	delay time.Duration
+}
+
+func (db *delayedDB) GetTeamByID(ctx context.Context, teamID int) (*models.Team, error) {
+	time.Sleep(db.delay)
+	return &models.Team{ID: teamID, Name: "Alpha"}, nil
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		wantLog bool
+	}{
+		{"slow query", 30 * time.Millisecond, true},
+		{"fast query", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged []string
+			db := withSlowQueryLog(&delayedDB{delay: tt.delay}, 10*time.Millisecond)
+			db.logf = func(format string, args ...any) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}
+
+			team, err := db.GetTeamByID(context.Background(), 1)
+			if err != nil || team.Name != "Alpha" {
+				t.Fatalf("Expected the wrapped result, got %+v, %v", team, err)
+			}
+
+			if !tt.wantLog {
+				if len(logged) != 0 {
+					t.Errorf("Expected no slow query log, got %v", logged)
+				}
+				return
+			}
+			if len(logged) != 1 || !strings.Contains(logged[0], "WARN") || !strings.Contains(logged[0], "GetTeamByID") {
+				t.Errorf("Expected one warning naming GetTeamByID, got %v", logged)
+			}
+		})
+	}
+}
+
+func TestSlowQueryThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"250", 250 * time.Millisecond},
+		{"0", 0},
+		{"fast", 0},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SLOW_QUERY_MS", tt.value)
+		if got := slowQueryThreshold(); got != tt.expected {
+			t.Errorf("SLOW_QUERY_MS=%q: expected %s, got %s", tt.value, tt.expected, got)
+		}
+	}
+}